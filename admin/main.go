@@ -0,0 +1,154 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	pb "JuegoCeN/proto"
+	"JuegoCeN/sim"
+	"JuegoCeN/tlsutil"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const usage = `Uso: admin [-addr host:puerto] [-secret clave] [opciones TLS] <comando> [args]
+
+Comandos:
+  rooms                 lista las salas activas
+  state <sala>          muestra el GameState actual de una sala
+  end <sala>            termina la partida de una sala
+  kick <sala> <asiento> expulsa al jugador de ese asiento (1 a 4) de una sala
+  drain                 vacía la cola de emparejamiento
+
+La clave es la -admin-secret del servidor (por defecto, PINGPONG_ADMIN_SECRET).
+Opciones TLS: -tls, -tls-ca, -tls-server-name, -tls-cert, -tls-key
+`
+
+func main() {
+	addr := flag.String("addr", "localhost:50052", "dirección del servicio Admin")
+	secret := flag.String("secret", os.Getenv("PINGPONG_ADMIN_SECRET"), "clave de administración")
+	useTLS := flag.Bool("tls", false, "conectar con TLS usando las raíces del sistema")
+	var tlsCfg tlsutil.Config
+	flag.StringVar(&tlsCfg.CAFile, "tls-ca", "", "CA PEM con la que validar al servidor (implica -tls)")
+	flag.StringVar(&tlsCfg.ServerName, "tls-server-name", "", "nombre esperado en el certificado del servidor")
+	flag.StringVar(&tlsCfg.CertFile, "tls-cert", "", "certificado PEM de cliente para TLS mutuo")
+	flag.StringVar(&tlsCfg.KeyFile, "tls-key", "", "clave PEM de cliente para TLS mutuo")
+	flag.Usage = func() { fmt.Fprint(os.Stderr, usage) }
+	flag.Parse()
+	args := flag.Args()
+	if len(args) == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	creds, err := tlsCfg.ClientCredentials(*useTLS)
+	if err != nil {
+		log.Fatalf("TLS: %v", err)
+	}
+	conn, err := grpc.Dial(*addr, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		log.Fatalf("Dial failed: %v", err)
+	}
+	defer conn.Close()
+	client := pb.NewAdminClient(conn)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if *secret != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+*secret)
+	}
+
+	need := func(n int) {
+		if len(args) != n+1 {
+			flag.Usage()
+			os.Exit(2)
+		}
+	}
+
+	switch args[0] {
+	case "rooms":
+		need(0)
+		resp, err := client.ListRooms(ctx, &pb.ListRoomsRequest{})
+		if err != nil {
+			log.Fatalf("ListRooms: %v", err)
+		}
+		if len(resp.Rooms) == 0 {
+			fmt.Println("No hay salas activas")
+			return
+		}
+		fmt.Printf("%-6s %-40s %-9s %s\n", "SALA", "JUGADORES", "MARCADOR", "INFRACCIONES")
+		for _, r := range resp.Rooms {
+			counts := make([]string, len(r.Violations))
+			for i, n := range r.Violations {
+				counts[i] = fmt.Sprint(n)
+			}
+			fmt.Printf("%-6s %-40s %-9s %s\n", r.RoomCode, strings.Join(r.Players, ","),
+				fmt.Sprintf("%d - %d", r.Score1, r.Score2), strings.Join(counts, ","))
+		}
+
+	case "state":
+		need(1)
+		st, err := client.GetRoomState(ctx, &pb.RoomRequest{RoomCode: args[1]})
+		if err != nil {
+			log.Fatalf("GetRoomState: %v", err)
+		}
+		printState(os.Stdout, st)
+
+	case "end":
+		need(1)
+		reply, err := client.EndMatch(ctx, &pb.RoomRequest{RoomCode: args[1]})
+		if err != nil {
+			log.Fatalf("EndMatch: %v", err)
+		}
+		fmt.Println(reply.Message)
+
+	case "kick":
+		need(2)
+		reply, err := client.KickPlayer(ctx, &pb.KickPlayerRequest{RoomCode: args[1], PlayerId: args[2]})
+		if err != nil {
+			log.Fatalf("KickPlayer: %v", err)
+		}
+		fmt.Println(reply.Message)
+
+	case "drain":
+		need(0)
+		resp, err := client.DrainQueue(ctx, &pb.DrainQueueRequest{})
+		if err != nil {
+			log.Fatalf("DrainQueue: %v", err)
+		}
+		fmt.Printf("Cola vaciada: %d jugadores\n", resp.Drained)
+
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+}
+
+// printState escribe el estado de una sala: las bolas y las dos palas de
+// un duelo o, en una arena, una pala por asiento con su marcador.
+func printState(w io.Writer, st *pb.GameState) {
+	fmt.Fprintf(w, "Sala %s\n", st.RoomCode)
+	if sim.MultiBall(st) {
+		for _, b := range st.Balls {
+			fmt.Fprintf(w, "  Bola %d:  (%.3f, %.3f)\n", b.Id, b.Pos.X, b.Pos.Y)
+		}
+	} else {
+		fmt.Fprintf(w, "  Bola:    (%.3f, %.3f)\n", st.Ball.X, st.Ball.Y)
+	}
+	if len(st.Paddles) > 0 {
+		for i, p := range st.Paddles {
+			fmt.Fprintf(w, "  Pala %d:  (%.3f, %.3f) %-6s %d  %s\n", i+1, p.Pos.X, p.Pos.Y, p.Side, p.Score, p.Name)
+		}
+		return
+	}
+	fmt.Fprintf(w, "  Pala 1:  (%.3f, %.3f)\n", st.Paddle1.X, st.Paddle1.Y)
+	fmt.Fprintf(w, "  Pala 2:  (%.3f, %.3f)\n", st.Paddle2.X, st.Paddle2.Y)
+	fmt.Fprintf(w, "  Marcador: %d - %d\n", st.Score1, st.Score2)
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	pb "JuegoCeN/proto"
+	"JuegoCeN/sim"
+)
+
+func TestPrintState(t *testing.T) {
+	duel := sim.NewState("D")
+	duel.Score1 = 3
+	arena := sim.NewArena("A", 4)
+	arena.Paddles[2].Name, arena.Paddles[2].Score = "Carlos", 2
+
+	for _, tc := range []struct {
+		name string
+		st   *pb.GameState
+		want []string
+	}{
+		{"duelo", duel, []string{"Sala D", "Pala 2:", "Marcador: 3 - 0"}},
+		// Las arenas no tienen Paddle1 ni Paddle2
+		{"arena", arena, []string{"Sala A", "Pala 4:", "2  Carlos"}},
+		{"equipos", sim.NewTeamArena("E"), []string{"Pala 4:"}},
+		{"varias bolas", sim.NewMultiBall("M", 3, sim.Classic), []string{"Bola 3:", "Pala 1:"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			var b strings.Builder
+			printState(&b, tc.st)
+			for _, w := range tc.want {
+				if !strings.Contains(b.String(), w) {
+					t.Errorf("falta %q en:\n%s", w, b.String())
+				}
+			}
+		})
+	}
+}
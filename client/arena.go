@@ -0,0 +1,92 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"golang.org/x/image/font/basicfont"
+
+	pb "JuegoCeN/proto"
+	"JuegoCeN/sim"
+)
+
+// Las salas de cuatro (modos FOUR y TEAMS) llegan con GameState.Paddles:
+// una pala por asiento, cada una con su marcador. En la de dos contra dos
+// el marcador de los equipos va en Score1 y Score2.
+
+// ownPaddle devuelve la pala del jugador en una arena, o nil.
+func ownPaddle(st *pb.GameState, playerID string) *pb.Paddle {
+	for i, p := range st.Paddles {
+		if fmt.Sprintf("%d", i+1) == playerID {
+			return p
+		}
+	}
+	return nil
+}
+
+// arenaMove traduce el teclado a la acción de la pala propia: W/S en las
+// de los lados y A/D en las de arriba y abajo.
+func arenaMove(st *pb.GameState, playerID string) string {
+	p := ownPaddle(st, playerID)
+	switch {
+	case p == nil:
+	case sim.Horizontal(p.Side) && ebiten.IsKeyPressed(ebiten.KeyA):
+		return "LEFT"
+	case sim.Horizontal(p.Side) && ebiten.IsKeyPressed(ebiten.KeyD):
+		return "RIGHT"
+	case !sim.Horizontal(p.Side) && ebiten.IsKeyPressed(ebiten.KeyW):
+		return "UP"
+	case !sim.Horizontal(p.Side) && ebiten.IsKeyPressed(ebiten.KeyS):
+		return "DOWN"
+	}
+	return "NONE"
+}
+
+// drawArena dibuja las palas de una arena, la propia en amarillo, y el
+// marcador de cada jugador junto a su lado, o bajo su pala si juegan por
+// equipos.
+func drawArena(screen *ebiten.Image, st *pb.GameState, playerID string) {
+	w, h := screen.Size()
+	r := sim.RulesOf(st)
+	paddleW := float64(r.PaddleW) * float64(w) / float64(r.ScreenW)
+	paddleH := float64(r.PaddleH) * float64(h) / float64(r.ScreenH)
+	face := basicfont.Face7x13
+	mine := ownPaddle(st, playerID)
+	teams := sim.Teams(st)
+	if teams {
+		msg := fmt.Sprintf("%s  %d - %d  %s", ascii(st.Name1), st.Score1, st.Score2, ascii(st.Name2))
+		text.Draw(screen, msg, face, (w-len(msg)*7)/2, 20, color.White)
+	}
+
+	for _, p := range st.Paddles {
+		clr := color.Color(color.White)
+		if p == mine {
+			clr = color.RGBA{255, 220, 0, 255}
+		}
+		cx, cy := float64(p.Pos.X)*float64(w), float64(p.Pos.Y)*float64(h)
+		pw, ph := paddleW, paddleH
+		if sim.Horizontal(p.Side) {
+			pw, ph = ph, pw
+		}
+		ebitenutil.DrawRect(screen, cx-pw/2, cy-ph/2, pw, ph, clr)
+
+		label := fmt.Sprintf("%s  %d", ascii(p.Name), p.Score)
+		var x, y int
+		switch {
+		case teams:
+			x, y = int(cx)-len(label)*7/2, h-10
+		case p.Side == pb.Side_LEFT:
+			x, y = 10, h/2-60
+		case p.Side == pb.Side_RIGHT:
+			x, y = w-10-len(label)*7, h/2-60
+		case p.Side == pb.Side_TOP:
+			x, y = (w-len(label)*7)/2, 20
+		case p.Side == pb.Side_BOTTOM:
+			x, y = (w-len(label)*7)/2, h-10
+		}
+		text.Draw(screen, label, face, x, y, clr)
+	}
+}
@@ -0,0 +1,263 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"image/color"
+	"strings"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"golang.org/x/image/font/basicfont"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	pb "JuegoCeN/proto"
+)
+
+const (
+	bracketLines   = 16
+	bracketRefresh = 2 * time.Second
+)
+
+var formatLabels = map[pb.TournamentFormat]string{
+	pb.TournamentFormat_SINGLE_ELIMINATION: "Eliminacion simple",
+	pb.TournamentFormat_DOUBLE_ELIMINATION: "Doble eliminacion",
+	pb.TournamentFormat_ROUND_ROBIN:        "Liga",
+}
+
+var sideLabels = map[pb.BracketSide]string{
+	pb.BracketSide_WINNERS: "Ronda",
+	pb.BracketSide_LOSERS:  "Perdedores",
+	pb.BracketSide_FINAL:   "Gran final",
+	pb.BracketSide_LEAGUE:  "Jornada",
+}
+
+type bracketResult struct {
+	resp *pb.TournamentBracket
+	err  error
+}
+
+// authed añade el token del jugador a ctx.
+func authed(ctx context.Context, token string) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+token)
+}
+
+// createTournament crea un torneo ("simple", "doble" o "liga") y devuelve su id.
+func createTournament(conn *grpc.ClientConn, token, format, name string) (string, error) {
+	formats := map[string]pb.TournamentFormat{
+		"simple": pb.TournamentFormat_SINGLE_ELIMINATION,
+		"doble":  pb.TournamentFormat_DOUBLE_ELIMINATION,
+		"liga":   pb.TournamentFormat_ROUND_ROBIN,
+	}
+	f, ok := formats[format]
+	if !ok {
+		return "", fmt.Errorf("formato %q desconocido (simple, doble o liga)", format)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	b, err := pb.NewTournamentClient(conn).CreateTournament(authed(ctx, token),
+		&pb.CreateTournamentRequest{Name: name, Format: f})
+	if err != nil {
+		return "", err
+	}
+	return b.TournamentId, nil
+}
+
+// joinTournament inscribe al jugador; si ya lo estaba no pasa nada.
+func joinTournament(conn *grpc.ClientConn, token, id string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err := pb.NewTournamentClient(conn).JoinTournament(authed(ctx, token),
+		&pb.TournamentRequest{TournamentId: id})
+	return err
+}
+
+// bracketView es la pantalla del cuadro del torneo (StateBracket). Se
+// refresca sola; quien creó el torneo puede empezarlo con S.
+type bracketView struct {
+	id      string
+	bracket *pb.TournamentBracket
+	err     error
+	scroll  int
+	results chan bracketResult
+	fetched time.Time
+	back    Button
+}
+
+func (v *bracketView) open(tc pb.TournamentClient, id string) {
+	*v = bracketView{
+		id:      id,
+		results: make(chan bracketResult, 4),
+		back:    Button{label: "Volver", x: 300, y: 530, w: 200, h: 50},
+	}
+	v.fetch(tc)
+}
+
+func (v *bracketView) fetch(tc pb.TournamentClient) {
+	v.fetched = time.Now()
+	id, results := v.id, v.results
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		resp, err := tc.GetBracket(ctx, &pb.TournamentRequest{TournamentId: id})
+		results <- bracketResult{resp, err}
+	}()
+}
+
+// start pide empezar el torneo con el token del jugador.
+func (v *bracketView) start(tc pb.TournamentClient, token string) {
+	id, results := v.id, v.results
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		resp, err := tc.StartTournament(authed(ctx, token), &pb.TournamentRequest{TournamentId: id})
+		results <- bracketResult{resp, err}
+	}()
+}
+
+// update procesa teclado, ratón y respuestas; devuelve true para volver al menú.
+func (v *bracketView) update(tc pb.TournamentClient, token string) bool {
+	select {
+	case r := <-v.results:
+		v.err = r.err
+		if r.resp != nil {
+			v.bracket = r.resp
+		}
+	default:
+		if time.Since(v.fetched) > bracketRefresh {
+			v.fetch(tc)
+		}
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		return true
+	}
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		if x, y := ebiten.CursorPosition(); v.back.contains(x, y) {
+			return true
+		}
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyS) && v.bracket != nil &&
+		v.bracket.Status == pb.TournamentStatus_TOURNAMENT_REGISTERING {
+		v.start(tc, token)
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyUp) && v.scroll > 0 {
+		v.scroll--
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyDown) && v.scroll+bracketLines < len(v.lines()) {
+		v.scroll++
+	}
+	return false
+}
+
+// lines es el cuadro como texto: clasificación (en liga) y partidos por
+// ronda.
+func (v *bracketView) lines() []string {
+	b := v.bracket
+	if b == nil {
+		return nil
+	}
+	var out []string
+	if b.Status == pb.TournamentStatus_TOURNAMENT_REGISTERING {
+		out = append(out, fmt.Sprintf("Inscritos (%d):", len(b.Players)))
+		for _, p := range b.Players {
+			out = append(out, "  "+ascii(p.DisplayName))
+		}
+		return out
+	}
+
+	if len(b.Standings) > 0 {
+		out = append(out, fmt.Sprintf("%-16s %3s %3s %3s %7s", "Jugador", "PJ", "G", "P", "Puntos"))
+		for _, s := range b.Standings {
+			out = append(out, fmt.Sprintf("%-16s %3d %3d %3d %3d-%-3d",
+				ascii(s.DisplayName), s.Played, s.Wins, s.Losses, s.PointsFor, s.PointsAgainst))
+		}
+		out = append(out, "")
+	}
+
+	var side pb.BracketSide = -1
+	round := int32(-1)
+	for _, m := range b.Matches {
+		if m.Side != side || m.Round != round {
+			side, round = m.Side, m.Round
+			out = append(out, fmt.Sprintf("%s %d", sideLabels[side], round))
+		}
+		out = append(out, "  "+matchLine(m))
+	}
+	return out
+}
+
+func matchLine(m *pb.BracketMatch) string {
+	name := func(n string) string {
+		if n == "" {
+			if m.Bye {
+				return "-"
+			}
+			return "?"
+		}
+		return ascii(n)
+	}
+	line := fmt.Sprintf("%-16s %-16s ", name(m.Player1), name(m.Player2))
+	switch m.Status {
+	case pb.BracketMatchStatus_MATCH_PLAYING:
+		line += "jugando en " + m.RoomCode
+	case pb.BracketMatchStatus_MATCH_READY:
+		line += "esperando jugadores"
+	case pb.BracketMatchStatus_MATCH_DONE:
+		switch {
+		case m.Bye && m.Winner == 1:
+			line += "pasa " + ascii(m.Player1)
+		case m.Bye && m.Winner == 2:
+			line += "pasa " + ascii(m.Player2)
+		case m.Walkover && m.Winner == 1:
+			line += "gana " + ascii(m.Player1) + " (no se presento el rival)"
+		case m.Walkover && m.Winner == 2:
+			line += "gana " + ascii(m.Player2) + " (no se presento el rival)"
+		case m.Winner != 0:
+			line += fmt.Sprintf("%d-%d", m.Score1, m.Score2)
+		}
+	}
+	return strings.TrimRight(line, " ")
+}
+
+func (v *bracketView) draw(screen *ebiten.Image) {
+	w, h := screen.Size()
+	ebitenutil.DrawRect(screen, 0, 0, float64(w), float64(h), color.RGBA{0, 0, 0, 200})
+	face := basicfont.Face7x13
+
+	title := "Torneo " + v.id
+	info := ""
+	if b := v.bracket; b != nil {
+		title = fmt.Sprintf("%s (%s) - %s", ascii(b.Name), b.TournamentId, formatLabels[b.Format])
+		switch b.Status {
+		case pb.TournamentStatus_TOURNAMENT_REGISTERING:
+			info = "Inscripcion abierta; " + ascii(b.Creator) + " puede empezarlo con S"
+		case pb.TournamentStatus_TOURNAMENT_RUNNING:
+			info = "En juego"
+		case pb.TournamentStatus_TOURNAMENT_FINISHED:
+			info = "Campeon: " + ascii(b.Champion)
+		}
+	}
+	text.Draw(screen, title, face, (w-len(title)*7)/2, 40, color.White)
+	text.Draw(screen, info, face, (w-len(info)*7)/2, 60, color.RGBA{200, 200, 255, 255})
+
+	const left = 120
+	switch {
+	case v.err != nil:
+		text.Draw(screen, "Error: "+ascii(v.err.Error()), face, left, 95, color.RGBA{255, 120, 120, 255})
+	case v.bracket == nil:
+		text.Draw(screen, "Cargando...", face, left, 95, color.White)
+	}
+	lines := v.lines()
+	for i := v.scroll; i < len(lines) && i < v.scroll+bracketLines; i++ {
+		text.Draw(screen, lines[i], face, left, 120+(i-v.scroll)*22, color.White)
+	}
+
+	help := "Flechas desplazar   Esc volver"
+	text.Draw(screen, help, face, (w-len(help)*7)/2, 500, color.RGBA{180, 180, 180, 255})
+	v.back.draw(screen)
+}
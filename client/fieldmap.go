@@ -0,0 +1,36 @@
+package main
+
+import (
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
+
+	pb "JuegoCeN/proto"
+)
+
+var mapColor = color.RGBA{150, 150, 170, 255}
+
+// drawMap dibuja el mapa de un duelo: el fondo fuera de la portería,
+// las paredes y los obstáculos, donde estén en este tick.
+func drawMap(screen *ebiten.Image, m *pb.FieldMap) {
+	w, h := screen.Size()
+	fw, fh := float32(w), float32(h)
+
+	if g := m.GoalWidth; g > 0 {
+		top, bottom := (0.5-g/2)*fh, (0.5+g/2)*fh
+		for _, x := range []float32{2, fw - 2} {
+			vector.StrokeLine(screen, x, 0, x, top, 4, mapColor, false)
+			vector.StrokeLine(screen, x, bottom, x, fh, 4, mapColor, false)
+		}
+	}
+	for _, s := range m.Walls {
+		vector.StrokeLine(screen, s.A.X*fw, s.A.Y*fh, s.B.X*fw, s.B.Y*fh, 3, mapColor, true)
+	}
+	for _, o := range m.Obstacles {
+		ebitenutil.DrawRect(screen,
+			float64((o.Pos.X-o.W/2)*fw), float64((o.Pos.Y-o.H/2)*fh),
+			float64(o.W*fw), float64(o.H*fh), mapColor)
+	}
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"image/color"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"golang.org/x/image/font/basicfont"
+
+	pb "JuegoCeN/proto"
+)
+
+const leaderboardPageSize = 10
+
+// periodLabels van sin tildes, como todos los textos de pantalla:
+// basicfont solo tiene glifos ASCII.
+var periodLabels = map[pb.Period]string{
+	pb.Period_PERIOD_ALL:   "Historica",
+	pb.Period_PERIOD_DAY:   "Ultimo dia",
+	pb.Period_PERIOD_WEEK:  "Ultima semana",
+	pb.Period_PERIOD_MONTH: "Ultimo mes",
+}
+
+// leaderboardPage es la respuesta de una petición junto con lo que se pidió,
+// para descartar respuestas que ya no corresponden a la vista.
+type leaderboardPage struct {
+	period pb.Period
+	offset int32
+	resp   *pb.LeaderboardResponse
+	err    error
+}
+
+// leaderboardView es la pantalla de clasificación (StateLeaderboard).
+type leaderboardView struct {
+	period  pb.Period
+	offset  int32
+	page    *pb.LeaderboardResponse
+	err     error
+	loading bool
+	results chan leaderboardPage
+	back    Button
+}
+
+// open reinicia la vista y pide la primera página.
+func (v *leaderboardView) open(history pb.HistoryClient) {
+	v.period = pb.Period_PERIOD_ALL
+	v.offset = 0
+	v.page = nil
+	v.results = make(chan leaderboardPage, 4)
+	v.back = Button{label: "Volver", x: 300, y: 530, w: 200, h: 50}
+	v.fetch(history)
+}
+
+func (v *leaderboardView) fetch(history pb.HistoryClient) {
+	v.loading = true
+	v.err = nil
+	period, offset, results := v.period, v.offset, v.results
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		resp, err := history.GetLeaderboard(ctx, &pb.LeaderboardRequest{
+			Period: period,
+			Limit:  leaderboardPageSize,
+			Offset: offset,
+		})
+		results <- leaderboardPage{period: period, offset: offset, resp: resp, err: err}
+	}()
+}
+
+// update procesa teclado, ratón y respuestas; devuelve true para volver al menú.
+func (v *leaderboardView) update(history pb.HistoryClient) bool {
+	select {
+	case r := <-v.results:
+		if r.period == v.period && r.offset == v.offset {
+			v.page, v.err, v.loading = r.resp, r.err, false
+		}
+	default:
+	}
+
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		return true
+	}
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
+		if x, y := ebiten.CursorPosition(); v.back.contains(x, y) {
+			return true
+		}
+	}
+
+	// Periodo con las teclas 1-4
+	for i, k := range []ebiten.Key{ebiten.Key1, ebiten.Key2, ebiten.Key3, ebiten.Key4} {
+		if p := pb.Period(i); inpututil.IsKeyJustPressed(k) && p != v.period {
+			v.period, v.offset = p, 0
+			v.fetch(history)
+		}
+	}
+
+	// Paginación con las flechas
+	if inpututil.IsKeyJustPressed(ebiten.KeyLeft) && v.offset > 0 {
+		v.offset -= leaderboardPageSize
+		v.fetch(history)
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyRight) && v.page != nil &&
+		v.offset+leaderboardPageSize < v.page.Total {
+		v.offset += leaderboardPageSize
+		v.fetch(history)
+	}
+	return false
+}
+
+func (v *leaderboardView) draw(screen *ebiten.Image) {
+	w, h := screen.Size()
+	ebitenutil.DrawRect(screen, 0, 0, float64(w), float64(h), color.RGBA{0, 0, 0, 200})
+
+	face := basicfont.Face7x13
+	title := "Clasificacion - " + periodLabels[v.period]
+	text.Draw(screen, title, face, (w-len(title)*7)/2, 60, color.White)
+
+	const left = 150
+	text.Draw(screen, fmt.Sprintf("%-4s %-16s %7s %5s %5s", "#", "Jugador", "Puntos", "G", "P"),
+		face, left, 110, color.RGBA{200, 200, 255, 255})
+
+	switch {
+	case v.err != nil:
+		text.Draw(screen, "Error: "+v.err.Error(), face, left, 140, color.RGBA{255, 120, 120, 255})
+	case v.page == nil:
+		text.Draw(screen, "Cargando...", face, left, 140, color.White)
+	case len(v.page.Entries) == 0:
+		text.Draw(screen, "Nadie ha jugado en este periodo", face, left, 140, color.White)
+	default:
+		for i, e := range v.page.Entries {
+			line := fmt.Sprintf("%-4d %-16s %7.0f %5d %5d",
+				e.Rank, ascii(e.DisplayName), e.Rating, e.Wins, e.Losses)
+			text.Draw(screen, line, face, left, 140+i*25, color.White)
+		}
+		pages := (v.page.Total + leaderboardPageSize - 1) / leaderboardPageSize
+		info := fmt.Sprintf("Pagina %d/%d", v.offset/leaderboardPageSize+1, pages)
+		if v.loading {
+			info += "  (cargando)"
+		}
+		text.Draw(screen, info, face, left, 405, color.White)
+	}
+
+	help := "<-/-> pagina   1-4 periodo   Esc volver"
+	text.Draw(screen, help, face, (w-len(help)*7)/2, 500, color.RGBA{180, 180, 180, 255})
+	v.back.draw(screen)
+}
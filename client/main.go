@@ -2,18 +2,29 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"image/color"
 	"log"
+	"os"
+	"path/filepath"
+	"strings"
 	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
 	"github.com/hajimehoshi/ebiten/v2/text"
 	"golang.org/x/image/font/basicfont"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 
 	pb "JuegoCeN/proto"
+	"JuegoCeN/replay"
+	"JuegoCeN/sim"
+	"JuegoCeN/tlsutil"
 )
 
 type State int
@@ -23,6 +34,10 @@ const (
 	StateWaiting
 	StatePlaying
 	StateOpponentLeft
+	StateLeaderboard
+	StateReplay
+	StateMatchOver
+	StateBracket
 )
 
 type Button struct {
@@ -31,8 +46,22 @@ type Button struct {
 	onClick    func()
 }
 
+// contains indica si el punto (x, y) de pantalla cae dentro del botón.
+func (b Button) contains(x, y int) bool {
+	return float64(x) >= b.x && float64(x) <= b.x+b.w &&
+		float64(y) >= b.y && float64(y) <= b.y+b.h
+}
+
+func (b Button) draw(screen *ebiten.Image) {
+	ebitenutil.DrawRect(screen, b.x, b.y, b.w, b.h, color.RGBA{100, 100, 200, 255})
+	text.Draw(screen, b.label, basicfont.Face7x13,
+		int(b.x+20), int(b.y+30), color.White)
+}
+
 type Game struct {
 	client      pb.PingPongClient
+	history     pb.HistoryClient
+	tournament  pb.TournamentClient
 	conn        *grpc.ClientConn
 	stream      pb.PingPong_PlayClient
 	state       State
@@ -41,14 +70,28 @@ type Game struct {
 	menuBg      *ebiten.Image
 	gameBg      *ebiten.Image
 	button      Button
+	boardButton Button
+	board       leaderboardView
+	modeButtons []Button // un botón por modo de juego; no están en un torneo
+	bracketBtn  Button
+	bracket     bracketView
+	viewer      replayViewer
 	gameState   *pb.GameState
 	playerID    string
+	token       string
+	displayName string
+	tourneyID   string // torneo en el que se juega; vacío = cola normal
+	mode        string // cola elegida: "" (duelo) o el modo de GameAction.mode
+	mapName     string // mapa pedido para los duelos (-map)
 	joiningDone bool
 	leftAt      time.Time
+	leftMsg     string
+	rematch     rematchOffer
 	lastUpdate  time.Time
 }
 
-func NewGame(client pb.PingPongClient, conn *grpc.ClientConn) *Game {
+// loadImages carga los fondos del menú y del campo.
+func loadImages() (menu, game *ebiten.Image) {
 	menuImg, _, err := ebitenutil.NewImageFromFile("client/robot.png")
 	if err != nil {
 		log.Fatalf("No se pudo cargar robot.png: %v", err)
@@ -57,42 +100,102 @@ func NewGame(client pb.PingPongClient, conn *grpc.ClientConn) *Game {
 	if err != nil {
 		log.Fatalf("No se pudo cargar fondo.png: %v", err)
 	}
+	return menuImg, gameImg
+}
+
+func NewGame(client pb.PingPongClient, conn *grpc.ClientConn, login *pb.LoginResponse, tourneyID string) *Game {
+	menuImg, gameImg := loadImages()
 
 	g := &Game{
-		client:     client,
-		conn:       conn,
-		state:      StateMenu,
-		menuBg:     menuImg,
-		gameBg:     gameImg,
-		lastUpdate: time.Now(),
+		client:      client,
+		history:     pb.NewHistoryClient(conn),
+		tournament:  pb.NewTournamentClient(conn),
+		conn:        conn,
+		state:       StateMenu,
+		menuBg:      menuImg,
+		gameBg:      gameImg,
+		lastUpdate:  time.Now(),
+		token:       login.Token,
+		displayName: login.DisplayName,
+		tourneyID:   tourneyID,
+	}
+
+	joinLabel := "Unirse a una partida"
+	if tourneyID != "" {
+		joinLabel = "Jugar el torneo " + tourneyID
 	}
 
 	g.button = Button{
-		label: "Unirse a una partida",
+		label: joinLabel,
 		x:     300, y: 280, w: 200, h: 50,
+		onClick: func() { g.join("") },
+	}
+
+	// Los demás modos de juego, en tres columnas bajo la clasificación y
+	// por encima de donde quedan los «Volver» de las otras pantallas
+	for i, m := range []struct{ mode, label string }{
+		{"SPEED", "Partida rapida"},
+		{"FOUR", "Partida a cuatro"},
+		{"MULTI", "Varias bolas"},
+		{"TEAMS", "Dos contra dos"},
+		{"PRACTICE", "Practica"},
+	} {
+		mode := m.mode
+		g.modeButtons = append(g.modeButtons, Button{
+			label: m.label,
+			x:     float64(80 + i%3*220), y: float64(410 + i/3*65), w: 200, h: 50,
+			onClick: func() { g.join(mode) },
+		})
+	}
+
+	g.boardButton = Button{
+		label: "Clasificacion",
+		x:     300, y: 345, w: 200, h: 50,
 		onClick: func() {
-			g.state = StateWaiting
-			g.joiningDone = false
-			g.gameState = nil
-			g.playerID = ""
-			g.updates = make(chan *pb.GameState, 1)
-			g.errChan = make(chan error, 1)
-			// abrir stream
-			stream, err := g.client.Play(context.Background())
-			if err != nil {
-				log.Printf("No se pudo abrir Play: %v", err)
-				g.state = StateMenu
-				return
-			}
-			g.stream = stream
-			go g.receiveUpdates()
+			g.state = StateLeaderboard
+			g.board.open(g.history)
+		},
+	}
+
+	g.bracketBtn = Button{
+		label: "Cuadro del torneo",
+		x:     300, y: 410, w: 200, h: 50,
+		onClick: func() {
+			g.state = StateBracket
+			g.bracket.open(g.tournament, g.tourneyID)
 		},
 	}
 
 	return g
 }
 
+// join abre el stream de Play para buscar partida en la cola del modo dado.
+func (g *Game) join(mode string) {
+	g.state = StateWaiting
+	g.mode = mode
+	g.joiningDone = false
+	g.gameState = nil
+	g.playerID = ""
+	g.updates = make(chan *pb.GameState, 1)
+	g.errChan = make(chan error, 1)
+	// abrir stream autenticado
+	ctx := metadata.AppendToOutgoingContext(context.Background(),
+		"authorization", "Bearer "+g.token)
+	stream, err := g.client.Play(ctx)
+	if err != nil {
+		log.Printf("No se pudo abrir Play: %v", err)
+		g.state = StateMenu
+		return
+	}
+	g.stream = stream
+	go g.receiveUpdates()
+}
+
+// receiveUpdates pasa los estados al bucle del juego. La configuración de
+// la partida (GameState.Mode) solo llega en el primer estado de cada sala,
+// así que se copia a los siguientes: alguno puede descartarse.
 func (g *Game) receiveUpdates() {
+	var mode *pb.GameMode
 	for {
 		st, err := g.stream.Recv()
 		if err != nil {
@@ -100,6 +203,11 @@ func (g *Game) receiveUpdates() {
 			return
 		}
 		g.lastUpdate = time.Now()
+		if st.Mode != nil {
+			mode = st.Mode
+		} else {
+			st.Mode = mode
+		}
 		select {
 		case g.updates <- st:
 		default:
@@ -107,23 +215,67 @@ func (g *Game) receiveUpdates() {
 	}
 }
 
+// streamEnded pasa a la pantalla de aviso con el motivo por el que el
+// servidor cerró el stream.
+func (g *Game) streamEnded(err error) {
+	g.leftMsg = "El oponente abandonó la partida"
+	if st, ok := status.FromError(err); ok && st.Code() != codes.Unknown && st.Message() != "" {
+		g.leftMsg = st.Message()
+	}
+	g.state = StateOpponentLeft
+	g.leftAt = time.Now()
+}
+
+// ascii quita las tildes de los textos que vienen del servidor, que
+// basicfont no sabe dibujar.
+var ascii = strings.NewReplacer(
+	"á", "a", "é", "e", "í", "i", "ó", "o", "ú", "u", "ü", "u", "ñ", "n",
+	"Á", "A", "É", "E", "Í", "I", "Ó", "O", "Ú", "U", "Ü", "U", "Ñ", "N",
+	"¡", "", "¿", "",
+).Replace
+
 func (g *Game) Update() error {
 	switch g.state {
 	case StateMenu:
-		if ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+		if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) {
 			x, y := ebiten.CursorPosition()
-			if float64(x) >= g.button.x && float64(x) <= g.button.x+g.button.w &&
-				float64(y) >= g.button.y && float64(y) <= g.button.y+g.button.h {
+			if g.button.contains(x, y) {
 				g.button.onClick()
+			} else if g.boardButton.contains(x, y) {
+				g.boardButton.onClick()
+			} else if g.tourneyID != "" && g.bracketBtn.contains(x, y) {
+				g.bracketBtn.onClick()
+			} else if g.tourneyID == "" {
+				for _, b := range g.modeButtons {
+					if b.contains(x, y) {
+						b.onClick()
+						break
+					}
+				}
 			}
 		}
 
+	case StateLeaderboard:
+		if g.board.update(g.history) {
+			g.state = StateMenu
+		}
+
+	case StateBracket:
+		if g.bracket.update(g.tournament, g.token) {
+			g.state = StateMenu
+		}
+
+	case StateReplay:
+		if g.viewer.update() {
+			// El visor solo se abre desde la línea de órdenes
+			return ebiten.Termination
+		}
+
 	case StateWaiting:
 		select {
 		case err := <-g.errChan:
 			log.Printf("Error de stream en espera: %v", err)
-			g.state = StateOpponentLeft
-			g.leftAt = time.Now()
+			g.streamEnded(err)
 			return nil
 		case st := <-g.updates:
 			g.gameState = st
@@ -132,7 +284,12 @@ func (g *Game) Update() error {
 			return nil
 		default:
 			if !g.joiningDone {
-				g.stream.Send(&pb.GameAction{RoomCode: ""})
+				first := &pb.GameAction{RoomCode: "", TournamentId: g.tourneyID, Mode: g.mode}
+				switch g.mode {
+				case "", "SPEED", "MULTI", "PRACTICE":
+					first.MapName = g.mapName
+				}
+				g.stream.Send(first)
 				g.joiningDone = true
 			}
 		}
@@ -140,6 +297,7 @@ func (g *Game) Update() error {
 	case StatePlaying:
 		if time.Since(g.lastUpdate) > 2*time.Second {
 			g.state = StateOpponentLeft
+			g.leftMsg = "El oponente abandonó la partida"
 			g.leftAt = time.Now()
 			return nil
 		}
@@ -147,19 +305,30 @@ func (g *Game) Update() error {
 		select {
 		case err := <-g.errChan:
 			log.Printf("Error de stream en juego: %v", err)
-			g.state = StateOpponentLeft
-			g.leftAt = time.Now()
+			g.streamEnded(err)
 			return nil
 		case st := <-g.updates:
 			g.gameState = st
+			if st.Finished {
+				g.state = StateMatchOver
+				g.rematch = newRematchOffer(st)
+				return nil
+			}
 		default:
 		}
 
 		if g.gameState != nil {
 			move := "NONE"
-			if ebiten.IsKeyPressed(ebiten.KeyW) {
+			switch {
+			case inpututil.IsKeyJustPressed(ebiten.KeyP):
+				move = "PAUSE"
+			case inpututil.IsKeyJustPressed(ebiten.KeyR):
+				move = "RESUME"
+			case len(g.gameState.Paddles) > 0:
+				move = arenaMove(g.gameState, g.playerID)
+			case ebiten.IsKeyPressed(ebiten.KeyW):
 				move = "UP"
-			} else if ebiten.IsKeyPressed(ebiten.KeyS) {
+			case ebiten.IsKeyPressed(ebiten.KeyS):
 				move = "DOWN"
 			}
 			g.stream.Send(&pb.GameAction{
@@ -169,6 +338,9 @@ func (g *Game) Update() error {
 			})
 		}
 
+	case StateMatchOver:
+		g.updateMatchOver()
+
 	case StateOpponentLeft:
 		if time.Since(g.leftAt) > 3*time.Second {
 			if g.stream != nil {
@@ -180,23 +352,95 @@ func (g *Game) Update() error {
 	return nil
 }
 
-func (g *Game) Draw(screen *ebiten.Image) {
-	// Definiciones que coinciden con el servidor:
-	const (
-		paddleW  = 10.0
-		paddleH  = 80.0
-		ballRad  = 8.0
-		ballSize = ballRad * 2
-		margin   = 10.0
-	)
+// drawField dibuja el campo (fondo, bola, palas y marcador) de un estado,
+// con las medidas del modo de la sala (GameState.Mode), que son las mismas
+// con las que simula el servidor. La usan tanto la partida en vivo como el
+// visor de repeticiones.
+func (g *Game) drawField(screen *ebiten.Image, st *pb.GameState) {
+	screen.DrawImage(g.gameBg, nil)
+	if st == nil {
+		return
+	}
+	w, h := screen.Size()
+	r := sim.RulesOf(st)
+	sx, sy := float64(w)/float64(r.ScreenW), float64(h)/float64(r.ScreenH)
+	if st.Map != nil {
+		drawMap(screen, st.Map)
+	}
+
+	// Bola, o todas las del modo de varias bolas (en gris las que esperan
+	// en el centro para sacar), y las adicionales de los potenciadores
+	// (gris claro)
+	ballW, ballH := 2*float64(r.BallRadius)*sx, 2*float64(r.BallRadius)*sy
+	drawBall := func(pos *pb.Vector, c color.Color) {
+		bx, by := float64(pos.X)*float64(w), float64(pos.Y)*float64(h)
+		ebitenutil.DrawRect(screen, bx-ballW/2, by-ballH/2, ballW, ballH, c)
+	}
+	if !sim.MultiBall(st) {
+		drawBall(st.Ball, color.White)
+	}
+	for _, b := range st.Balls {
+		var c color.Color = color.White
+		switch {
+		case b.Transient:
+			c = color.RGBA{200, 200, 200, 255}
+		case b.Wait > 0:
+			c = color.RGBA{120, 120, 120, 255}
+		}
+		drawBall(b.Pos, c)
+	}
+
+	if len(st.Paddles) > 0 {
+		drawArena(screen, st, g.playerID)
+		return
+	}
+
+	// Palas centradas en su posición (más largas con la pala grande); en
+	// la práctica, a la derecha hay una pared
+	drawPaddle := func(pos *pb.Vector, player int32) {
+		pw := float64(r.PaddleW) * sx
+		ph := float64(r.PaddleH*sim.PaddleScale(st, player)) * sy
+		px, py := float64(pos.X)*float64(w), float64(pos.Y)*float64(h)
+		ebitenutil.DrawRect(screen, px-pw/2, py-ph/2, pw, ph, color.White)
+	}
+	drawPaddle(st.Paddle1, 1)
+	if r.Wall {
+		ebitenutil.DrawRect(screen, float64(w)-6, 0, 6, float64(h), color.RGBA{150, 150, 170, 255})
+	} else {
+		drawPaddle(st.Paddle2, 2)
+	}
+
+	// Marcador con los nombres de los jugadores
+	text.Draw(screen, fmt.Sprintf("%s  %d", ascii(st.Name1), st.Score1),
+		basicfont.Face7x13, w/4, 20, color.White)
+	text.Draw(screen, fmt.Sprintf("%d  %s", st.Score2, ascii(st.Name2)),
+		basicfont.Face7x13, 3*w/4, 20, color.White)
+
+	// Marcador de la serie (juegos ganados por cada lado)
+	if st.SeriesLength > 1 {
+		msg := fmt.Sprintf("Juego %d/%d  Serie %d-%d", st.SeriesGame, st.SeriesLength,
+			st.SeriesWins1, st.SeriesWins2)
+		text.Draw(screen, msg, basicfont.Face7x13, (w-len(msg)*7)/2, 52, color.White)
+	}
+
+	drawPowerUps(screen, st)
+}
 
+func (g *Game) Draw(screen *ebiten.Image) {
 	switch g.state {
 	case StateMenu:
 		screen.DrawImage(g.menuBg, nil)
-		ebitenutil.DrawRect(screen, g.button.x, g.button.y, g.button.w, g.button.h,
-			color.RGBA{100, 100, 200, 255})
-		text.Draw(screen, g.button.label, basicfont.Face7x13,
-			int(g.button.x+20), int(g.button.y+30), color.White)
+		g.button.draw(screen)
+		g.boardButton.draw(screen)
+		if g.tourneyID != "" {
+			g.bracketBtn.draw(screen)
+		} else {
+			for _, b := range g.modeButtons {
+				b.draw(screen)
+			}
+		}
+		text.Draw(screen, "Jugador: "+ascii(g.displayName), basicfont.Face7x13,
+			10, 20, color.White)
 
 	case StateWaiting:
 		screen.DrawImage(g.menuBg, nil)
@@ -204,53 +448,60 @@ func (g *Game) Draw(screen *ebiten.Image) {
 		ebitenutil.DrawRect(screen, 0, 0, float64(w), float64(h),
 			color.RGBA{0, 0, 0, 180})
 		msg := "Esperando jugador..."
+		switch g.mode {
+		case "FOUR", "TEAMS":
+			msg = "Esperando a otros tres jugadores..."
+		case "PRACTICE":
+			msg = "Preparando la practica..."
+		}
 		textWidth := len(msg) * 7
 		text.Draw(screen, msg, basicfont.Face7x13,
 			(w-textWidth)/2, h/2, color.White)
 
 	case StatePlaying:
-		screen.DrawImage(g.gameBg, nil)
-		if g.gameState != nil {
+		g.drawField(screen, g.gameState)
+		// Las arenas y la práctica no tienen puntuación en juego ni pausas
+		if g.gameState != nil && len(g.gameState.Paddles) == 0 && !sim.RulesOf(g.gameState).Wall {
 			w, h := screen.Size()
 
-			// Bola
-			bx := float64(g.gameState.Ball.X) * float64(w)
-			by := float64(g.gameState.Ball.Y) * float64(h)
-			ebitenutil.DrawRect(screen,
-				bx-ballRad, by-ballRad,
-				ballSize, ballSize,
-				color.White,
-			)
-
-			// Pala izquierda
-			p1y := float64(g.gameState.Paddle1.Y) * float64(h)
-			ebitenutil.DrawRect(screen,
-				margin, p1y-paddleH/2,
-				paddleW, paddleH,
-				color.White,
-			)
-
-			// Pala derecha
-			p2y := float64(g.gameState.Paddle2.Y) * float64(h)
-			ebitenutil.DrawRect(screen,
-				float64(w)-margin-paddleW, p2y-paddleH/2,
-				paddleW, paddleH,
-				color.White,
-			)
-
-			// Marcador
-			text.Draw(screen, fmt.Sprintf("%d", g.gameState.Score1),
-				basicfont.Face7x13, w/4, 20, color.White)
-			text.Draw(screen, fmt.Sprintf("%d", g.gameState.Score2),
-				basicfont.Face7x13, 3*w/4, 20, color.White)
+			// Puntuación Elo y probabilidad de victoria propia
+			text.Draw(screen, fmt.Sprintf("(%.0f)", g.gameState.Rating1),
+				basicfont.Face7x13, w/4, 36, color.White)
+			text.Draw(screen, fmt.Sprintf("(%.0f)", g.gameState.Rating2),
+				basicfont.Face7x13, 3*w/4, 36, color.White)
+			winProb := g.gameState.WinProb1
+			if g.playerID == "2" {
+				winProb = 1 - winProb
+			}
+			msg := fmt.Sprintf("Prob. de victoria: %.0f%%", winProb*100)
+			text.Draw(screen, msg, basicfont.Face7x13,
+				(w-len(msg)*7)/2, h-12, color.White)
+
+			drawPause(screen, g.gameState, g.playerID)
 		}
 
+	case StateReplay:
+		g.drawField(screen, g.viewer.state())
+		g.viewer.draw(screen)
+
+	case StateLeaderboard:
+		screen.DrawImage(g.menuBg, nil)
+		g.board.draw(screen)
+
+	case StateBracket:
+		screen.DrawImage(g.menuBg, nil)
+		g.bracket.draw(screen)
+
+	case StateMatchOver:
+		g.drawField(screen, g.gameState)
+		drawMatchOver(screen, g.gameState, g.playerID, g.rematch, g.tourneyID != "")
+
 	case StateOpponentLeft:
 		screen.DrawImage(g.menuBg, nil)
 		w, h := screen.Size()
 		ebitenutil.DrawRect(screen, 0, 0, float64(w), float64(h),
 			color.RGBA{0, 0, 0, 180})
-		msg := "El oponente abandonó la partida"
+		msg := ascii(g.leftMsg)
 		textWidth := len(msg) * 7
 		text.Draw(screen, msg, basicfont.Face7x13,
 			(w-textWidth)/2, h/2, color.White)
@@ -261,19 +512,129 @@ func (g *Game) Layout(outsideWidth, outsideHeight int) (int, int) {
 	return 800, 600
 }
 
+// defaultTokenFile devuelve dónde se guarda el token entre sesiones.
+func defaultTokenFile() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "jugador.token"
+	}
+	return filepath.Join(dir, "JuegoCeN", "token")
+}
+
+// login obtiene un token del servidor reutilizando el guardado, de forma
+// que el jugador conserve su identidad, y guarda el nuevo.
+func login(conn *grpc.ClientConn, name, tokenFile string) (*pb.LoginResponse, error) {
+	auth := pb.NewAuthClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	prev, _ := os.ReadFile(tokenFile)
+	resp, err := auth.Login(ctx, &pb.LoginRequest{
+		DisplayName: name,
+		Token:       strings.TrimSpace(string(prev)),
+	})
+	if status.Code(err) == codes.Unauthenticated && len(prev) > 0 {
+		// El token guardado no es de este servidor: pedimos identidad nueva
+		log.Printf("Token guardado rechazado, creando jugador nuevo: %v", err)
+		resp, err = auth.Login(ctx, &pb.LoginRequest{DisplayName: name})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(tokenFile), 0o700); err == nil {
+		if err := os.WriteFile(tokenFile, []byte(resp.Token), 0o600); err != nil {
+			log.Printf("No se pudo guardar el token: %v", err)
+		}
+	}
+	return resp, nil
+}
+
+// runReplay abre la ventana en modo visor de repeticiones.
+func runReplay(rep *pb.Replay) {
+	log.Printf("Reproduciendo %s: %s vs %s", rep.MatchId, rep.Name1, rep.Name2)
+	ebiten.SetWindowTitle("Ping Pong - Repeticion")
+	if err := ebiten.RunGame(NewReplayGame(rep)); err != nil {
+		log.Fatalf("Game exited: %v", err)
+	}
+}
+
 func main() {
+	addr := flag.String("addr", "localhost:50051", "dirección del servidor")
+	name := flag.String("name", os.Getenv("USER"), "nombre visible del jugador")
+	tokenFile := flag.String("token-file", defaultTokenFile(), "fichero donde se guarda el token")
+	useTLS := flag.Bool("tls", false, "conectar con TLS usando las raíces del sistema")
+	var tlsCfg tlsutil.Config
+	flag.StringVar(&tlsCfg.CAFile, "tls-ca", "", "CA PEM con la que validar al servidor (implica -tls)")
+	flag.StringVar(&tlsCfg.ServerName, "tls-server-name", "", "nombre esperado en el certificado del servidor")
+	flag.StringVar(&tlsCfg.CertFile, "tls-cert", "", "certificado PEM de cliente para TLS mutuo")
+	flag.StringVar(&tlsCfg.KeyFile, "tls-key", "", "clave PEM de cliente para TLS mutuo")
+	replayFile := flag.String("replay", "", "reproducir un fichero de repetición sin conectarse")
+	replayRoom := flag.String("replay-room", "", "descargar y reproducir la última partida de esta sala")
+	replayMatch := flag.String("replay-match", "", "descargar y reproducir esta partida (id de partida)")
+	tourneyID := flag.String("tournament", "", "inscribirse y jugar en este torneo")
+	newTourney := flag.String("new-tournament", "", "crear un torneo (simple, doble o liga) y jugar en él")
+	tourneyName := flag.String("tournament-name", "", "nombre del torneo nuevo")
+	mapName := flag.String("map", "", "mapa en el que jugar los duelos (de los que ofrezca el servidor)")
+	flag.Parse()
+
+	ebiten.SetWindowSize(800, 600)
+	ebiten.SetRunnableOnUnfocused(true)
+
+	if *replayFile != "" {
+		rep, err := replay.ReadFile(*replayFile)
+		if err != nil {
+			log.Fatalf("No se pudo leer la repetición: %v", err)
+		}
+		runReplay(rep)
+		return
+	}
+
+	creds, err := tlsCfg.ClientCredentials(*useTLS)
+	if err != nil {
+		log.Fatalf("TLS: %v", err)
+	}
+
 	// Conectar gRPC
-	conn, err := grpc.Dial("localhost:50051", grpc.WithInsecure())
+	conn, err := grpc.Dial(*addr, grpc.WithTransportCredentials(creds))
 	if err != nil {
 		log.Fatalf("Dial failed: %v", err)
 	}
 	defer conn.Close()
 	client := pb.NewPingPongClient(conn)
 
-	game := NewGame(client, conn)
-	ebiten.SetWindowSize(800, 600)
+	if *replayRoom != "" || *replayMatch != "" {
+		rep, err := downloadReplay(conn, *replayRoom, *replayMatch)
+		if err != nil {
+			log.Fatalf("No se pudo descargar la repetición: %v", err)
+		}
+		runReplay(rep)
+		return
+	}
+
+	session, err := login(conn, *name, *tokenFile)
+	if err != nil {
+		log.Fatalf("Login failed: %v", err)
+	}
+	log.Printf("Conectado como %s (%s)", session.DisplayName, session.PlayerId)
+
+	if *newTourney != "" {
+		id, err := createTournament(conn, session.Token, *newTourney, *tourneyName)
+		if err != nil {
+			log.Fatalf("No se pudo crear el torneo: %v", err)
+		}
+		log.Printf("Torneo %s creado; los demás se apuntan con -tournament %s", id, id)
+		*tourneyID = id
+	}
+	if *tourneyID != "" {
+		if err := joinTournament(conn, session.Token, *tourneyID); err != nil {
+			log.Printf("Inscripción en el torneo %s: %v", *tourneyID, err)
+		}
+	}
+
+	game := NewGame(client, conn, session, *tourneyID)
+	game.mapName = *mapName
 	ebiten.SetWindowTitle("Ping Pong Multijugador")
-	ebiten.SetRunnableOnUnfocused(true)
 	if err := ebiten.RunGame(game); err != nil {
 		log.Fatalf("Game exited: %v", err)
 	}
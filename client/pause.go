@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"golang.org/x/image/font/basicfont"
+
+	pb "JuegoCeN/proto"
+)
+
+// drawPause dibuja las pausas que le quedan al jugador y, si la partida
+// está en pausa, la capa con quién la pidió y el estado de la reanudación.
+func drawPause(screen *ebiten.Image, st *pb.GameState, playerID string) {
+	w, h := screen.Size()
+	face := basicfont.Face7x13
+
+	left, mine := st.PausesLeft1, st.ResumeReady1
+	if playerID == "2" {
+		left, mine = st.PausesLeft2, st.ResumeReady2
+	}
+	text.Draw(screen, fmt.Sprintf("Pausas: %d (P)", left), face, 10, h-12, color.White)
+
+	if !st.Paused {
+		return
+	}
+	ebitenutil.DrawRect(screen, 0, 0, float64(w), float64(h), color.RGBA{0, 0, 0, 160})
+
+	by := st.Name1
+	if st.PausedBy == 2 {
+		by = st.Name2
+	}
+	lines := []string{"PAUSA", "Pedida por " + ascii(by), ""}
+	ready := func(name string, ok bool) string {
+		if ok {
+			return ascii(name) + ": listo"
+		}
+		return ascii(name) + ": esperando"
+	}
+	lines = append(lines, ready(st.Name1, st.ResumeReady1), ready(st.Name2, st.ResumeReady2), "")
+	switch {
+	case st.ResumeInMs > 0:
+		lines = append(lines, fmt.Sprintf("Reanudando en %d", (st.ResumeInMs+999)/1000))
+	case mine:
+		lines = append(lines, "Esperando al rival...")
+	default:
+		lines = append(lines, "Pulsa R para reanudar")
+	}
+
+	y := h/2 - len(lines)*18/2
+	for _, l := range lines {
+		text.Draw(screen, l, face, (w-len(l)*7)/2, y, color.White)
+		y += 18
+	}
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"golang.org/x/image/font/basicfont"
+
+	pb "JuegoCeN/proto"
+	"JuegoCeN/sim"
+)
+
+// Aspecto de cada potenciador: letra sobre el objeto, color y nombre en
+// la lista de efectos.
+var powerUpLooks = map[pb.PowerUpKind]struct {
+	letter string
+	color  color.RGBA
+	name   string
+}{
+	pb.PowerUpKind_BIG_PADDLE: {"G", color.RGBA{80, 200, 80, 255}, "Pala grande"},
+	pb.PowerUpKind_FAST_BALL:  {"R", color.RGBA{230, 90, 60, 255}, "Bola rapida"},
+	pb.PowerUpKind_MULTI_BALL: {"M", color.RGBA{80, 140, 230, 255}, "Multibola"},
+	pb.PowerUpKind_REVERSE:    {"I", color.RGBA{180, 90, 200, 255}, "Controles invertidos"},
+}
+
+// drawPowerUps dibuja los objetos del campo y, bajo el marcador de cada
+// jugador, sus efectos con los segundos que les quedan. Las bolas
+// adicionales se dibujan con las demás.
+func drawPowerUps(screen *ebiten.Image, st *pb.GameState) {
+	if !st.PowerUps {
+		return
+	}
+	w, h := screen.Size()
+	face := basicfont.Face7x13
+	rules := sim.RulesOf(st)
+
+	const r = sim.ItemRadius
+	for _, it := range st.Items {
+		look := powerUpLooks[it.Kind]
+		x, y := float64(it.Pos.X)*float64(w), float64(it.Pos.Y)*float64(h)
+		ebitenutil.DrawRect(screen, x-r, y-r, 2*r, 2*r, look.color)
+		text.Draw(screen, look.letter, face, int(x)-3, int(y)+4, color.White)
+	}
+
+	// La bola rápida afecta a los dos, pero se apunta a quien la recogió
+	top := 56 // debajo de la puntuación de cada jugador
+	if st.SeriesLength > 1 {
+		top = 72 // y del marcador de la serie
+	}
+	lines := map[int32]int{}
+	for _, e := range st.Effects {
+		look := powerUpLooks[e.Kind]
+		secs := float64(e.TicksLeft) * float64(rules.TickMs) / 1000
+		msg := fmt.Sprintf("%s %.0fs", look.name, secs)
+		x := w / 4
+		if e.Player == 2 {
+			x = 3 * w / 4
+		}
+		text.Draw(screen, msg, face, x, top+lines[e.Player]*16, look.color)
+		lines[e.Player]++
+	}
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"fmt"
+	"image/color"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"golang.org/x/image/font/basicfont"
+
+	pb "JuegoCeN/proto"
+)
+
+// rematchOffer es el plazo de revancha visto desde el cliente.
+type rematchOffer struct {
+	deadline time.Time
+	sent     bool
+}
+
+// newRematchOffer arranca el plazo con lo que queda según el servidor: el
+// de la revancha o la espera hasta el siguiente juego de la serie.
+func newRematchOffer(st *pb.GameState) rematchOffer {
+	ms := st.RematchInMs
+	if st.NextGameInMs > 0 {
+		ms = st.NextGameInMs
+	}
+	return rematchOffer{deadline: time.Now().Add(time.Duration(ms) * time.Millisecond)}
+}
+
+// updateMatchOver atiende la pantalla de fin de partida: Y ofrece o acepta
+// la revancha y N (o Esc) vuelve al menú. Si ambos aceptan, o si sigue la
+// serie, llega el estado de la sala nueva por el mismo stream.
+func (g *Game) updateMatchOver() {
+	select {
+	case err := <-g.errChan:
+		g.streamEnded(err)
+		return
+	case st := <-g.updates:
+		if !st.Finished {
+			g.gameState, g.playerID = st, st.PlayerId
+			g.state = StatePlaying
+			return
+		}
+		g.gameState = st
+	default:
+	}
+
+	switch {
+	// En un torneo no hay revancha: se espera al siguiente partido
+	case g.tourneyID != "":
+		if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+			g.stream.CloseSend()
+			g.state = StateMenu
+		}
+	case !g.rematch.sent && g.gameState.NextGameInMs == 0 && inpututil.IsKeyJustPressed(ebiten.KeyY):
+		g.stream.Send(&pb.GameAction{Move: "REMATCH"})
+		g.rematch.sent = true
+	case inpututil.IsKeyJustPressed(ebiten.KeyN), inpututil.IsKeyJustPressed(ebiten.KeyEscape),
+		// Por si el aviso del servidor no llega
+		time.Now().After(g.rematch.deadline.Add(2 * time.Second)):
+		g.stream.Send(&pb.GameAction{Move: "LEAVE"})
+		g.stream.CloseSend()
+		g.state = StateMenu
+	}
+}
+
+// drawMatchOver dibuja el resultado y el estado de la revancha (o, en un
+// torneo, la espera al siguiente partido).
+func drawMatchOver(screen *ebiten.Image, st *pb.GameState, playerID string, offer rematchOffer, inTournament bool) {
+	w, h := screen.Size()
+	ebitenutil.DrawRect(screen, 0, 0, float64(w), float64(h), color.RGBA{0, 0, 0, 160})
+
+	mine, theirs, rival := st.RematchOffer1, st.RematchOffer2, st.Name2
+	if playerID == "2" {
+		mine, theirs, rival = st.RematchOffer2, st.RematchOffer1, st.Name1
+	}
+	won := fmt.Sprint(st.Winner) == playerID
+	title := "HAS PERDIDO"
+	if won {
+		title = "HAS GANADO"
+	}
+	if st.SeriesLength > 1 {
+		if st.NextGameInMs > 0 {
+			title += fmt.Sprintf(" EL JUEGO %d", st.SeriesGame)
+		} else {
+			title += " LA SERIE"
+		}
+	}
+	lines := []string{title, ascii(st.EndReason), ""}
+	if inTournament {
+		lines = append(lines, "Esperando el resultado del torneo...", "Esc: salir")
+		drawLines(screen, lines)
+		return
+	}
+	rival = ascii(rival)
+	switch {
+	case st.NextGameInMs > 0:
+		lines = append(lines, fmt.Sprintf("Serie %d-%d: siguiente juego con los lados cambiados",
+			st.SeriesWins1, st.SeriesWins2), "Esc: abandonar la serie")
+	case mine:
+		lines = append(lines, "Esperando a "+rival+"...")
+	case theirs:
+		lines = append(lines, rival+" quiere la revancha", "Y: aceptar   N: volver al menu")
+	default:
+		lines = append(lines, "Y: pedir revancha   N: volver al menu")
+	}
+	left := time.Until(offer.deadline)
+	if left < 0 {
+		left = 0
+	}
+	lines = append(lines, "", fmt.Sprintf("Quedan %d s", int(left.Seconds()+0.999)))
+	drawLines(screen, lines)
+}
+
+// drawLines centra las líneas en pantalla.
+func drawLines(screen *ebiten.Image, lines []string) {
+	w, h := screen.Size()
+	y := h/2 - len(lines)*18/2
+	for _, l := range lines {
+		text.Draw(screen, l, basicfont.Face7x13, (w-len(l)*7)/2, y, color.White)
+		y += 18
+	}
+}
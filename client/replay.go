@@ -0,0 +1,229 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image/color"
+	"io"
+	"time"
+
+	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hajimehoshi/ebiten/v2/ebitenutil"
+	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/text"
+	"golang.org/x/image/font/basicfont"
+	"google.golang.org/grpc"
+
+	pb "JuegoCeN/proto"
+	"JuegoCeN/replay"
+)
+
+// Velocidades de reproducción disponibles; empieza en 1x.
+var replaySpeeds = []float64{0.25, 0.5, 1, 2, 4}
+
+const defaultSpeed = 2
+
+// Barra de búsqueda en la parte inferior de la pantalla.
+const (
+	seekX = 50.0
+	seekY = 560.0
+	seekW = 700.0
+	seekH = 8.0
+)
+
+var (
+	colorPlayer1 = color.RGBA{90, 170, 255, 255}
+	colorPlayer2 = color.RGBA{255, 120, 90, 255}
+)
+
+// replayViewer es el visor de repeticiones (StateReplay). pos es el tick
+// actual con decimales, para poder avanzar a velocidades menores que 1x.
+type replayViewer struct {
+	player   *replay.Player
+	points   []replay.Point
+	pos      float64
+	paused   bool
+	speedIdx int
+	dragging bool
+	cached   *pb.GameState
+	cachedAt uint32
+}
+
+// open prepara el visor para una repetición.
+func (v *replayViewer) open(rep *pb.Replay) {
+	*v = replayViewer{
+		player:   replay.NewPlayer(rep),
+		speedIdx: defaultSpeed,
+	}
+	v.points = v.player.Points()
+}
+
+func (v *replayViewer) total() float64 { return float64(v.player.TotalTicks()) }
+
+func (v *replayViewer) seek(pos float64) {
+	v.pos = min(max(pos, 0), v.total())
+}
+
+// update procesa la entrada y avanza la reproducción. Devuelve true si el
+// usuario quiere salir.
+func (v *replayViewer) update() bool {
+	if inpututil.IsKeyJustPressed(ebiten.KeyEscape) {
+		return true
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeySpace) {
+		if !v.paused && v.pos >= v.total() {
+			v.pos = 0
+		}
+		v.paused = !v.paused
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyUp) && v.speedIdx < len(replaySpeeds)-1 {
+		v.speedIdx++
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyDown) && v.speedIdx > 0 {
+		v.speedIdx--
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyHome) {
+		v.seek(0)
+	}
+	if inpututil.IsKeyJustPressed(ebiten.KeyEnd) {
+		v.seek(v.total())
+	}
+	// Fotograma a fotograma: solo en pausa
+	if v.paused {
+		if inpututil.IsKeyJustPressed(ebiten.KeyPeriod) || inpututil.IsKeyJustPressed(ebiten.KeyRight) {
+			v.seek(float64(int(v.pos) + 1))
+		}
+		if inpututil.IsKeyJustPressed(ebiten.KeyComma) || inpututil.IsKeyJustPressed(ebiten.KeyLeft) {
+			v.seek(float64(int(v.pos) - 1))
+		}
+	}
+
+	x, y := ebiten.CursorPosition()
+	if inpututil.IsMouseButtonJustPressed(ebiten.MouseButtonLeft) &&
+		float64(x) >= seekX-5 && float64(x) <= seekX+seekW+5 &&
+		float64(y) >= seekY-10 && float64(y) <= seekY+seekH+10 {
+		v.dragging = true
+	}
+	if !ebiten.IsMouseButtonPressed(ebiten.MouseButtonLeft) {
+		v.dragging = false
+	}
+	if v.dragging {
+		v.seek((float64(x) - seekX) / seekW * v.total())
+		return false
+	}
+
+	if !v.paused {
+		// Ebiten llama a Update 60 veces por segundo
+		ticksPerFrame := (1000.0 / 60) / float64(v.player.TickDuration().Milliseconds())
+		v.seek(v.pos + ticksPerFrame*replaySpeeds[v.speedIdx])
+		if v.pos >= v.total() {
+			v.paused = true
+		}
+	}
+	return false
+}
+
+// state devuelve el estado del tick actual; se recalcula solo al cambiar.
+func (v *replayViewer) state() *pb.GameState {
+	tick := uint32(v.pos)
+	if v.cached == nil || v.cachedAt != tick {
+		v.cached = v.player.StateAt(tick)
+		v.cachedAt = tick
+		// StateAt solo reconstruye la física: los nombres del marcador
+		// salen de la cabecera de la repetición
+		rep := v.player.Replay()
+		v.cached.Name1, v.cached.Name2 = rep.Name1, rep.Name2
+	}
+	return v.cached
+}
+
+func (v *replayViewer) draw(screen *ebiten.Image) {
+	rep := v.player.Replay()
+	total := v.total()
+
+	// Barra con la posición actual y un marcador por cada punto
+	ebitenutil.DrawRect(screen, seekX, seekY, seekW, seekH, color.RGBA{60, 60, 60, 220})
+	if total > 0 {
+		ebitenutil.DrawRect(screen, seekX, seekY, seekW*v.pos/total, seekH,
+			color.RGBA{200, 200, 200, 255})
+		for _, pt := range v.points {
+			c := colorPlayer1
+			if pt.Player == 2 {
+				c = colorPlayer2
+			}
+			px := seekX + seekW*float64(pt.Tick)/total
+			ebitenutil.DrawRect(screen, px-1, seekY-6, 3, 6, c)
+		}
+		ebitenutil.DrawRect(screen, seekX+seekW*v.pos/total-2, seekY-3, 4, seekH+6, color.White)
+	}
+
+	// Línea temporal del marcador: el último punto antes de la posición actual
+	timeline := "Sin puntos"
+	for _, pt := range v.points {
+		if float64(pt.Tick) > v.pos {
+			break
+		}
+		timeline = fmt.Sprintf("Ultimo punto: %d-%d", pt.Score1, pt.Score2)
+	}
+
+	tick := v.player.TickDuration()
+	elapsed := time.Duration(v.pos) * tick
+	length := time.Duration(total) * tick
+	speed := fmt.Sprintf("%gx", replaySpeeds[v.speedIdx])
+	if v.paused {
+		speed = "pausa"
+	}
+	status := fmt.Sprintf("%s / %s  [%s]  tick %d/%d  %s",
+		clock(elapsed), clock(length), speed, int(v.pos), int(total), timeline)
+	text.Draw(screen, status, basicfont.Face7x13, int(seekX), int(seekY)+28, color.White)
+
+	header := fmt.Sprintf("Repeticion %s - sala %s", rep.MatchId, rep.RoomCode)
+	text.Draw(screen, header, basicfont.Face7x13, 10, 50, color.White)
+	help := "Espacio: pausa  ,/.: fotograma  Arriba/Abajo: velocidad  Inicio/Fin  Esc: salir"
+	text.Draw(screen, help, basicfont.Face7x13, int(seekX), int(seekY)-14, color.RGBA{180, 180, 180, 255})
+}
+
+// clock formatea una duración como m:ss.
+func clock(d time.Duration) string {
+	s := int(d.Seconds())
+	return fmt.Sprintf("%d:%02d", s/60, s%60)
+}
+
+// NewReplayGame crea un juego que solo reproduce una partida grabada.
+func NewReplayGame(rep *pb.Replay) *Game {
+	menuImg, gameImg := loadImages()
+	g := &Game{
+		state:  StateReplay,
+		menuBg: menuImg,
+		gameBg: gameImg,
+	}
+	g.viewer.open(rep)
+	return g
+}
+
+// downloadReplay descarga del servidor la última repetición de una sala, o
+// una partida concreta si matchID no está vacío.
+func downloadReplay(conn *grpc.ClientConn, roomCode, matchID string) (*pb.Replay, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+	stream, err := pb.NewReplaysClient(conn).DownloadReplay(ctx, &pb.ReplayRequest{
+		RoomCode: roomCode,
+		MatchId:  matchID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(chunk.Data)
+	}
+	return replay.Decode(&buf)
+}
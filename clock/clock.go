@@ -0,0 +1,162 @@
+// Package clock abstrae el paso del tiempo para que el servidor pueda
+// usar el reloj real en producción y uno manual en los tests.
+package clock
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock da la hora y crea temporizadores.
+type Clock interface {
+	Now() time.Time
+	// After es como time.After.
+	After(d time.Duration) <-chan time.Time
+	// NewTicker es como time.NewTicker.
+	NewTicker(d time.Duration) Ticker
+}
+
+// Ticker es la parte de time.Ticker que usa el servidor.
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+// Real es el reloj del sistema.
+type Real struct{}
+
+func (Real) Now() time.Time                         { return time.Now() }
+func (Real) After(d time.Duration) <-chan time.Time { return time.After(d) }
+func (Real) NewTicker(d time.Duration) Ticker       { return realTicker{time.NewTicker(d)} }
+
+type realTicker struct{ t *time.Ticker }
+
+func (t realTicker) C() <-chan time.Time { return t.t.C }
+func (t realTicker) Stop()               { t.t.Stop() }
+
+// Manual es un reloj que solo avanza al llamar a Advance. Los temporizadores
+// vencidos se disparan en orden dentro de Advance; como time.Ticker, un
+// ticker cuyo canal está lleno pierde el tick.
+type Manual struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	now     time.Time
+	waiters []*waiter
+}
+
+type waiter struct {
+	at     time.Time
+	period time.Duration // 0 para After
+	c      chan time.Time
+}
+
+// NewManual crea un reloj manual parado en start.
+func NewManual(start time.Time) *Manual {
+	m := &Manual{now: start}
+	m.cond = sync.NewCond(&m.mu)
+	return m
+}
+
+func (m *Manual) Now() time.Time {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.now
+}
+
+func (m *Manual) After(d time.Duration) <-chan time.Time {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	w := &waiter{at: m.now.Add(d), c: make(chan time.Time, 1)}
+	if d <= 0 {
+		w.c <- m.now
+		return w.c
+	}
+	m.add(w)
+	return w.c
+}
+
+func (m *Manual) NewTicker(d time.Duration) Ticker {
+	if d <= 0 {
+		panic("clock: periodo no positivo en NewTicker")
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	w := &waiter{at: m.now.Add(d), period: d, c: make(chan time.Time, 1)}
+	m.add(w)
+	return &manualTicker{m: m, w: w}
+}
+
+// add requiere m.mu.
+func (m *Manual) add(w *waiter) {
+	m.waiters = append(m.waiters, w)
+	m.cond.Broadcast()
+}
+
+// remove requiere m.mu.
+func (m *Manual) remove(w *waiter) {
+	for i, x := range m.waiters {
+		if x == w {
+			m.waiters = append(m.waiters[:i], m.waiters[i+1:]...)
+			return
+		}
+	}
+}
+
+// Advance adelanta el reloj d y dispara, en orden, los temporizadores que
+// venzan por el camino.
+func (m *Manual) Advance(d time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	end := m.now.Add(d)
+	for {
+		sort.SliceStable(m.waiters, func(i, j int) bool {
+			return m.waiters[i].at.Before(m.waiters[j].at)
+		})
+		if len(m.waiters) == 0 || m.waiters[0].at.After(end) {
+			break
+		}
+		w := m.waiters[0]
+		m.now = w.at
+		select {
+		case w.c <- w.at:
+		default:
+		}
+		if w.period > 0 {
+			w.at = w.at.Add(w.period)
+		} else {
+			m.waiters = m.waiters[1:]
+		}
+	}
+	m.now = end
+}
+
+// Waiters devuelve cuántos temporizadores y tickers hay pendientes.
+func (m *Manual) Waiters() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.waiters)
+}
+
+// BlockUntil espera a que haya al menos n temporizadores pendientes; sirve
+// para saber que una goroutine ya está esperando antes de llamar a Advance.
+func (m *Manual) BlockUntil(n int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for len(m.waiters) < n {
+		m.cond.Wait()
+	}
+}
+
+type manualTicker struct {
+	m *Manual
+	w *waiter
+}
+
+func (t *manualTicker) C() <-chan time.Time { return t.w.c }
+
+func (t *manualTicker) Stop() {
+	t.m.mu.Lock()
+	defer t.m.mu.Unlock()
+	t.m.remove(t.w)
+}
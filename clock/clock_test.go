@@ -0,0 +1,82 @@
+package clock
+
+import (
+	"testing"
+	"time"
+)
+
+var t0 = time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+
+func fired(c <-chan time.Time) (time.Time, bool) {
+	select {
+	case at := <-c:
+		return at, true
+	default:
+		return time.Time{}, false
+	}
+}
+
+func TestManualAfter(t *testing.T) {
+	m := NewManual(t0)
+	c := m.After(time.Second)
+
+	m.Advance(999 * time.Millisecond)
+	if _, ok := fired(c); ok {
+		t.Fatal("After disparó antes de tiempo")
+	}
+	m.Advance(time.Millisecond)
+	if at, ok := fired(c); !ok || !at.Equal(t0.Add(time.Second)) {
+		t.Fatalf("After = %v, %v", at, ok)
+	}
+	if m.Waiters() != 0 {
+		t.Fatalf("quedan %d temporizadores", m.Waiters())
+	}
+	if !m.Now().Equal(t0.Add(time.Second)) {
+		t.Fatalf("Now = %v", m.Now())
+	}
+}
+
+func TestManualTicker(t *testing.T) {
+	m := NewManual(t0)
+	tk := m.NewTicker(16 * time.Millisecond)
+
+	for i := 1; i <= 3; i++ {
+		m.Advance(16 * time.Millisecond)
+		at, ok := fired(tk.C())
+		if !ok || !at.Equal(t0.Add(time.Duration(i)*16*time.Millisecond)) {
+			t.Fatalf("tick %d = %v, %v", i, at, ok)
+		}
+	}
+
+	// Como time.Ticker, los ticks que no se leen se pierden
+	m.Advance(100 * time.Millisecond)
+	if _, ok := fired(tk.C()); !ok {
+		t.Fatal("falta el tick pendiente")
+	}
+	if _, ok := fired(tk.C()); ok {
+		t.Fatal("el canal del ticker debería guardar un solo tick")
+	}
+
+	tk.Stop()
+	m.Advance(time.Second)
+	if _, ok := fired(tk.C()); ok {
+		t.Fatal("un ticker parado no debería disparar")
+	}
+}
+
+func TestManualBlockUntil(t *testing.T) {
+	m := NewManual(t0)
+	done := make(chan struct{})
+	go func() {
+		<-m.After(time.Minute)
+		close(done)
+	}()
+
+	m.BlockUntil(1)
+	m.Advance(time.Minute)
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("la goroutine no despertó")
+	}
+}
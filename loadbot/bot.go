@@ -0,0 +1,245 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	pb "JuegoCeN/proto"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// Un jugador alterna entre jugar siguiendo la bola y medir el RTT. Para
+// medirlo deja de mover la pala hasta que su posición se estabiliza, la
+// mantiene en una dirección y espera al primer estado en el que la pala se
+// ha movido; entonces la suelta. La pala avanza mientras se mantiene la
+// dirección, así que el desplazamiento no depende de cuántos ticks caigan
+// entre dos acciones.
+type phase int
+
+const (
+	phasePlay phase = iota
+	phaseSettle
+	phaseProbe
+)
+
+const (
+	settleMin    = 50 * time.Millisecond
+	probeTimeout = 2 * time.Second
+	rejoinDelay  = 500 * time.Millisecond
+)
+
+// received es un estado junto con el momento en que llegó.
+type received struct {
+	st *pb.GameState
+	at time.Time
+}
+
+type bot struct {
+	name       string
+	conn       *grpc.ClientConn
+	token      string
+	stats      *stats
+	interval   time.Duration
+	probeEvery time.Duration
+}
+
+// run inicia sesión y juega partidas hasta que se cancela ctx. Si una
+// partida termina antes de tiempo vuelve a la cola.
+func (b *bot) run(ctx context.Context) {
+	lctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	resp, err := pb.NewAuthClient(b.conn).Login(lctx, &pb.LoginRequest{DisplayName: b.name})
+	cancel()
+	if err != nil {
+		if ctx.Err() == nil {
+			b.stats.addError("login", err)
+		}
+		return
+	}
+	b.token = resp.Token
+
+	for ctx.Err() == nil {
+		err := b.play(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			b.stats.addError("play", err)
+		}
+		select {
+		case <-time.After(rejoinDelay):
+		case <-ctx.Done():
+		}
+	}
+}
+
+// play juega una partida (o serie) completa y devuelve el error con el que
+// terminó, o nil si alguien la ganó (el bot rechaza siempre la revancha).
+func (b *bot) play(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+b.token)
+
+	stream, err := pb.NewPingPongClient(b.conn).Play(ctx)
+	if err != nil {
+		return err
+	}
+	joined := time.Now()
+	if err := stream.Send(&pb.GameAction{RoomCode: ""}); err != nil {
+		return err
+	}
+	b.stats.waiting(1)
+	inQueue := true
+	defer func() {
+		if inQueue {
+			b.stats.waiting(-1)
+		}
+	}()
+
+	states := make(chan received, 16)
+	errc := make(chan error, 1)
+	go func() {
+		for {
+			st, err := stream.Recv()
+			if err != nil {
+				errc <- err
+				return
+			}
+			select {
+			case states <- received{st, time.Now()}:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	// Esperar a que el servidor nos empareje
+	var last received
+	select {
+	case last = <-states:
+	case err := <-errc:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	inQueue = false
+	b.stats.waiting(-1)
+	b.stats.matched(last.at.Sub(joined))
+	b.stats.playing(1)
+	defer b.stats.playing(-1)
+	b.stats.addStates(1)
+	playerID := last.st.PlayerId
+
+	ticker := time.NewTicker(b.interval)
+	defer ticker.Stop()
+
+	ph := phasePlay
+	nextProbe := time.Now().Add(b.probeEvery)
+	var phaseAt time.Time
+	var probeY float32
+	var probeMove string
+
+	for {
+		select {
+		case <-ticker.C:
+		case err := <-errc:
+			return err
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+
+		// Consumir todo lo recibido desde la última acción
+		prevY := myPaddle(last.st, playerID)
+		fresh := false
+		for drained := false; !drained; {
+			select {
+			case r := <-states:
+				last = r
+				fresh = true
+				b.stats.addStates(1)
+			default:
+				drained = true
+			}
+		}
+		// Entre juegos de una serie se espera al siguiente, en el que el
+		// asiento cambia
+		if last.st.Finished && last.st.NextGameInMs == 0 {
+			stream.Send(&pb.GameAction{Move: "LEAVE"})
+			stream.CloseSend()
+			return nil
+		}
+		playerID = last.st.PlayerId
+		now := time.Now()
+		y := myPaddle(last.st, playerID)
+
+		move := "NONE"
+		switch ph {
+		case phasePlay:
+			move = follow(last.st, y)
+			if now.After(nextProbe) {
+				ph, phaseAt = phaseSettle, now
+				move = "NONE"
+			}
+
+		case phaseSettle:
+			// Estable: un estado nuevo con la pala donde estaba
+			if fresh && y == prevY && now.Sub(phaseAt) >= settleMin {
+				probeMove = "DOWN"
+				if y >= 0.5 {
+					probeMove = "UP"
+				}
+				move = probeMove
+				ph, phaseAt, probeY = phaseProbe, now, y
+			}
+
+		case phaseProbe:
+			// Se mantiene la dirección hasta ver moverse la pala, y
+			// entonces (o al rendirse) se suelta
+			move = probeMove
+			switch {
+			case y != probeY:
+				b.stats.addRTT(last.at.Sub(phaseAt))
+				ph, nextProbe, move = phasePlay, now.Add(b.probeEvery), "NONE"
+			case now.Sub(phaseAt) > probeTimeout:
+				b.stats.probeTimeout()
+				ph, nextProbe, move = phasePlay, now.Add(b.probeEvery), "NONE"
+			}
+		}
+
+		if err := stream.Send(&pb.GameAction{Move: move}); err != nil {
+			select {
+			case err := <-errc:
+				return err
+			default:
+			}
+			return err
+		}
+	}
+}
+
+func myPaddle(st *pb.GameState, playerID string) float32 {
+	p := st.Paddle1
+	if playerID == "2" {
+		p = st.Paddle2
+	}
+	if p == nil {
+		return 0
+	}
+	return p.Y
+}
+
+// follow mueve la pala hacia la altura de la bola, como haría una persona.
+func follow(st *pb.GameState, y float32) string {
+	const deadZone = 0.03
+	if st.Ball == nil {
+		return "NONE"
+	}
+	switch {
+	case st.Ball.Y < y-deadZone:
+		return "UP"
+	case st.Ball.Y > y+deadZone:
+		return "DOWN"
+	}
+	return "NONE"
+}
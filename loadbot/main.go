@@ -0,0 +1,104 @@
+// Comando loadbot lanza jugadores simulados sin interfaz contra un servidor
+// para medir cuántas salas simultáneas aguanta.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"sync"
+	"time"
+
+	"JuegoCeN/tlsutil"
+
+	"google.golang.org/grpc"
+)
+
+func main() {
+	addr := flag.String("addr", "localhost:50051", "dirección del servidor")
+	players := flag.Int("players", 10, "número de jugadores simulados")
+	duration := flag.Duration("duration", 30*time.Second, "duración de la prueba")
+	ramp := flag.Duration("ramp", 20*time.Millisecond, "pausa entre el arranque de un jugador y el siguiente")
+	rate := flag.Float64("rate", 60, "acciones por segundo que envía cada jugador")
+	probeEvery := flag.Duration("probe-every", time.Second, "cada cuánto mide cada jugador el RTT")
+	prefix := flag.String("name", "bot", "prefijo del nombre de los jugadores")
+	useTLS := flag.Bool("tls", false, "conectar con TLS usando las raíces del sistema")
+	var tlsCfg tlsutil.Config
+	flag.StringVar(&tlsCfg.CAFile, "tls-ca", "", "CA PEM con la que validar al servidor (implica -tls)")
+	flag.StringVar(&tlsCfg.ServerName, "tls-server-name", "", "nombre esperado en el certificado del servidor")
+	flag.StringVar(&tlsCfg.CertFile, "tls-cert", "", "certificado PEM de cliente para TLS mutuo")
+	flag.StringVar(&tlsCfg.KeyFile, "tls-key", "", "clave PEM de cliente para TLS mutuo")
+	flag.Parse()
+
+	if *players < 1 || *rate <= 0 {
+		log.Fatal("-players y -rate deben ser positivos")
+	}
+	creds, err := tlsCfg.ClientCredentials(*useTLS)
+	if err != nil {
+		log.Fatalf("TLS: %v", err)
+	}
+
+	// Sin plazo en el contexto: el servidor vería DeadlineExceeded antes de
+	// que los jugadores sepan que la prueba ha terminado
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	time.AfterFunc(*duration, cancel)
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt)
+	defer stop()
+
+	st := newStats()
+	var wg sync.WaitGroup
+	log.Printf("Lanzando %d jugadores contra %s durante %v", *players, *addr, *duration)
+
+spawn:
+	for i := 0; i < *players; i++ {
+		// Cada jugador usa su propia conexión, como un cliente real
+		conn, err := grpc.Dial(*addr, grpc.WithTransportCredentials(creds))
+		if err != nil {
+			log.Fatalf("Dial failed: %v", err)
+		}
+		defer conn.Close()
+
+		b := &bot{
+			name:       fmt.Sprintf("%s-%03d", *prefix, i+1),
+			conn:       conn,
+			stats:      st,
+			interval:   time.Duration(float64(time.Second) / *rate),
+			probeEvery: *probeEvery,
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			b.run(ctx)
+		}()
+
+		select {
+		case <-time.After(*ramp):
+		case <-ctx.Done():
+			break spawn
+		}
+	}
+
+	// Progreso cada 5 segundos hasta que acabe la prueba
+	progress := time.NewTicker(5 * time.Second)
+	defer progress.Stop()
+	prev := st.snapshot()
+wait:
+	for {
+		select {
+		case <-progress.C:
+			cur := st.snapshot()
+			log.Printf("en partida: %d  en cola: %d  estados/s: %.0f  errores: %d",
+				cur.playing, cur.waiting, float64(cur.states-prev.states)/5, cur.errors)
+			prev = cur
+		case <-ctx.Done():
+			break wait
+		}
+	}
+	wg.Wait()
+
+	st.report(os.Stdout)
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc/status"
+)
+
+// stats acumula las medidas de todos los jugadores.
+type stats struct {
+	mu            sync.Mutex
+	start         time.Time
+	matchLatency  []time.Duration
+	rtts          []time.Duration
+	probeTimeouts int
+	states        int64
+	inGame        int
+	inQueue       int
+	playTime      time.Duration // tiempo total en partida sumando jugadores
+	playSince     time.Time     // última vez que cambió inGame
+	errors        map[string]int
+	examples      map[string]string
+}
+
+// progress es una foto de los contadores para el informe periódico.
+type progress struct {
+	playing, waiting int
+	states           int64
+	errors           int
+}
+
+func newStats() *stats {
+	now := time.Now()
+	return &stats{
+		start:     now,
+		playSince: now,
+		errors:    make(map[string]int),
+		examples:  make(map[string]string),
+	}
+}
+
+// accrue suma al tiempo de juego lo transcurrido desde el último cambio;
+// requiere s.mu.
+func (s *stats) accrue() {
+	now := time.Now()
+	s.playTime += time.Duration(s.inGame) * now.Sub(s.playSince)
+	s.playSince = now
+}
+
+func (s *stats) playing(delta int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.accrue()
+	s.inGame += delta
+}
+
+func (s *stats) waiting(delta int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.inQueue += delta
+}
+
+func (s *stats) matched(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.matchLatency = append(s.matchLatency, d)
+}
+
+func (s *stats) addStates(n int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.states += n
+}
+
+func (s *stats) addRTT(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.rtts = append(s.rtts, d)
+}
+
+func (s *stats) probeTimeout() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.probeTimeouts++
+}
+
+// addError cuenta un error agrupado por etapa y código gRPC.
+func (s *stats) addError(stage string, err error) {
+	st, _ := status.FromError(err)
+	key := stage + "/" + st.Code().String()
+	if err == io.EOF {
+		key = stage + "/EOF"
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.errors[key]++
+	if _, ok := s.examples[key]; !ok {
+		s.examples[key] = err.Error()
+	}
+}
+
+func (s *stats) snapshot() progress {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p := progress{playing: s.inGame, waiting: s.inQueue, states: s.states}
+	for _, n := range s.errors {
+		p.errors += n
+	}
+	return p
+}
+
+// percentile devuelve el percentil p (0-100) de unas duraciones ordenadas.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	i := int(p/100*float64(len(sorted)-1) + 0.5)
+	return sorted[i]
+}
+
+func summary(ds []time.Duration) string {
+	if len(ds) == 0 {
+		return "sin muestras"
+	}
+	sorted := append([]time.Duration(nil), ds...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	round := func(d time.Duration) time.Duration { return d.Round(100 * time.Microsecond) }
+	return fmt.Sprintf("n=%d p50=%v p90=%v p99=%v max=%v", len(sorted),
+		round(percentile(sorted, 50)), round(percentile(sorted, 90)),
+		round(percentile(sorted, 99)), round(sorted[len(sorted)-1]))
+}
+
+func (s *stats) report(w io.Writer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.accrue()
+	elapsed := time.Since(s.start)
+
+	fmt.Fprintf(w, "Duración:            %v\n", elapsed.Round(time.Millisecond))
+	fmt.Fprintf(w, "Partidas formadas:   %d\n", len(s.matchLatency)/2)
+	fmt.Fprintf(w, "Emparejamiento:      %s\n", summary(s.matchLatency))
+	perPlayer := 0.0
+	if s.playTime > 0 {
+		perPlayer = float64(s.states) / s.playTime.Seconds()
+	}
+	fmt.Fprintf(w, "Estados recibidos:   %d (%.1f/s por jugador en partida, %.0f/s en total)\n",
+		s.states, perPlayer, float64(s.states)/elapsed.Seconds())
+	fmt.Fprintf(w, "RTT acción->estado:  %s\n", summary(s.rtts))
+	if s.probeTimeouts > 0 {
+		fmt.Fprintf(w, "Sondas sin respuesta: %d\n", s.probeTimeouts)
+	}
+
+	if len(s.errors) == 0 {
+		fmt.Fprintln(w, "Errores:             ninguno")
+		return
+	}
+	keys := make([]string, 0, len(s.errors))
+	for k := range s.errors {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	fmt.Fprintln(w, "Errores:")
+	for _, k := range keys {
+		fmt.Fprintf(w, "  %-24s %5d  %s\n", k, s.errors[k], s.examples[k])
+	}
+}
@@ -0,0 +1,810 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.30.2
+// source: proto/history.proto
+
+package pingpong
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Period int32
+
+const (
+	Period_PERIOD_ALL   Period = 0
+	Period_PERIOD_DAY   Period = 1
+	Period_PERIOD_WEEK  Period = 2
+	Period_PERIOD_MONTH Period = 3
+)
+
+// Enum value maps for Period.
+var (
+	Period_name = map[int32]string{
+		0: "PERIOD_ALL",
+		1: "PERIOD_DAY",
+		2: "PERIOD_WEEK",
+		3: "PERIOD_MONTH",
+	}
+	Period_value = map[string]int32{
+		"PERIOD_ALL":   0,
+		"PERIOD_DAY":   1,
+		"PERIOD_WEEK":  2,
+		"PERIOD_MONTH": 3,
+	}
+)
+
+func (x Period) Enum() *Period {
+	p := new(Period)
+	*p = x
+	return p
+}
+
+func (x Period) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Period) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_history_proto_enumTypes[0].Descriptor()
+}
+
+func (Period) Type() protoreflect.EnumType {
+	return &file_proto_history_proto_enumTypes[0]
+}
+
+func (x Period) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Period.Descriptor instead.
+func (Period) EnumDescriptor() ([]byte, []int) {
+	return file_proto_history_proto_rawDescGZIP(), []int{0}
+}
+
+// Si player_id va vacío se usa el jugador del token de la cabecera
+// "authorization".
+type PlayerRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlayerRequest) Reset() {
+	*x = PlayerRequest{}
+	mi := &file_proto_history_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlayerRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayerRequest) ProtoMessage() {}
+
+func (x *PlayerRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_history_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayerRequest.ProtoReflect.Descriptor instead.
+func (*PlayerRequest) Descriptor() ([]byte, []int) {
+	return file_proto_history_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PlayerRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+type PlayerProfile struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	DisplayName   string                 `protobuf:"bytes,2,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	FirstSeen     int64                  `protobuf:"varint,3,opt,name=first_seen,json=firstSeen,proto3" json:"first_seen,omitempty"`
+	LastSeen      int64                  `protobuf:"varint,4,opt,name=last_seen,json=lastSeen,proto3" json:"last_seen,omitempty"`
+	Played        int32                  `protobuf:"varint,5,opt,name=played,proto3" json:"played,omitempty"`
+	Wins          int32                  `protobuf:"varint,6,opt,name=wins,proto3" json:"wins,omitempty"`
+	Losses        int32                  `protobuf:"varint,7,opt,name=losses,proto3" json:"losses,omitempty"`
+	Rating        float32                `protobuf:"fixed32,8,opt,name=rating,proto3" json:"rating,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlayerProfile) Reset() {
+	*x = PlayerProfile{}
+	mi := &file_proto_history_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlayerProfile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayerProfile) ProtoMessage() {}
+
+func (x *PlayerProfile) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_history_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayerProfile.ProtoReflect.Descriptor instead.
+func (*PlayerProfile) Descriptor() ([]byte, []int) {
+	return file_proto_history_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PlayerProfile) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *PlayerProfile) GetDisplayName() string {
+	if x != nil {
+		return x.DisplayName
+	}
+	return ""
+}
+
+func (x *PlayerProfile) GetFirstSeen() int64 {
+	if x != nil {
+		return x.FirstSeen
+	}
+	return 0
+}
+
+func (x *PlayerProfile) GetLastSeen() int64 {
+	if x != nil {
+		return x.LastSeen
+	}
+	return 0
+}
+
+func (x *PlayerProfile) GetPlayed() int32 {
+	if x != nil {
+		return x.Played
+	}
+	return 0
+}
+
+func (x *PlayerProfile) GetWins() int32 {
+	if x != nil {
+		return x.Wins
+	}
+	return 0
+}
+
+func (x *PlayerProfile) GetLosses() int32 {
+	if x != nil {
+		return x.Losses
+	}
+	return 0
+}
+
+func (x *PlayerProfile) GetRating() float32 {
+	if x != nil {
+		return x.Rating
+	}
+	return 0
+}
+
+type MatchHistoryRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MatchHistoryRequest) Reset() {
+	*x = MatchHistoryRequest{}
+	mi := &file_proto_history_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchHistoryRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchHistoryRequest) ProtoMessage() {}
+
+func (x *MatchHistoryRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_history_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchHistoryRequest.ProtoReflect.Descriptor instead.
+func (*MatchHistoryRequest) Descriptor() ([]byte, []int) {
+	return file_proto_history_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *MatchHistoryRequest) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *MatchHistoryRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *MatchHistoryRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type MatchRecord struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	MatchId     string                 `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	RoomCode    string                 `protobuf:"bytes,2,opt,name=room_code,json=roomCode,proto3" json:"room_code,omitempty"`
+	Player1Id   string                 `protobuf:"bytes,3,opt,name=player1_id,json=player1Id,proto3" json:"player1_id,omitempty"`
+	Player1Name string                 `protobuf:"bytes,4,opt,name=player1_name,json=player1Name,proto3" json:"player1_name,omitempty"`
+	Player2Id   string                 `protobuf:"bytes,5,opt,name=player2_id,json=player2Id,proto3" json:"player2_id,omitempty"`
+	Player2Name string                 `protobuf:"bytes,6,opt,name=player2_name,json=player2Name,proto3" json:"player2_name,omitempty"`
+	Score1      int32                  `protobuf:"varint,7,opt,name=Score1,proto3" json:"Score1,omitempty"`
+	Score2      int32                  `protobuf:"varint,8,opt,name=Score2,proto3" json:"Score2,omitempty"`
+	StartedAt   int64                  `protobuf:"varint,9,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	DurationMs  int64                  `protobuf:"varint,10,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	EndReason   string                 `protobuf:"bytes,11,opt,name=end_reason,json=endReason,proto3" json:"end_reason,omitempty"`
+	// Ganador (vacío si hubo empate o la partida no puntúa). Quien abandona
+	// o es expulsado pierde aunque fuera por delante en el marcador.
+	WinnerId      string `protobuf:"bytes,12,opt,name=winner_id,json=winnerId,proto3" json:"winner_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MatchRecord) Reset() {
+	*x = MatchRecord{}
+	mi := &file_proto_history_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchRecord) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchRecord) ProtoMessage() {}
+
+func (x *MatchRecord) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_history_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchRecord.ProtoReflect.Descriptor instead.
+func (*MatchRecord) Descriptor() ([]byte, []int) {
+	return file_proto_history_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *MatchRecord) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *MatchRecord) GetRoomCode() string {
+	if x != nil {
+		return x.RoomCode
+	}
+	return ""
+}
+
+func (x *MatchRecord) GetPlayer1Id() string {
+	if x != nil {
+		return x.Player1Id
+	}
+	return ""
+}
+
+func (x *MatchRecord) GetPlayer1Name() string {
+	if x != nil {
+		return x.Player1Name
+	}
+	return ""
+}
+
+func (x *MatchRecord) GetPlayer2Id() string {
+	if x != nil {
+		return x.Player2Id
+	}
+	return ""
+}
+
+func (x *MatchRecord) GetPlayer2Name() string {
+	if x != nil {
+		return x.Player2Name
+	}
+	return ""
+}
+
+func (x *MatchRecord) GetScore1() int32 {
+	if x != nil {
+		return x.Score1
+	}
+	return 0
+}
+
+func (x *MatchRecord) GetScore2() int32 {
+	if x != nil {
+		return x.Score2
+	}
+	return 0
+}
+
+func (x *MatchRecord) GetStartedAt() int64 {
+	if x != nil {
+		return x.StartedAt
+	}
+	return 0
+}
+
+func (x *MatchRecord) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+func (x *MatchRecord) GetEndReason() string {
+	if x != nil {
+		return x.EndReason
+	}
+	return ""
+}
+
+func (x *MatchRecord) GetWinnerId() string {
+	if x != nil {
+		return x.WinnerId
+	}
+	return ""
+}
+
+type MatchHistoryResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Matches       []*MatchRecord         `protobuf:"bytes,1,rep,name=matches,proto3" json:"matches,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MatchHistoryResponse) Reset() {
+	*x = MatchHistoryResponse{}
+	mi := &file_proto_history_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MatchHistoryResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MatchHistoryResponse) ProtoMessage() {}
+
+func (x *MatchHistoryResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_history_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MatchHistoryResponse.ProtoReflect.Descriptor instead.
+func (*MatchHistoryResponse) Descriptor() ([]byte, []int) {
+	return file_proto_history_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *MatchHistoryResponse) GetMatches() []*MatchRecord {
+	if x != nil {
+		return x.Matches
+	}
+	return nil
+}
+
+func (x *MatchHistoryResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+type LeaderboardRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Period        Period                 `protobuf:"varint,1,opt,name=period,proto3,enum=pingpong.Period" json:"period,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Offset        int32                  `protobuf:"varint,3,opt,name=offset,proto3" json:"offset,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaderboardRequest) Reset() {
+	*x = LeaderboardRequest{}
+	mi := &file_proto_history_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaderboardRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaderboardRequest) ProtoMessage() {}
+
+func (x *LeaderboardRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_history_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaderboardRequest.ProtoReflect.Descriptor instead.
+func (*LeaderboardRequest) Descriptor() ([]byte, []int) {
+	return file_proto_history_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *LeaderboardRequest) GetPeriod() Period {
+	if x != nil {
+		return x.Period
+	}
+	return Period_PERIOD_ALL
+}
+
+func (x *LeaderboardRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *LeaderboardRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+// wins, losses y played cuentan solo las partidas del periodo; rating es la
+// puntuación actual.
+type LeaderboardEntry struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rank          int32                  `protobuf:"varint,1,opt,name=rank,proto3" json:"rank,omitempty"`
+	PlayerId      string                 `protobuf:"bytes,2,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	DisplayName   string                 `protobuf:"bytes,3,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	Rating        float32                `protobuf:"fixed32,4,opt,name=rating,proto3" json:"rating,omitempty"`
+	Wins          int32                  `protobuf:"varint,5,opt,name=wins,proto3" json:"wins,omitempty"`
+	Losses        int32                  `protobuf:"varint,6,opt,name=losses,proto3" json:"losses,omitempty"`
+	Played        int32                  `protobuf:"varint,7,opt,name=played,proto3" json:"played,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaderboardEntry) Reset() {
+	*x = LeaderboardEntry{}
+	mi := &file_proto_history_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaderboardEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaderboardEntry) ProtoMessage() {}
+
+func (x *LeaderboardEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_history_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaderboardEntry.ProtoReflect.Descriptor instead.
+func (*LeaderboardEntry) Descriptor() ([]byte, []int) {
+	return file_proto_history_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *LeaderboardEntry) GetRank() int32 {
+	if x != nil {
+		return x.Rank
+	}
+	return 0
+}
+
+func (x *LeaderboardEntry) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *LeaderboardEntry) GetDisplayName() string {
+	if x != nil {
+		return x.DisplayName
+	}
+	return ""
+}
+
+func (x *LeaderboardEntry) GetRating() float32 {
+	if x != nil {
+		return x.Rating
+	}
+	return 0
+}
+
+func (x *LeaderboardEntry) GetWins() int32 {
+	if x != nil {
+		return x.Wins
+	}
+	return 0
+}
+
+func (x *LeaderboardEntry) GetLosses() int32 {
+	if x != nil {
+		return x.Losses
+	}
+	return 0
+}
+
+func (x *LeaderboardEntry) GetPlayed() int32 {
+	if x != nil {
+		return x.Played
+	}
+	return 0
+}
+
+type LeaderboardResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Entries       []*LeaderboardEntry    `protobuf:"bytes,1,rep,name=entries,proto3" json:"entries,omitempty"`
+	Total         int32                  `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaderboardResponse) Reset() {
+	*x = LeaderboardResponse{}
+	mi := &file_proto_history_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaderboardResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaderboardResponse) ProtoMessage() {}
+
+func (x *LeaderboardResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_history_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaderboardResponse.ProtoReflect.Descriptor instead.
+func (*LeaderboardResponse) Descriptor() ([]byte, []int) {
+	return file_proto_history_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *LeaderboardResponse) GetEntries() []*LeaderboardEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+func (x *LeaderboardResponse) GetTotal() int32 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+var File_proto_history_proto protoreflect.FileDescriptor
+
+const file_proto_history_proto_rawDesc = "" +
+	"\n" +
+	"\x13proto/history.proto\x12\bpingpong\",\n" +
+	"\rPlayerRequest\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\"\xe7\x01\n" +
+	"\rPlayerProfile\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12!\n" +
+	"\fdisplay_name\x18\x02 \x01(\tR\vdisplayName\x12\x1d\n" +
+	"\n" +
+	"first_seen\x18\x03 \x01(\x03R\tfirstSeen\x12\x1b\n" +
+	"\tlast_seen\x18\x04 \x01(\x03R\blastSeen\x12\x16\n" +
+	"\x06played\x18\x05 \x01(\x05R\x06played\x12\x12\n" +
+	"\x04wins\x18\x06 \x01(\x05R\x04wins\x12\x16\n" +
+	"\x06losses\x18\a \x01(\x05R\x06losses\x12\x16\n" +
+	"\x06rating\x18\b \x01(\x02R\x06rating\"`\n" +
+	"\x13MatchHistoryRequest\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x05R\x06offset\"\xf5\x02\n" +
+	"\vMatchRecord\x12\x19\n" +
+	"\bmatch_id\x18\x01 \x01(\tR\amatchId\x12\x1b\n" +
+	"\troom_code\x18\x02 \x01(\tR\broomCode\x12\x1d\n" +
+	"\n" +
+	"player1_id\x18\x03 \x01(\tR\tplayer1Id\x12!\n" +
+	"\fplayer1_name\x18\x04 \x01(\tR\vplayer1Name\x12\x1d\n" +
+	"\n" +
+	"player2_id\x18\x05 \x01(\tR\tplayer2Id\x12!\n" +
+	"\fplayer2_name\x18\x06 \x01(\tR\vplayer2Name\x12\x16\n" +
+	"\x06Score1\x18\a \x01(\x05R\x06Score1\x12\x16\n" +
+	"\x06Score2\x18\b \x01(\x05R\x06Score2\x12\x1d\n" +
+	"\n" +
+	"started_at\x18\t \x01(\x03R\tstartedAt\x12\x1f\n" +
+	"\vduration_ms\x18\n" +
+	" \x01(\x03R\n" +
+	"durationMs\x12\x1d\n" +
+	"\n" +
+	"end_reason\x18\v \x01(\tR\tendReason\x12\x1b\n" +
+	"\twinner_id\x18\f \x01(\tR\bwinnerId\"]\n" +
+	"\x14MatchHistoryResponse\x12/\n" +
+	"\amatches\x18\x01 \x03(\v2\x15.pingpong.MatchRecordR\amatches\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total\"l\n" +
+	"\x12LeaderboardRequest\x12(\n" +
+	"\x06period\x18\x01 \x01(\x0e2\x10.pingpong.PeriodR\x06period\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x03 \x01(\x05R\x06offset\"\xc2\x01\n" +
+	"\x10LeaderboardEntry\x12\x12\n" +
+	"\x04rank\x18\x01 \x01(\x05R\x04rank\x12\x1b\n" +
+	"\tplayer_id\x18\x02 \x01(\tR\bplayerId\x12!\n" +
+	"\fdisplay_name\x18\x03 \x01(\tR\vdisplayName\x12\x16\n" +
+	"\x06rating\x18\x04 \x01(\x02R\x06rating\x12\x12\n" +
+	"\x04wins\x18\x05 \x01(\x05R\x04wins\x12\x16\n" +
+	"\x06losses\x18\x06 \x01(\x05R\x06losses\x12\x16\n" +
+	"\x06played\x18\a \x01(\x05R\x06played\"a\n" +
+	"\x13LeaderboardResponse\x124\n" +
+	"\aentries\x18\x01 \x03(\v2\x1a.pingpong.LeaderboardEntryR\aentries\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x05R\x05total*K\n" +
+	"\x06Period\x12\x0e\n" +
+	"\n" +
+	"PERIOD_ALL\x10\x00\x12\x0e\n" +
+	"\n" +
+	"PERIOD_DAY\x10\x01\x12\x0f\n" +
+	"\vPERIOD_WEEK\x10\x02\x12\x10\n" +
+	"\fPERIOD_MONTH\x10\x032\xea\x01\n" +
+	"\aHistory\x12>\n" +
+	"\n" +
+	"GetProfile\x12\x17.pingpong.PlayerRequest\x1a\x17.pingpong.PlayerProfile\x12P\n" +
+	"\x0fGetMatchHistory\x12\x1d.pingpong.MatchHistoryRequest\x1a\x1e.pingpong.MatchHistoryResponse\x12M\n" +
+	"\x0eGetLeaderboard\x12\x1c.pingpong.LeaderboardRequest\x1a\x1d.pingpong.LeaderboardResponseB\x19Z\x17JuegoCeN/proto;pingpongb\x06proto3"
+
+var (
+	file_proto_history_proto_rawDescOnce sync.Once
+	file_proto_history_proto_rawDescData []byte
+)
+
+func file_proto_history_proto_rawDescGZIP() []byte {
+	file_proto_history_proto_rawDescOnce.Do(func() {
+		file_proto_history_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_history_proto_rawDesc), len(file_proto_history_proto_rawDesc)))
+	})
+	return file_proto_history_proto_rawDescData
+}
+
+var file_proto_history_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_proto_history_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_proto_history_proto_goTypes = []any{
+	(Period)(0),                  // 0: pingpong.Period
+	(*PlayerRequest)(nil),        // 1: pingpong.PlayerRequest
+	(*PlayerProfile)(nil),        // 2: pingpong.PlayerProfile
+	(*MatchHistoryRequest)(nil),  // 3: pingpong.MatchHistoryRequest
+	(*MatchRecord)(nil),          // 4: pingpong.MatchRecord
+	(*MatchHistoryResponse)(nil), // 5: pingpong.MatchHistoryResponse
+	(*LeaderboardRequest)(nil),   // 6: pingpong.LeaderboardRequest
+	(*LeaderboardEntry)(nil),     // 7: pingpong.LeaderboardEntry
+	(*LeaderboardResponse)(nil),  // 8: pingpong.LeaderboardResponse
+}
+var file_proto_history_proto_depIdxs = []int32{
+	4, // 0: pingpong.MatchHistoryResponse.matches:type_name -> pingpong.MatchRecord
+	0, // 1: pingpong.LeaderboardRequest.period:type_name -> pingpong.Period
+	7, // 2: pingpong.LeaderboardResponse.entries:type_name -> pingpong.LeaderboardEntry
+	1, // 3: pingpong.History.GetProfile:input_type -> pingpong.PlayerRequest
+	3, // 4: pingpong.History.GetMatchHistory:input_type -> pingpong.MatchHistoryRequest
+	6, // 5: pingpong.History.GetLeaderboard:input_type -> pingpong.LeaderboardRequest
+	2, // 6: pingpong.History.GetProfile:output_type -> pingpong.PlayerProfile
+	5, // 7: pingpong.History.GetMatchHistory:output_type -> pingpong.MatchHistoryResponse
+	8, // 8: pingpong.History.GetLeaderboard:output_type -> pingpong.LeaderboardResponse
+	6, // [6:9] is the sub-list for method output_type
+	3, // [3:6] is the sub-list for method input_type
+	3, // [3:3] is the sub-list for extension type_name
+	3, // [3:3] is the sub-list for extension extendee
+	0, // [0:3] is the sub-list for field type_name
+}
+
+func init() { file_proto_history_proto_init() }
+func file_proto_history_proto_init() {
+	if File_proto_history_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_history_proto_rawDesc), len(file_proto_history_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_history_proto_goTypes,
+		DependencyIndexes: file_proto_history_proto_depIdxs,
+		EnumInfos:         file_proto_history_proto_enumTypes,
+		MessageInfos:      file_proto_history_proto_msgTypes,
+	}.Build()
+	File_proto_history_proto = out.File
+	file_proto_history_proto_goTypes = nil
+	file_proto_history_proto_depIdxs = nil
+}
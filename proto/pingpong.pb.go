@@ -21,11 +21,132 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Lado del campo que defiende una pala.
+type Side int32
+
+const (
+	Side_LEFT   Side = 0
+	Side_RIGHT  Side = 1
+	Side_TOP    Side = 2
+	Side_BOTTOM Side = 3
+)
+
+// Enum value maps for Side.
+var (
+	Side_name = map[int32]string{
+		0: "LEFT",
+		1: "RIGHT",
+		2: "TOP",
+		3: "BOTTOM",
+	}
+	Side_value = map[string]int32{
+		"LEFT":   0,
+		"RIGHT":  1,
+		"TOP":    2,
+		"BOTTOM": 3,
+	}
+)
+
+func (x Side) Enum() *Side {
+	p := new(Side)
+	*p = x
+	return p
+}
+
+func (x Side) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Side) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_pingpong_proto_enumTypes[0].Descriptor()
+}
+
+func (Side) Type() protoreflect.EnumType {
+	return &file_proto_pingpong_proto_enumTypes[0]
+}
+
+func (x Side) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Side.Descriptor instead.
+func (Side) EnumDescriptor() ([]byte, []int) {
+	return file_proto_pingpong_proto_rawDescGZIP(), []int{0}
+}
+
+// Objetos que aparecen en el campo con los potenciadores activados.
+type PowerUpKind int32
+
+const (
+	PowerUpKind_BIG_PADDLE PowerUpKind = 0 // pala un 50% más larga
+	PowerUpKind_FAST_BALL  PowerUpKind = 1 // bolas un 50% más rápidas
+	PowerUpKind_MULTI_BALL PowerUpKind = 2 // dos bolas más hasta que marquen
+	PowerUpKind_REVERSE    PowerUpKind = 3 // controles invertidos del rival
+)
+
+// Enum value maps for PowerUpKind.
+var (
+	PowerUpKind_name = map[int32]string{
+		0: "BIG_PADDLE",
+		1: "FAST_BALL",
+		2: "MULTI_BALL",
+		3: "REVERSE",
+	}
+	PowerUpKind_value = map[string]int32{
+		"BIG_PADDLE": 0,
+		"FAST_BALL":  1,
+		"MULTI_BALL": 2,
+		"REVERSE":    3,
+	}
+)
+
+func (x PowerUpKind) Enum() *PowerUpKind {
+	p := new(PowerUpKind)
+	*p = x
+	return p
+}
+
+func (x PowerUpKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PowerUpKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_pingpong_proto_enumTypes[1].Descriptor()
+}
+
+func (PowerUpKind) Type() protoreflect.EnumType {
+	return &file_proto_pingpong_proto_enumTypes[1]
+}
+
+func (x PowerUpKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PowerUpKind.Descriptor instead.
+func (PowerUpKind) EnumDescriptor() ([]byte, []int) {
+	return file_proto_pingpong_proto_rawDescGZIP(), []int{1}
+}
+
+// move: "UP", "DOWN" o "NONE" para la pala; "PAUSE" pide una pausa y
+// "RESUME" da el visto bueno para reanudar. Terminada la partida,
+// "REMATCH" ofrece o acepta la revancha y "LEAVE" la rechaza. Si la
+// primera acción lleva tournament_id, el jugador entra en la sala de espera
+// de ese torneo en vez de en la cola normal. mode, también en la primera
+// acción, elige la cola: "" o "DUEL" para uno contra uno, "FOUR" para
+// cuatro jugadores, uno por lado (las palas de arriba y abajo se mueven con
+// "LEFT" y "RIGHT"), "TEAMS" para dos contra dos, con dos palas por lado,
+// "MULTI" para uno contra uno con varias bolas a la vez, "SPEED" para uno
+// contra uno más rápido y "PRACTICE" para jugar solo contra una pared (ver
+// sim.DefaultModes). map_name elige el mapa de la partida (solo en duelos
+// y práctica; "" = campo vacío): se empareja con quien pida el mismo.
 type GameAction struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
 	Move          string                 `protobuf:"bytes,2,opt,name=move,proto3" json:"move,omitempty"`
-	RoomCode      string                 `protobuf:"bytes,3,opt,name=room_code,json=roomCode,proto3" json:"room_code,omitempty"` // ← lo agregas aquí
+	RoomCode      string                 `protobuf:"bytes,3,opt,name=room_code,json=roomCode,proto3" json:"room_code,omitempty"`
+	TournamentId  string                 `protobuf:"bytes,4,opt,name=tournament_id,json=tournamentId,proto3" json:"tournament_id,omitempty"`
+	Mode          string                 `protobuf:"bytes,5,opt,name=mode,proto3" json:"mode,omitempty"`
+	MapName       string                 `protobuf:"bytes,6,opt,name=map_name,json=mapName,proto3" json:"map_name,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -81,6 +202,27 @@ func (x *GameAction) GetRoomCode() string {
 	return ""
 }
 
+func (x *GameAction) GetTournamentId() string {
+	if x != nil {
+		return x.TournamentId
+	}
+	return ""
+}
+
+func (x *GameAction) GetMode() string {
+	if x != nil {
+		return x.Mode
+	}
+	return ""
+}
+
+func (x *GameAction) GetMapName() string {
+	if x != nil {
+		return x.MapName
+	}
+	return ""
+}
+
 type Vector struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	X             float32                `protobuf:"fixed32,1,opt,name=X,proto3" json:"X,omitempty"`
@@ -133,33 +275,34 @@ func (x *Vector) GetY() float32 {
 	return 0
 }
 
-type GameState struct {
+// Pala de una sala de más de dos jugadores: lado que defiende, centro,
+// puntos y jugador. Las de los lados se mueven en Y y las de arriba y
+// abajo en X.
+type Paddle struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	RoomCode      string                 `protobuf:"bytes,1,opt,name=room_code,json=roomCode,proto3" json:"room_code,omitempty"`
-	Ball          *Vector                `protobuf:"bytes,2,opt,name=Ball,proto3" json:"Ball,omitempty"`
-	Paddle1       *Vector                `protobuf:"bytes,3,opt,name=Paddle1,proto3" json:"Paddle1,omitempty"`
-	Paddle2       *Vector                `protobuf:"bytes,4,opt,name=Paddle2,proto3" json:"Paddle2,omitempty"`
-	Score1        int32                  `protobuf:"varint,5,opt,name=Score1,proto3" json:"Score1,omitempty"`
-	Score2        int32                  `protobuf:"varint,6,opt,name=Score2,proto3" json:"Score2,omitempty"`
-	PlayerId      string                 `protobuf:"bytes,7,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"` // si lo necesitas también
+	Side          Side                   `protobuf:"varint,1,opt,name=side,proto3,enum=pingpong.Side" json:"side,omitempty"`
+	Pos           *Vector                `protobuf:"bytes,2,opt,name=pos,proto3" json:"pos,omitempty"`
+	Score         int32                  `protobuf:"varint,3,opt,name=score,proto3" json:"score,omitempty"`
+	Name          string                 `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	Rating        float32                `protobuf:"fixed32,5,opt,name=rating,proto3" json:"rating,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GameState) Reset() {
-	*x = GameState{}
+func (x *Paddle) Reset() {
+	*x = Paddle{}
 	mi := &file_proto_pingpong_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GameState) String() string {
+func (x *Paddle) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GameState) ProtoMessage() {}
+func (*Paddle) ProtoMessage() {}
 
-func (x *GameState) ProtoReflect() protoreflect.Message {
+func (x *Paddle) ProtoReflect() protoreflect.Message {
 	mi := &file_proto_pingpong_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -171,81 +314,1156 @@ func (x *GameState) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GameState.ProtoReflect.Descriptor instead.
-func (*GameState) Descriptor() ([]byte, []int) {
+// Deprecated: Use Paddle.ProtoReflect.Descriptor instead.
+func (*Paddle) Descriptor() ([]byte, []int) {
 	return file_proto_pingpong_proto_rawDescGZIP(), []int{2}
 }
 
-func (x *GameState) GetRoomCode() string {
+func (x *Paddle) GetSide() Side {
 	if x != nil {
-		return x.RoomCode
+		return x.Side
 	}
-	return ""
+	return Side_LEFT
 }
 
-func (x *GameState) GetBall() *Vector {
+func (x *Paddle) GetPos() *Vector {
 	if x != nil {
-		return x.Ball
+		return x.Pos
 	}
 	return nil
 }
 
-func (x *GameState) GetPaddle1() *Vector {
+func (x *Paddle) GetScore() int32 {
 	if x != nil {
-		return x.Paddle1
+		return x.Score
+	}
+	return 0
+}
+
+func (x *Paddle) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Paddle) GetRating() float32 {
+	if x != nil {
+		return x.Rating
+	}
+	return 0
+}
+
+// Potenciador en el campo, esperando a que lo toque una bola.
+type PowerUp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kind          PowerUpKind            `protobuf:"varint,1,opt,name=kind,proto3,enum=pingpong.PowerUpKind" json:"kind,omitempty"`
+	Pos           *Vector                `protobuf:"bytes,2,opt,name=pos,proto3" json:"pos,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PowerUp) Reset() {
+	*x = PowerUp{}
+	mi := &file_proto_pingpong_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PowerUp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PowerUp) ProtoMessage() {}
+
+func (x *PowerUp) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_pingpong_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PowerUp.ProtoReflect.Descriptor instead.
+func (*PowerUp) Descriptor() ([]byte, []int) {
+	return file_proto_pingpong_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PowerUp) GetKind() PowerUpKind {
+	if x != nil {
+		return x.Kind
+	}
+	return PowerUpKind_BIG_PADDLE
+}
+
+func (x *PowerUp) GetPos() *Vector {
+	if x != nil {
+		return x.Pos
 	}
 	return nil
 }
 
-func (x *GameState) GetPaddle2() *Vector {
+// Efecto en curso: sobre qué jugador actúa (en REVERSE, el rival de quien
+// lo recogió) y cuántos ticks le quedan.
+type Effect struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kind          PowerUpKind            `protobuf:"varint,1,opt,name=kind,proto3,enum=pingpong.PowerUpKind" json:"kind,omitempty"`
+	Player        int32                  `protobuf:"varint,2,opt,name=player,proto3" json:"player,omitempty"`
+	TicksLeft     int32                  `protobuf:"varint,3,opt,name=ticks_left,json=ticksLeft,proto3" json:"ticks_left,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Effect) Reset() {
+	*x = Effect{}
+	mi := &file_proto_pingpong_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Effect) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Effect) ProtoMessage() {}
+
+func (x *Effect) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_pingpong_proto_msgTypes[4]
 	if x != nil {
-		return x.Paddle2
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Effect.ProtoReflect.Descriptor instead.
+func (*Effect) Descriptor() ([]byte, []int) {
+	return file_proto_pingpong_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Effect) GetKind() PowerUpKind {
+	if x != nil {
+		return x.Kind
+	}
+	return PowerUpKind_BIG_PADDLE
+}
+
+func (x *Effect) GetPlayer() int32 {
+	if x != nil {
+		return x.Player
+	}
+	return 0
+}
+
+func (x *Effect) GetTicksLeft() int32 {
+	if x != nil {
+		return x.TicksLeft
+	}
+	return 0
+}
+
+// Bola con su propia velocidad por tick. wait son los ticks que le quedan
+// parada en el centro antes de sacar (solo en GameState.Balls).
+type Ball struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Pos   *Vector                `protobuf:"bytes,2,opt,name=pos,proto3" json:"pos,omitempty"`
+	VelX  float32                `protobuf:"fixed32,3,opt,name=vel_x,json=velX,proto3" json:"vel_x,omitempty"`
+	VelY  float32                `protobuf:"fixed32,4,opt,name=vel_y,json=velY,proto3" json:"vel_y,omitempty"`
+	Wait  int32                  `protobuf:"varint,5,opt,name=wait,proto3" json:"wait,omitempty"`
+	// Bola adicional de un potenciador: al marcar desaparece en vez de
+	// volver al centro.
+	Transient     bool `protobuf:"varint,6,opt,name=transient,proto3" json:"transient,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Ball) Reset() {
+	*x = Ball{}
+	mi := &file_proto_pingpong_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Ball) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Ball) ProtoMessage() {}
+
+func (x *Ball) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_pingpong_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Ball.ProtoReflect.Descriptor instead.
+func (*Ball) Descriptor() ([]byte, []int) {
+	return file_proto_pingpong_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Ball) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Ball) GetPos() *Vector {
+	if x != nil {
+		return x.Pos
 	}
 	return nil
 }
 
-func (x *GameState) GetScore1() int32 {
+func (x *Ball) GetVelX() float32 {
 	if x != nil {
-		return x.Score1
+		return x.VelX
 	}
 	return 0
 }
 
-func (x *GameState) GetScore2() int32 {
+func (x *Ball) GetVelY() float32 {
 	if x != nil {
-		return x.Score2
+		return x.VelY
 	}
 	return 0
 }
 
-func (x *GameState) GetPlayerId() string {
+func (x *Ball) GetWait() int32 {
 	if x != nil {
-		return x.PlayerId
+		return x.Wait
 	}
-	return ""
+	return 0
 }
 
-var File_proto_pingpong_proto protoreflect.FileDescriptor
+func (x *Ball) GetTransient() bool {
+	if x != nil {
+		return x.Transient
+	}
+	return false
+}
 
-const file_proto_pingpong_proto_rawDesc = "" +
-	"\n" +
-	"\x14proto/pingpong.proto\x12\bpingpong\"Z\n" +
-	"\n" +
-	"GameAction\x12\x1b\n" +
-	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12\x12\n" +
-	"\x04move\x18\x02 \x01(\tR\x04move\x12\x1b\n" +
-	"\troom_code\x18\x03 \x01(\tR\broomCode\"$\n" +
-	"\x06Vector\x12\f\n" +
-	"\x01X\x18\x01 \x01(\x02R\x01X\x12\f\n" +
-	"\x01Y\x18\x02 \x01(\x02R\x01Y\"\xf3\x01\n" +
-	"\tGameState\x12\x1b\n" +
-	"\troom_code\x18\x01 \x01(\tR\broomCode\x12$\n" +
-	"\x04Ball\x18\x02 \x01(\v2\x10.pingpong.VectorR\x04Ball\x12*\n" +
-	"\aPaddle1\x18\x03 \x01(\v2\x10.pingpong.VectorR\aPaddle1\x12*\n" +
-	"\aPaddle2\x18\x04 \x01(\v2\x10.pingpong.VectorR\aPaddle2\x12\x16\n" +
-	"\x06Score1\x18\x05 \x01(\x05R\x06Score1\x12\x16\n" +
-	"\x06Score2\x18\x06 \x01(\x05R\x06Score2\x12\x1b\n" +
-	"\tplayer_id\x18\a \x01(\tR\bplayerId2A\n" +
+// Pared recta de a a b en la que rebota la bola.
+type Segment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	A             *Vector                `protobuf:"bytes,1,opt,name=a,proto3" json:"a,omitempty"`
+	B             *Vector                `protobuf:"bytes,2,opt,name=b,proto3" json:"b,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Segment) Reset() {
+	*x = Segment{}
+	mi := &file_proto_pingpong_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Segment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Segment) ProtoMessage() {}
+
+func (x *Segment) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_pingpong_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Segment.ProtoReflect.Descriptor instead.
+func (*Segment) Descriptor() ([]byte, []int) {
+	return file_proto_pingpong_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Segment) GetA() *Vector {
+	if x != nil {
+		return x.A
+	}
+	return nil
+}
+
+func (x *Segment) GetB() *Vector {
+	if x != nil {
+		return x.B
+	}
+	return nil
+}
+
+// Obstáculo rectangular con centro en pos y tamaño w x h, todo normalizado
+// a [0,1] como las posiciones. Si period > 0 es móvil: va de from a to y
+// vuelve cada period ticks, y pos es dónde está ahora.
+type Obstacle struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Pos           *Vector                `protobuf:"bytes,1,opt,name=pos,proto3" json:"pos,omitempty"`
+	W             float32                `protobuf:"fixed32,2,opt,name=w,proto3" json:"w,omitempty"`
+	H             float32                `protobuf:"fixed32,3,opt,name=h,proto3" json:"h,omitempty"`
+	From          *Vector                `protobuf:"bytes,4,opt,name=from,proto3" json:"from,omitempty"`
+	To            *Vector                `protobuf:"bytes,5,opt,name=to,proto3" json:"to,omitempty"`
+	Period        int32                  `protobuf:"varint,6,opt,name=period,proto3" json:"period,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Obstacle) Reset() {
+	*x = Obstacle{}
+	mi := &file_proto_pingpong_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Obstacle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Obstacle) ProtoMessage() {}
+
+func (x *Obstacle) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_pingpong_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Obstacle.ProtoReflect.Descriptor instead.
+func (*Obstacle) Descriptor() ([]byte, []int) {
+	return file_proto_pingpong_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *Obstacle) GetPos() *Vector {
+	if x != nil {
+		return x.Pos
+	}
+	return nil
+}
+
+func (x *Obstacle) GetW() float32 {
+	if x != nil {
+		return x.W
+	}
+	return 0
+}
+
+func (x *Obstacle) GetH() float32 {
+	if x != nil {
+		return x.H
+	}
+	return 0
+}
+
+func (x *Obstacle) GetFrom() *Vector {
+	if x != nil {
+		return x.From
+	}
+	return nil
+}
+
+func (x *Obstacle) GetTo() *Vector {
+	if x != nil {
+		return x.To
+	}
+	return nil
+}
+
+func (x *Obstacle) GetPeriod() int32 {
+	if x != nil {
+		return x.Period
+	}
+	return 0
+}
+
+// Mapa de un duelo. goal_width es la fracción de cada lado, centrada, que
+// hace de portería (0 = el lado entero, como en el campo vacío); el resto
+// del lado es pared. walls son paredes adicionales y tick los ticks
+// simulados, que deciden dónde están los obstáculos móviles.
+type FieldMap struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	GoalWidth     float32                `protobuf:"fixed32,2,opt,name=goal_width,json=goalWidth,proto3" json:"goal_width,omitempty"`
+	Walls         []*Segment             `protobuf:"bytes,3,rep,name=walls,proto3" json:"walls,omitempty"`
+	Obstacles     []*Obstacle            `protobuf:"bytes,4,rep,name=obstacles,proto3" json:"obstacles,omitempty"`
+	Tick          int32                  `protobuf:"varint,5,opt,name=tick,proto3" json:"tick,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FieldMap) Reset() {
+	*x = FieldMap{}
+	mi := &file_proto_pingpong_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FieldMap) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FieldMap) ProtoMessage() {}
+
+func (x *FieldMap) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_pingpong_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FieldMap.ProtoReflect.Descriptor instead.
+func (*FieldMap) Descriptor() ([]byte, []int) {
+	return file_proto_pingpong_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *FieldMap) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FieldMap) GetGoalWidth() float32 {
+	if x != nil {
+		return x.GoalWidth
+	}
+	return 0
+}
+
+func (x *FieldMap) GetWalls() []*Segment {
+	if x != nil {
+		return x.Walls
+	}
+	return nil
+}
+
+func (x *FieldMap) GetObstacles() []*Obstacle {
+	if x != nil {
+		return x.Obstacles
+	}
+	return nil
+}
+
+func (x *FieldMap) GetTick() int32 {
+	if x != nil {
+		return x.Tick
+	}
+	return 0
+}
+
+// Modo de juego de la sala: cuántos jugadores reúne, cuántas bolas hay a
+// la vez y las reglas con que se simula, en las unidades de sim.Rules, para
+// que el cliente dibuje con las mismas medidas. wall es el modo de
+// práctica: el lado derecho es una pared.
+type GameMode struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Players       int32                  `protobuf:"varint,3,opt,name=players,proto3" json:"players,omitempty"`
+	Balls         int32                  `protobuf:"varint,4,opt,name=balls,proto3" json:"balls,omitempty"`
+	TickMs        int32                  `protobuf:"varint,5,opt,name=tick_ms,json=tickMs,proto3" json:"tick_ms,omitempty"`
+	PaddleDelta   float32                `protobuf:"fixed32,6,opt,name=paddle_delta,json=paddleDelta,proto3" json:"paddle_delta,omitempty"`
+	BallVelX      float32                `protobuf:"fixed32,7,opt,name=ball_vel_x,json=ballVelX,proto3" json:"ball_vel_x,omitempty"`
+	BallVelY      float32                `protobuf:"fixed32,8,opt,name=ball_vel_y,json=ballVelY,proto3" json:"ball_vel_y,omitempty"`
+	ScreenW       float32                `protobuf:"fixed32,9,opt,name=screen_w,json=screenW,proto3" json:"screen_w,omitempty"`
+	ScreenH       float32                `protobuf:"fixed32,10,opt,name=screen_h,json=screenH,proto3" json:"screen_h,omitempty"`
+	PaddleW       float32                `protobuf:"fixed32,11,opt,name=paddle_w,json=paddleW,proto3" json:"paddle_w,omitempty"`
+	PaddleH       float32                `protobuf:"fixed32,12,opt,name=paddle_h,json=paddleH,proto3" json:"paddle_h,omitempty"`
+	BallRadius    float32                `protobuf:"fixed32,13,opt,name=ball_radius,json=ballRadius,proto3" json:"ball_radius,omitempty"`
+	Wall          bool                   `protobuf:"varint,14,opt,name=wall,proto3" json:"wall,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GameMode) Reset() {
+	*x = GameMode{}
+	mi := &file_proto_pingpong_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GameMode) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameMode) ProtoMessage() {}
+
+func (x *GameMode) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_pingpong_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameMode.ProtoReflect.Descriptor instead.
+func (*GameMode) Descriptor() ([]byte, []int) {
+	return file_proto_pingpong_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GameMode) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GameMode) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *GameMode) GetPlayers() int32 {
+	if x != nil {
+		return x.Players
+	}
+	return 0
+}
+
+func (x *GameMode) GetBalls() int32 {
+	if x != nil {
+		return x.Balls
+	}
+	return 0
+}
+
+func (x *GameMode) GetTickMs() int32 {
+	if x != nil {
+		return x.TickMs
+	}
+	return 0
+}
+
+func (x *GameMode) GetPaddleDelta() float32 {
+	if x != nil {
+		return x.PaddleDelta
+	}
+	return 0
+}
+
+func (x *GameMode) GetBallVelX() float32 {
+	if x != nil {
+		return x.BallVelX
+	}
+	return 0
+}
+
+func (x *GameMode) GetBallVelY() float32 {
+	if x != nil {
+		return x.BallVelY
+	}
+	return 0
+}
+
+func (x *GameMode) GetScreenW() float32 {
+	if x != nil {
+		return x.ScreenW
+	}
+	return 0
+}
+
+func (x *GameMode) GetScreenH() float32 {
+	if x != nil {
+		return x.ScreenH
+	}
+	return 0
+}
+
+func (x *GameMode) GetPaddleW() float32 {
+	if x != nil {
+		return x.PaddleW
+	}
+	return 0
+}
+
+func (x *GameMode) GetPaddleH() float32 {
+	if x != nil {
+		return x.PaddleH
+	}
+	return 0
+}
+
+func (x *GameMode) GetBallRadius() float32 {
+	if x != nil {
+		return x.BallRadius
+	}
+	return 0
+}
+
+func (x *GameMode) GetWall() bool {
+	if x != nil {
+		return x.Wall
+	}
+	return false
+}
+
+type GameState struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	RoomCode string                 `protobuf:"bytes,1,opt,name=room_code,json=roomCode,proto3" json:"room_code,omitempty"`
+	Ball     *Vector                `protobuf:"bytes,2,opt,name=Ball,proto3" json:"Ball,omitempty"`
+	Paddle1  *Vector                `protobuf:"bytes,3,opt,name=Paddle1,proto3" json:"Paddle1,omitempty"`
+	Paddle2  *Vector                `protobuf:"bytes,4,opt,name=Paddle2,proto3" json:"Paddle2,omitempty"`
+	Score1   int32                  `protobuf:"varint,5,opt,name=Score1,proto3" json:"Score1,omitempty"`
+	Score2   int32                  `protobuf:"varint,6,opt,name=Score2,proto3" json:"Score2,omitempty"`
+	PlayerId string                 `protobuf:"bytes,7,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	Name1    string                 `protobuf:"bytes,8,opt,name=Name1,proto3" json:"Name1,omitempty"`
+	Name2    string                 `protobuf:"bytes,9,opt,name=Name2,proto3" json:"Name2,omitempty"`
+	Rating1  float32                `protobuf:"fixed32,10,opt,name=Rating1,proto3" json:"Rating1,omitempty"`
+	Rating2  float32                `protobuf:"fixed32,11,opt,name=Rating2,proto3" json:"Rating2,omitempty"`
+	WinProb1 float32                `protobuf:"fixed32,12,opt,name=WinProb1,proto3" json:"WinProb1,omitempty"`
+	// Pausa: quién la pidió, pausas que le quedan a cada jugador, quién ha
+	// aceptado reanudar y, si ambos lo han hecho, la cuenta atrás en ms.
+	Paused       bool  `protobuf:"varint,13,opt,name=Paused,proto3" json:"Paused,omitempty"`
+	PausedBy     int32 `protobuf:"varint,14,opt,name=PausedBy,proto3" json:"PausedBy,omitempty"`
+	PausesLeft1  int32 `protobuf:"varint,15,opt,name=PausesLeft1,proto3" json:"PausesLeft1,omitempty"`
+	PausesLeft2  int32 `protobuf:"varint,16,opt,name=PausesLeft2,proto3" json:"PausesLeft2,omitempty"`
+	ResumeReady1 bool  `protobuf:"varint,17,opt,name=ResumeReady1,proto3" json:"ResumeReady1,omitempty"`
+	ResumeReady2 bool  `protobuf:"varint,18,opt,name=ResumeReady2,proto3" json:"ResumeReady2,omitempty"`
+	ResumeInMs   int32 `protobuf:"varint,19,opt,name=ResumeInMs,proto3" json:"ResumeInMs,omitempty"`
+	// Fin de partida: si alguien llega a los puntos para ganar y ambos siguen
+	// conectados, llega un último estado con Finished, el motivo y el ganador
+	// (Winner 1 o 2), y se abre el plazo de revancha: quién la ha ofrecido y
+	// cuánto queda en ms. Si ambos aceptan, el mismo stream recibe el estado inicial
+	// de la nueva sala con los lados cambiados.
+	Finished      bool   `protobuf:"varint,20,opt,name=Finished,proto3" json:"Finished,omitempty"`
+	EndReason     string `protobuf:"bytes,21,opt,name=EndReason,proto3" json:"EndReason,omitempty"`
+	Winner        int32  `protobuf:"varint,22,opt,name=Winner,proto3" json:"Winner,omitempty"`
+	RematchOffer1 bool   `protobuf:"varint,23,opt,name=RematchOffer1,proto3" json:"RematchOffer1,omitempty"`
+	RematchOffer2 bool   `protobuf:"varint,24,opt,name=RematchOffer2,proto3" json:"RematchOffer2,omitempty"`
+	RematchInMs   int32  `protobuf:"varint,25,opt,name=RematchInMs,proto3" json:"RematchInMs,omitempty"`
+	// Serie al mejor de SeriesLength (1 = partida suelta): número de juego y
+	// juegos ganados por cada asiento de esta sala. Si el juego termina sin
+	// decidir la serie no hay revancha: el siguiente empieza solo, con los
+	// lados cambiados, pasados NextGameInMs.
+	SeriesLength int32 `protobuf:"varint,26,opt,name=SeriesLength,proto3" json:"SeriesLength,omitempty"`
+	SeriesGame   int32 `protobuf:"varint,27,opt,name=SeriesGame,proto3" json:"SeriesGame,omitempty"`
+	SeriesWins1  int32 `protobuf:"varint,28,opt,name=SeriesWins1,proto3" json:"SeriesWins1,omitempty"`
+	SeriesWins2  int32 `protobuf:"varint,29,opt,name=SeriesWins2,proto3" json:"SeriesWins2,omitempty"`
+	NextGameInMs int32 `protobuf:"varint,30,opt,name=NextGameInMs,proto3" json:"NextGameInMs,omitempty"`
+	// Salas de más de dos jugadores: una pala por asiento, en el orden de
+	// player_id, cada una con su marcador; Paddle1, Paddle2 y los campos por
+	// jugador de arriba no se usan. En la de dos contra dos (asientos 1 y 2
+	// a la izquierda, 3 y 4 a la derecha) Name*, Rating*, WinProb1 y
+	// Score1/Score2 son los de cada equipo, y el marcador de cada pala los
+	// goles de ese jugador. LastHit es el asiento que tocó la bola por última
+	// vez (0 = nadie desde el saque).
+	Paddles []*Paddle `protobuf:"bytes,31,rep,name=Paddles,proto3" json:"Paddles,omitempty"`
+	LastHit int32     `protobuf:"varint,32,opt,name=LastHit,proto3" json:"LastHit,omitempty"`
+	// Potenciadores (solo en partidas de dos): objetos en el campo y efectos
+	// en curso; las bolas adicionales van en Balls. Los recoge quien tocó la bola por última
+	// vez (LastHit) cuando una bola pasa por encima. Seed es el estado del
+	// generador con el que la simulación decide dónde y cuándo aparecen, de
+	// modo que las repeticiones salen idénticas.
+	PowerUps   bool       `protobuf:"varint,33,opt,name=PowerUps,proto3" json:"PowerUps,omitempty"`
+	Seed       uint64     `protobuf:"varint,34,opt,name=Seed,proto3" json:"Seed,omitempty"`
+	NextItemIn int32      `protobuf:"varint,35,opt,name=NextItemIn,proto3" json:"NextItemIn,omitempty"`
+	Items      []*PowerUp `protobuf:"bytes,36,rep,name=Items,proto3" json:"Items,omitempty"`
+	Effects    []*Effect  `protobuf:"bytes,37,rep,name=Effects,proto3" json:"Effects,omitempty"`
+	// Bolas con velocidad propia. En el modo de varias bolas (uno contra
+	// uno) son todas las de la partida y Ball no se usa: cada gol suma un
+	// punto y devuelve esa bola al centro, de donde vuelve a salir pasado su
+	// wait. Con potenciadores son las adicionales (transient), que juegan
+	// junto a Ball y desaparecen al marcar.
+	Balls []*Ball `protobuf:"bytes,39,rep,name=Balls,proto3" json:"Balls,omitempty"`
+	// Mapa de la partida (nil = campo vacío).
+	Map *FieldMap `protobuf:"bytes,40,opt,name=Map,proto3" json:"Map,omitempty"`
+	// Modo de juego de la sala: la configuración de la partida. Solo llega en
+	// el primer estado de cada sala; el cliente la guarda para los siguientes.
+	Mode *GameMode `protobuf:"bytes,41,opt,name=Mode,proto3" json:"Mode,omitempty"`
+	// Dirección que mantiene cada asiento (Inputs[asiento-1]): -1, 0 o 1. En
+	// cada tick la pala avanza PaddleDelta en esa dirección, por muchas
+	// acciones que envíe el cliente.
+	Inputs        []int32 `protobuf:"zigzag32,42,rep,packed,name=Inputs,proto3" json:"Inputs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GameState) Reset() {
+	*x = GameState{}
+	mi := &file_proto_pingpong_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GameState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameState) ProtoMessage() {}
+
+func (x *GameState) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_pingpong_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameState.ProtoReflect.Descriptor instead.
+func (*GameState) Descriptor() ([]byte, []int) {
+	return file_proto_pingpong_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GameState) GetRoomCode() string {
+	if x != nil {
+		return x.RoomCode
+	}
+	return ""
+}
+
+func (x *GameState) GetBall() *Vector {
+	if x != nil {
+		return x.Ball
+	}
+	return nil
+}
+
+func (x *GameState) GetPaddle1() *Vector {
+	if x != nil {
+		return x.Paddle1
+	}
+	return nil
+}
+
+func (x *GameState) GetPaddle2() *Vector {
+	if x != nil {
+		return x.Paddle2
+	}
+	return nil
+}
+
+func (x *GameState) GetScore1() int32 {
+	if x != nil {
+		return x.Score1
+	}
+	return 0
+}
+
+func (x *GameState) GetScore2() int32 {
+	if x != nil {
+		return x.Score2
+	}
+	return 0
+}
+
+func (x *GameState) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *GameState) GetName1() string {
+	if x != nil {
+		return x.Name1
+	}
+	return ""
+}
+
+func (x *GameState) GetName2() string {
+	if x != nil {
+		return x.Name2
+	}
+	return ""
+}
+
+func (x *GameState) GetRating1() float32 {
+	if x != nil {
+		return x.Rating1
+	}
+	return 0
+}
+
+func (x *GameState) GetRating2() float32 {
+	if x != nil {
+		return x.Rating2
+	}
+	return 0
+}
+
+func (x *GameState) GetWinProb1() float32 {
+	if x != nil {
+		return x.WinProb1
+	}
+	return 0
+}
+
+func (x *GameState) GetPaused() bool {
+	if x != nil {
+		return x.Paused
+	}
+	return false
+}
+
+func (x *GameState) GetPausedBy() int32 {
+	if x != nil {
+		return x.PausedBy
+	}
+	return 0
+}
+
+func (x *GameState) GetPausesLeft1() int32 {
+	if x != nil {
+		return x.PausesLeft1
+	}
+	return 0
+}
+
+func (x *GameState) GetPausesLeft2() int32 {
+	if x != nil {
+		return x.PausesLeft2
+	}
+	return 0
+}
+
+func (x *GameState) GetResumeReady1() bool {
+	if x != nil {
+		return x.ResumeReady1
+	}
+	return false
+}
+
+func (x *GameState) GetResumeReady2() bool {
+	if x != nil {
+		return x.ResumeReady2
+	}
+	return false
+}
+
+func (x *GameState) GetResumeInMs() int32 {
+	if x != nil {
+		return x.ResumeInMs
+	}
+	return 0
+}
+
+func (x *GameState) GetFinished() bool {
+	if x != nil {
+		return x.Finished
+	}
+	return false
+}
+
+func (x *GameState) GetEndReason() string {
+	if x != nil {
+		return x.EndReason
+	}
+	return ""
+}
+
+func (x *GameState) GetWinner() int32 {
+	if x != nil {
+		return x.Winner
+	}
+	return 0
+}
+
+func (x *GameState) GetRematchOffer1() bool {
+	if x != nil {
+		return x.RematchOffer1
+	}
+	return false
+}
+
+func (x *GameState) GetRematchOffer2() bool {
+	if x != nil {
+		return x.RematchOffer2
+	}
+	return false
+}
+
+func (x *GameState) GetRematchInMs() int32 {
+	if x != nil {
+		return x.RematchInMs
+	}
+	return 0
+}
+
+func (x *GameState) GetSeriesLength() int32 {
+	if x != nil {
+		return x.SeriesLength
+	}
+	return 0
+}
+
+func (x *GameState) GetSeriesGame() int32 {
+	if x != nil {
+		return x.SeriesGame
+	}
+	return 0
+}
+
+func (x *GameState) GetSeriesWins1() int32 {
+	if x != nil {
+		return x.SeriesWins1
+	}
+	return 0
+}
+
+func (x *GameState) GetSeriesWins2() int32 {
+	if x != nil {
+		return x.SeriesWins2
+	}
+	return 0
+}
+
+func (x *GameState) GetNextGameInMs() int32 {
+	if x != nil {
+		return x.NextGameInMs
+	}
+	return 0
+}
+
+func (x *GameState) GetPaddles() []*Paddle {
+	if x != nil {
+		return x.Paddles
+	}
+	return nil
+}
+
+func (x *GameState) GetLastHit() int32 {
+	if x != nil {
+		return x.LastHit
+	}
+	return 0
+}
+
+func (x *GameState) GetPowerUps() bool {
+	if x != nil {
+		return x.PowerUps
+	}
+	return false
+}
+
+func (x *GameState) GetSeed() uint64 {
+	if x != nil {
+		return x.Seed
+	}
+	return 0
+}
+
+func (x *GameState) GetNextItemIn() int32 {
+	if x != nil {
+		return x.NextItemIn
+	}
+	return 0
+}
+
+func (x *GameState) GetItems() []*PowerUp {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *GameState) GetEffects() []*Effect {
+	if x != nil {
+		return x.Effects
+	}
+	return nil
+}
+
+func (x *GameState) GetBalls() []*Ball {
+	if x != nil {
+		return x.Balls
+	}
+	return nil
+}
+
+func (x *GameState) GetMap() *FieldMap {
+	if x != nil {
+		return x.Map
+	}
+	return nil
+}
+
+func (x *GameState) GetMode() *GameMode {
+	if x != nil {
+		return x.Mode
+	}
+	return nil
+}
+
+func (x *GameState) GetInputs() []int32 {
+	if x != nil {
+		return x.Inputs
+	}
+	return nil
+}
+
+var File_proto_pingpong_proto protoreflect.FileDescriptor
+
+const file_proto_pingpong_proto_rawDesc = "" +
+	"\n" +
+	"\x14proto/pingpong.proto\x12\bpingpong\"\xae\x01\n" +
+	"\n" +
+	"GameAction\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12\x12\n" +
+	"\x04move\x18\x02 \x01(\tR\x04move\x12\x1b\n" +
+	"\troom_code\x18\x03 \x01(\tR\broomCode\x12#\n" +
+	"\rtournament_id\x18\x04 \x01(\tR\ftournamentId\x12\x12\n" +
+	"\x04mode\x18\x05 \x01(\tR\x04mode\x12\x19\n" +
+	"\bmap_name\x18\x06 \x01(\tR\amapName\"$\n" +
+	"\x06Vector\x12\f\n" +
+	"\x01X\x18\x01 \x01(\x02R\x01X\x12\f\n" +
+	"\x01Y\x18\x02 \x01(\x02R\x01Y\"\x92\x01\n" +
+	"\x06Paddle\x12\"\n" +
+	"\x04side\x18\x01 \x01(\x0e2\x0e.pingpong.SideR\x04side\x12\"\n" +
+	"\x03pos\x18\x02 \x01(\v2\x10.pingpong.VectorR\x03pos\x12\x14\n" +
+	"\x05score\x18\x03 \x01(\x05R\x05score\x12\x12\n" +
+	"\x04name\x18\x04 \x01(\tR\x04name\x12\x16\n" +
+	"\x06rating\x18\x05 \x01(\x02R\x06rating\"X\n" +
+	"\aPowerUp\x12)\n" +
+	"\x04kind\x18\x01 \x01(\x0e2\x15.pingpong.PowerUpKindR\x04kind\x12\"\n" +
+	"\x03pos\x18\x02 \x01(\v2\x10.pingpong.VectorR\x03pos\"j\n" +
+	"\x06Effect\x12)\n" +
+	"\x04kind\x18\x01 \x01(\x0e2\x15.pingpong.PowerUpKindR\x04kind\x12\x16\n" +
+	"\x06player\x18\x02 \x01(\x05R\x06player\x12\x1d\n" +
+	"\n" +
+	"ticks_left\x18\x03 \x01(\x05R\tticksLeft\"\x96\x01\n" +
+	"\x04Ball\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\"\n" +
+	"\x03pos\x18\x02 \x01(\v2\x10.pingpong.VectorR\x03pos\x12\x13\n" +
+	"\x05vel_x\x18\x03 \x01(\x02R\x04velX\x12\x13\n" +
+	"\x05vel_y\x18\x04 \x01(\x02R\x04velY\x12\x12\n" +
+	"\x04wait\x18\x05 \x01(\x05R\x04wait\x12\x1c\n" +
+	"\ttransient\x18\x06 \x01(\bR\ttransient\"I\n" +
+	"\aSegment\x12\x1e\n" +
+	"\x01a\x18\x01 \x01(\v2\x10.pingpong.VectorR\x01a\x12\x1e\n" +
+	"\x01b\x18\x02 \x01(\v2\x10.pingpong.VectorR\x01b\"\xaa\x01\n" +
+	"\bObstacle\x12\"\n" +
+	"\x03pos\x18\x01 \x01(\v2\x10.pingpong.VectorR\x03pos\x12\f\n" +
+	"\x01w\x18\x02 \x01(\x02R\x01w\x12\f\n" +
+	"\x01h\x18\x03 \x01(\x02R\x01h\x12$\n" +
+	"\x04from\x18\x04 \x01(\v2\x10.pingpong.VectorR\x04from\x12 \n" +
+	"\x02to\x18\x05 \x01(\v2\x10.pingpong.VectorR\x02to\x12\x16\n" +
+	"\x06period\x18\x06 \x01(\x05R\x06period\"\xac\x01\n" +
+	"\bFieldMap\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"goal_width\x18\x02 \x01(\x02R\tgoalWidth\x12'\n" +
+	"\x05walls\x18\x03 \x03(\v2\x11.pingpong.SegmentR\x05walls\x120\n" +
+	"\tobstacles\x18\x04 \x03(\v2\x12.pingpong.ObstacleR\tobstacles\x12\x12\n" +
+	"\x04tick\x18\x05 \x01(\x05R\x04tick\"\xfd\x02\n" +
+	"\bGameMode\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x18\n" +
+	"\aplayers\x18\x03 \x01(\x05R\aplayers\x12\x14\n" +
+	"\x05balls\x18\x04 \x01(\x05R\x05balls\x12\x17\n" +
+	"\atick_ms\x18\x05 \x01(\x05R\x06tickMs\x12!\n" +
+	"\fpaddle_delta\x18\x06 \x01(\x02R\vpaddleDelta\x12\x1c\n" +
+	"\n" +
+	"ball_vel_x\x18\a \x01(\x02R\bballVelX\x12\x1c\n" +
+	"\n" +
+	"ball_vel_y\x18\b \x01(\x02R\bballVelY\x12\x19\n" +
+	"\bscreen_w\x18\t \x01(\x02R\ascreenW\x12\x19\n" +
+	"\bscreen_h\x18\n" +
+	" \x01(\x02R\ascreenH\x12\x19\n" +
+	"\bpaddle_w\x18\v \x01(\x02R\apaddleW\x12\x19\n" +
+	"\bpaddle_h\x18\f \x01(\x02R\apaddleH\x12\x1f\n" +
+	"\vball_radius\x18\r \x01(\x02R\n" +
+	"ballRadius\x12\x12\n" +
+	"\x04wall\x18\x0e \x01(\bR\x04wall\"\xc4\n" +
+	"\n" +
+	"\tGameState\x12\x1b\n" +
+	"\troom_code\x18\x01 \x01(\tR\broomCode\x12$\n" +
+	"\x04Ball\x18\x02 \x01(\v2\x10.pingpong.VectorR\x04Ball\x12*\n" +
+	"\aPaddle1\x18\x03 \x01(\v2\x10.pingpong.VectorR\aPaddle1\x12*\n" +
+	"\aPaddle2\x18\x04 \x01(\v2\x10.pingpong.VectorR\aPaddle2\x12\x16\n" +
+	"\x06Score1\x18\x05 \x01(\x05R\x06Score1\x12\x16\n" +
+	"\x06Score2\x18\x06 \x01(\x05R\x06Score2\x12\x1b\n" +
+	"\tplayer_id\x18\a \x01(\tR\bplayerId\x12\x14\n" +
+	"\x05Name1\x18\b \x01(\tR\x05Name1\x12\x14\n" +
+	"\x05Name2\x18\t \x01(\tR\x05Name2\x12\x18\n" +
+	"\aRating1\x18\n" +
+	" \x01(\x02R\aRating1\x12\x18\n" +
+	"\aRating2\x18\v \x01(\x02R\aRating2\x12\x1a\n" +
+	"\bWinProb1\x18\f \x01(\x02R\bWinProb1\x12\x16\n" +
+	"\x06Paused\x18\r \x01(\bR\x06Paused\x12\x1a\n" +
+	"\bPausedBy\x18\x0e \x01(\x05R\bPausedBy\x12 \n" +
+	"\vPausesLeft1\x18\x0f \x01(\x05R\vPausesLeft1\x12 \n" +
+	"\vPausesLeft2\x18\x10 \x01(\x05R\vPausesLeft2\x12\"\n" +
+	"\fResumeReady1\x18\x11 \x01(\bR\fResumeReady1\x12\"\n" +
+	"\fResumeReady2\x18\x12 \x01(\bR\fResumeReady2\x12\x1e\n" +
+	"\n" +
+	"ResumeInMs\x18\x13 \x01(\x05R\n" +
+	"ResumeInMs\x12\x1a\n" +
+	"\bFinished\x18\x14 \x01(\bR\bFinished\x12\x1c\n" +
+	"\tEndReason\x18\x15 \x01(\tR\tEndReason\x12\x16\n" +
+	"\x06Winner\x18\x16 \x01(\x05R\x06Winner\x12$\n" +
+	"\rRematchOffer1\x18\x17 \x01(\bR\rRematchOffer1\x12$\n" +
+	"\rRematchOffer2\x18\x18 \x01(\bR\rRematchOffer2\x12 \n" +
+	"\vRematchInMs\x18\x19 \x01(\x05R\vRematchInMs\x12\"\n" +
+	"\fSeriesLength\x18\x1a \x01(\x05R\fSeriesLength\x12\x1e\n" +
+	"\n" +
+	"SeriesGame\x18\x1b \x01(\x05R\n" +
+	"SeriesGame\x12 \n" +
+	"\vSeriesWins1\x18\x1c \x01(\x05R\vSeriesWins1\x12 \n" +
+	"\vSeriesWins2\x18\x1d \x01(\x05R\vSeriesWins2\x12\"\n" +
+	"\fNextGameInMs\x18\x1e \x01(\x05R\fNextGameInMs\x12*\n" +
+	"\aPaddles\x18\x1f \x03(\v2\x10.pingpong.PaddleR\aPaddles\x12\x18\n" +
+	"\aLastHit\x18  \x01(\x05R\aLastHit\x12\x1a\n" +
+	"\bPowerUps\x18! \x01(\bR\bPowerUps\x12\x12\n" +
+	"\x04Seed\x18\" \x01(\x04R\x04Seed\x12\x1e\n" +
+	"\n" +
+	"NextItemIn\x18# \x01(\x05R\n" +
+	"NextItemIn\x12'\n" +
+	"\x05Items\x18$ \x03(\v2\x11.pingpong.PowerUpR\x05Items\x12*\n" +
+	"\aEffects\x18% \x03(\v2\x10.pingpong.EffectR\aEffects\x12$\n" +
+	"\x05Balls\x18' \x03(\v2\x0e.pingpong.BallR\x05Balls\x12$\n" +
+	"\x03Map\x18( \x01(\v2\x12.pingpong.FieldMapR\x03Map\x12&\n" +
+	"\x04Mode\x18) \x01(\v2\x12.pingpong.GameModeR\x04Mode\x12\x16\n" +
+	"\x06Inputs\x18* \x03(\x11R\x06InputsJ\x04\b&\x10'R\n" +
+	"ExtraBalls*0\n" +
+	"\x04Side\x12\b\n" +
+	"\x04LEFT\x10\x00\x12\t\n" +
+	"\x05RIGHT\x10\x01\x12\a\n" +
+	"\x03TOP\x10\x02\x12\n" +
+	"\n" +
+	"\x06BOTTOM\x10\x03*I\n" +
+	"\vPowerUpKind\x12\x0e\n" +
+	"\n" +
+	"BIG_PADDLE\x10\x00\x12\r\n" +
+	"\tFAST_BALL\x10\x01\x12\x0e\n" +
+	"\n" +
+	"MULTI_BALL\x10\x02\x12\v\n" +
+	"\aREVERSE\x10\x032A\n" +
 	"\bPingPong\x125\n" +
 	"\x04Play\x12\x14.pingpong.GameAction\x1a\x13.pingpong.GameState(\x010\x01B\x19Z\x17JuegoCeN/proto;pingpongb\x06proto3"
 
@@ -261,23 +1479,53 @@ func file_proto_pingpong_proto_rawDescGZIP() []byte {
 	return file_proto_pingpong_proto_rawDescData
 }
 
-var file_proto_pingpong_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_proto_pingpong_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_proto_pingpong_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
 var file_proto_pingpong_proto_goTypes = []any{
-	(*GameAction)(nil), // 0: pingpong.GameAction
-	(*Vector)(nil),     // 1: pingpong.Vector
-	(*GameState)(nil),  // 2: pingpong.GameState
+	(Side)(0),          // 0: pingpong.Side
+	(PowerUpKind)(0),   // 1: pingpong.PowerUpKind
+	(*GameAction)(nil), // 2: pingpong.GameAction
+	(*Vector)(nil),     // 3: pingpong.Vector
+	(*Paddle)(nil),     // 4: pingpong.Paddle
+	(*PowerUp)(nil),    // 5: pingpong.PowerUp
+	(*Effect)(nil),     // 6: pingpong.Effect
+	(*Ball)(nil),       // 7: pingpong.Ball
+	(*Segment)(nil),    // 8: pingpong.Segment
+	(*Obstacle)(nil),   // 9: pingpong.Obstacle
+	(*FieldMap)(nil),   // 10: pingpong.FieldMap
+	(*GameMode)(nil),   // 11: pingpong.GameMode
+	(*GameState)(nil),  // 12: pingpong.GameState
 }
 var file_proto_pingpong_proto_depIdxs = []int32{
-	1, // 0: pingpong.GameState.Ball:type_name -> pingpong.Vector
-	1, // 1: pingpong.GameState.Paddle1:type_name -> pingpong.Vector
-	1, // 2: pingpong.GameState.Paddle2:type_name -> pingpong.Vector
-	0, // 3: pingpong.PingPong.Play:input_type -> pingpong.GameAction
-	2, // 4: pingpong.PingPong.Play:output_type -> pingpong.GameState
-	4, // [4:5] is the sub-list for method output_type
-	3, // [3:4] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	0,  // 0: pingpong.Paddle.side:type_name -> pingpong.Side
+	3,  // 1: pingpong.Paddle.pos:type_name -> pingpong.Vector
+	1,  // 2: pingpong.PowerUp.kind:type_name -> pingpong.PowerUpKind
+	3,  // 3: pingpong.PowerUp.pos:type_name -> pingpong.Vector
+	1,  // 4: pingpong.Effect.kind:type_name -> pingpong.PowerUpKind
+	3,  // 5: pingpong.Ball.pos:type_name -> pingpong.Vector
+	3,  // 6: pingpong.Segment.a:type_name -> pingpong.Vector
+	3,  // 7: pingpong.Segment.b:type_name -> pingpong.Vector
+	3,  // 8: pingpong.Obstacle.pos:type_name -> pingpong.Vector
+	3,  // 9: pingpong.Obstacle.from:type_name -> pingpong.Vector
+	3,  // 10: pingpong.Obstacle.to:type_name -> pingpong.Vector
+	8,  // 11: pingpong.FieldMap.walls:type_name -> pingpong.Segment
+	9,  // 12: pingpong.FieldMap.obstacles:type_name -> pingpong.Obstacle
+	3,  // 13: pingpong.GameState.Ball:type_name -> pingpong.Vector
+	3,  // 14: pingpong.GameState.Paddle1:type_name -> pingpong.Vector
+	3,  // 15: pingpong.GameState.Paddle2:type_name -> pingpong.Vector
+	4,  // 16: pingpong.GameState.Paddles:type_name -> pingpong.Paddle
+	5,  // 17: pingpong.GameState.Items:type_name -> pingpong.PowerUp
+	6,  // 18: pingpong.GameState.Effects:type_name -> pingpong.Effect
+	7,  // 19: pingpong.GameState.Balls:type_name -> pingpong.Ball
+	10, // 20: pingpong.GameState.Map:type_name -> pingpong.FieldMap
+	11, // 21: pingpong.GameState.Mode:type_name -> pingpong.GameMode
+	2,  // 22: pingpong.PingPong.Play:input_type -> pingpong.GameAction
+	12, // 23: pingpong.PingPong.Play:output_type -> pingpong.GameState
+	23, // [23:24] is the sub-list for method output_type
+	22, // [22:23] is the sub-list for method input_type
+	22, // [22:22] is the sub-list for extension type_name
+	22, // [22:22] is the sub-list for extension extendee
+	0,  // [0:22] is the sub-list for field type_name
 }
 
 func init() { file_proto_pingpong_proto_init() }
@@ -290,13 +1538,14 @@ func file_proto_pingpong_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_pingpong_proto_rawDesc), len(file_proto_pingpong_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   3,
+			NumEnums:      2,
+			NumMessages:   11,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_proto_pingpong_proto_goTypes,
 		DependencyIndexes: file_proto_pingpong_proto_depIdxs,
+		EnumInfos:         file_proto_pingpong_proto_enumTypes,
 		MessageInfos:      file_proto_pingpong_proto_msgTypes,
 	}.Build()
 	File_proto_pingpong_proto = out.File
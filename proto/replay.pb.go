@@ -0,0 +1,708 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.30.2
+// source: proto/replay.proto
+
+package pingpong
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Parámetros de la simulación con los que se jugó la partida.
+type ReplayRules struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TickMs        int32                  `protobuf:"varint,1,opt,name=tick_ms,json=tickMs,proto3" json:"tick_ms,omitempty"`
+	PaddleDelta   float32                `protobuf:"fixed32,2,opt,name=paddle_delta,json=paddleDelta,proto3" json:"paddle_delta,omitempty"`
+	BallVelX      float32                `protobuf:"fixed32,3,opt,name=ball_vel_x,json=ballVelX,proto3" json:"ball_vel_x,omitempty"`
+	BallVelY      float32                `protobuf:"fixed32,4,opt,name=ball_vel_y,json=ballVelY,proto3" json:"ball_vel_y,omitempty"`
+	ScreenW       float32                `protobuf:"fixed32,5,opt,name=screen_w,json=screenW,proto3" json:"screen_w,omitempty"`
+	ScreenH       float32                `protobuf:"fixed32,6,opt,name=screen_h,json=screenH,proto3" json:"screen_h,omitempty"`
+	PaddleW       float32                `protobuf:"fixed32,7,opt,name=paddle_w,json=paddleW,proto3" json:"paddle_w,omitempty"`
+	PaddleH       float32                `protobuf:"fixed32,8,opt,name=paddle_h,json=paddleH,proto3" json:"paddle_h,omitempty"`
+	BallRadius    float32                `protobuf:"fixed32,9,opt,name=ball_radius,json=ballRadius,proto3" json:"ball_radius,omitempty"`
+	Wall          bool                   `protobuf:"varint,10,opt,name=wall,proto3" json:"wall,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplayRules) Reset() {
+	*x = ReplayRules{}
+	mi := &file_proto_replay_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplayRules) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayRules) ProtoMessage() {}
+
+func (x *ReplayRules) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_replay_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayRules.ProtoReflect.Descriptor instead.
+func (*ReplayRules) Descriptor() ([]byte, []int) {
+	return file_proto_replay_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ReplayRules) GetTickMs() int32 {
+	if x != nil {
+		return x.TickMs
+	}
+	return 0
+}
+
+func (x *ReplayRules) GetPaddleDelta() float32 {
+	if x != nil {
+		return x.PaddleDelta
+	}
+	return 0
+}
+
+func (x *ReplayRules) GetBallVelX() float32 {
+	if x != nil {
+		return x.BallVelX
+	}
+	return 0
+}
+
+func (x *ReplayRules) GetBallVelY() float32 {
+	if x != nil {
+		return x.BallVelY
+	}
+	return 0
+}
+
+func (x *ReplayRules) GetScreenW() float32 {
+	if x != nil {
+		return x.ScreenW
+	}
+	return 0
+}
+
+func (x *ReplayRules) GetScreenH() float32 {
+	if x != nil {
+		return x.ScreenH
+	}
+	return 0
+}
+
+func (x *ReplayRules) GetPaddleW() float32 {
+	if x != nil {
+		return x.PaddleW
+	}
+	return 0
+}
+
+func (x *ReplayRules) GetPaddleH() float32 {
+	if x != nil {
+		return x.PaddleH
+	}
+	return 0
+}
+
+func (x *ReplayRules) GetBallRadius() float32 {
+	if x != nil {
+		return x.BallRadius
+	}
+	return 0
+}
+
+func (x *ReplayRules) GetWall() bool {
+	if x != nil {
+		return x.Wall
+	}
+	return false
+}
+
+// Acción de un jugador: dir -1 sube, 1 baja y 0 suelta.
+type ReplayInput struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Player        int32                  `protobuf:"varint,1,opt,name=player,proto3" json:"player,omitempty"`
+	Dir           int32                  `protobuf:"zigzag32,2,opt,name=dir,proto3" json:"dir,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplayInput) Reset() {
+	*x = ReplayInput{}
+	mi := &file_proto_replay_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplayInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayInput) ProtoMessage() {}
+
+func (x *ReplayInput) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_replay_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayInput.ProtoReflect.Descriptor instead.
+func (*ReplayInput) Descriptor() ([]byte, []int) {
+	return file_proto_replay_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ReplayInput) GetPlayer() int32 {
+	if x != nil {
+		return x.Player
+	}
+	return 0
+}
+
+func (x *ReplayInput) GetDir() int32 {
+	if x != nil {
+		return x.Dir
+	}
+	return 0
+}
+
+// Acciones aplicadas, en orden, justo antes de simular el tick indicado.
+// Solo se guardan los ticks con alguna acción y, con held_inputs, solo los
+// cambios de dirección.
+type ReplayTick struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tick          uint32                 `protobuf:"varint,1,opt,name=tick,proto3" json:"tick,omitempty"`
+	Inputs        []*ReplayInput         `protobuf:"bytes,2,rep,name=inputs,proto3" json:"inputs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplayTick) Reset() {
+	*x = ReplayTick{}
+	mi := &file_proto_replay_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplayTick) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayTick) ProtoMessage() {}
+
+func (x *ReplayTick) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_replay_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayTick.ProtoReflect.Descriptor instead.
+func (*ReplayTick) Descriptor() ([]byte, []int) {
+	return file_proto_replay_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ReplayTick) GetTick() uint32 {
+	if x != nil {
+		return x.Tick
+	}
+	return 0
+}
+
+func (x *ReplayTick) GetInputs() []*ReplayInput {
+	if x != nil {
+		return x.Inputs
+	}
+	return nil
+}
+
+// Estado tras simular el tick indicado, con la velocidad de la bola para
+// poder seguir simulando desde aquí.
+type ReplayCheckpoint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tick          uint32                 `protobuf:"varint,1,opt,name=tick,proto3" json:"tick,omitempty"`
+	State         *GameState             `protobuf:"bytes,2,opt,name=state,proto3" json:"state,omitempty"`
+	VelX          float32                `protobuf:"fixed32,3,opt,name=vel_x,json=velX,proto3" json:"vel_x,omitempty"`
+	VelY          float32                `protobuf:"fixed32,4,opt,name=vel_y,json=velY,proto3" json:"vel_y,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplayCheckpoint) Reset() {
+	*x = ReplayCheckpoint{}
+	mi := &file_proto_replay_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplayCheckpoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayCheckpoint) ProtoMessage() {}
+
+func (x *ReplayCheckpoint) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_replay_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayCheckpoint.ProtoReflect.Descriptor instead.
+func (*ReplayCheckpoint) Descriptor() ([]byte, []int) {
+	return file_proto_replay_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *ReplayCheckpoint) GetTick() uint32 {
+	if x != nil {
+		return x.Tick
+	}
+	return 0
+}
+
+func (x *ReplayCheckpoint) GetState() *GameState {
+	if x != nil {
+		return x.State
+	}
+	return nil
+}
+
+func (x *ReplayCheckpoint) GetVelX() float32 {
+	if x != nil {
+		return x.VelX
+	}
+	return 0
+}
+
+func (x *ReplayCheckpoint) GetVelY() float32 {
+	if x != nil {
+		return x.VelY
+	}
+	return 0
+}
+
+type Replay struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	MatchId         string                 `protobuf:"bytes,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	RoomCode        string                 `protobuf:"bytes,2,opt,name=room_code,json=roomCode,proto3" json:"room_code,omitempty"`
+	Name1           string                 `protobuf:"bytes,3,opt,name=name1,proto3" json:"name1,omitempty"`
+	Name2           string                 `protobuf:"bytes,4,opt,name=name2,proto3" json:"name2,omitempty"`
+	StartedAt       int64                  `protobuf:"varint,5,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"`
+	Rules           *ReplayRules           `protobuf:"bytes,6,opt,name=rules,proto3" json:"rules,omitempty"`
+	Initial         *GameState             `protobuf:"bytes,7,opt,name=initial,proto3" json:"initial,omitempty"`
+	VelX            float32                `protobuf:"fixed32,8,opt,name=vel_x,json=velX,proto3" json:"vel_x,omitempty"`
+	VelY            float32                `protobuf:"fixed32,9,opt,name=vel_y,json=velY,proto3" json:"vel_y,omitempty"`
+	Ticks           []*ReplayTick          `protobuf:"bytes,10,rep,name=ticks,proto3" json:"ticks,omitempty"`
+	CheckpointEvery uint32                 `protobuf:"varint,11,opt,name=checkpoint_every,json=checkpointEvery,proto3" json:"checkpoint_every,omitempty"`
+	Checkpoints     []*ReplayCheckpoint    `protobuf:"bytes,12,rep,name=checkpoints,proto3" json:"checkpoints,omitempty"`
+	TotalTicks      uint32                 `protobuf:"varint,13,opt,name=total_ticks,json=totalTicks,proto3" json:"total_ticks,omitempty"`
+	Final           *GameState             `protobuf:"bytes,14,opt,name=final,proto3" json:"final,omitempty"`
+	EndReason       string                 `protobuf:"bytes,15,opt,name=end_reason,json=endReason,proto3" json:"end_reason,omitempty"`
+	// Las acciones son la dirección que mantiene el jugador desde ese tick.
+	// En las repeticiones antiguas (false) cada acción movía la pala una vez.
+	HeldInputs    bool `protobuf:"varint,16,opt,name=held_inputs,json=heldInputs,proto3" json:"held_inputs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Replay) Reset() {
+	*x = Replay{}
+	mi := &file_proto_replay_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Replay) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Replay) ProtoMessage() {}
+
+func (x *Replay) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_replay_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Replay.ProtoReflect.Descriptor instead.
+func (*Replay) Descriptor() ([]byte, []int) {
+	return file_proto_replay_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *Replay) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+func (x *Replay) GetRoomCode() string {
+	if x != nil {
+		return x.RoomCode
+	}
+	return ""
+}
+
+func (x *Replay) GetName1() string {
+	if x != nil {
+		return x.Name1
+	}
+	return ""
+}
+
+func (x *Replay) GetName2() string {
+	if x != nil {
+		return x.Name2
+	}
+	return ""
+}
+
+func (x *Replay) GetStartedAt() int64 {
+	if x != nil {
+		return x.StartedAt
+	}
+	return 0
+}
+
+func (x *Replay) GetRules() *ReplayRules {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+func (x *Replay) GetInitial() *GameState {
+	if x != nil {
+		return x.Initial
+	}
+	return nil
+}
+
+func (x *Replay) GetVelX() float32 {
+	if x != nil {
+		return x.VelX
+	}
+	return 0
+}
+
+func (x *Replay) GetVelY() float32 {
+	if x != nil {
+		return x.VelY
+	}
+	return 0
+}
+
+func (x *Replay) GetTicks() []*ReplayTick {
+	if x != nil {
+		return x.Ticks
+	}
+	return nil
+}
+
+func (x *Replay) GetCheckpointEvery() uint32 {
+	if x != nil {
+		return x.CheckpointEvery
+	}
+	return 0
+}
+
+func (x *Replay) GetCheckpoints() []*ReplayCheckpoint {
+	if x != nil {
+		return x.Checkpoints
+	}
+	return nil
+}
+
+func (x *Replay) GetTotalTicks() uint32 {
+	if x != nil {
+		return x.TotalTicks
+	}
+	return 0
+}
+
+func (x *Replay) GetFinal() *GameState {
+	if x != nil {
+		return x.Final
+	}
+	return nil
+}
+
+func (x *Replay) GetEndReason() string {
+	if x != nil {
+		return x.EndReason
+	}
+	return ""
+}
+
+func (x *Replay) GetHeldInputs() bool {
+	if x != nil {
+		return x.HeldInputs
+	}
+	return false
+}
+
+// Sin match_id se devuelve la última partida grabada con ese código de sala.
+type ReplayRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomCode      string                 `protobuf:"bytes,1,opt,name=room_code,json=roomCode,proto3" json:"room_code,omitempty"`
+	MatchId       string                 `protobuf:"bytes,2,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplayRequest) Reset() {
+	*x = ReplayRequest{}
+	mi := &file_proto_replay_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplayRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayRequest) ProtoMessage() {}
+
+func (x *ReplayRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_replay_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayRequest.ProtoReflect.Descriptor instead.
+func (*ReplayRequest) Descriptor() ([]byte, []int) {
+	return file_proto_replay_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ReplayRequest) GetRoomCode() string {
+	if x != nil {
+		return x.RoomCode
+	}
+	return ""
+}
+
+func (x *ReplayRequest) GetMatchId() string {
+	if x != nil {
+		return x.MatchId
+	}
+	return ""
+}
+
+// Trozo del fichero de repetición (protobuf comprimido con gzip).
+type ReplayChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplayChunk) Reset() {
+	*x = ReplayChunk{}
+	mi := &file_proto_replay_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplayChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplayChunk) ProtoMessage() {}
+
+func (x *ReplayChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_replay_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplayChunk.ProtoReflect.Descriptor instead.
+func (*ReplayChunk) Descriptor() ([]byte, []int) {
+	return file_proto_replay_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ReplayChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+var File_proto_replay_proto protoreflect.FileDescriptor
+
+const file_proto_replay_proto_rawDesc = "" +
+	"\n" +
+	"\x12proto/replay.proto\x12\bpingpong\x1a\x14proto/pingpong.proto\"\xa6\x02\n" +
+	"\vReplayRules\x12\x17\n" +
+	"\atick_ms\x18\x01 \x01(\x05R\x06tickMs\x12!\n" +
+	"\fpaddle_delta\x18\x02 \x01(\x02R\vpaddleDelta\x12\x1c\n" +
+	"\n" +
+	"ball_vel_x\x18\x03 \x01(\x02R\bballVelX\x12\x1c\n" +
+	"\n" +
+	"ball_vel_y\x18\x04 \x01(\x02R\bballVelY\x12\x19\n" +
+	"\bscreen_w\x18\x05 \x01(\x02R\ascreenW\x12\x19\n" +
+	"\bscreen_h\x18\x06 \x01(\x02R\ascreenH\x12\x19\n" +
+	"\bpaddle_w\x18\a \x01(\x02R\apaddleW\x12\x19\n" +
+	"\bpaddle_h\x18\b \x01(\x02R\apaddleH\x12\x1f\n" +
+	"\vball_radius\x18\t \x01(\x02R\n" +
+	"ballRadius\x12\x12\n" +
+	"\x04wall\x18\n" +
+	" \x01(\bR\x04wall\"7\n" +
+	"\vReplayInput\x12\x16\n" +
+	"\x06player\x18\x01 \x01(\x05R\x06player\x12\x10\n" +
+	"\x03dir\x18\x02 \x01(\x11R\x03dir\"O\n" +
+	"\n" +
+	"ReplayTick\x12\x12\n" +
+	"\x04tick\x18\x01 \x01(\rR\x04tick\x12-\n" +
+	"\x06inputs\x18\x02 \x03(\v2\x15.pingpong.ReplayInputR\x06inputs\"{\n" +
+	"\x10ReplayCheckpoint\x12\x12\n" +
+	"\x04tick\x18\x01 \x01(\rR\x04tick\x12)\n" +
+	"\x05state\x18\x02 \x01(\v2\x13.pingpong.GameStateR\x05state\x12\x13\n" +
+	"\x05vel_x\x18\x03 \x01(\x02R\x04velX\x12\x13\n" +
+	"\x05vel_y\x18\x04 \x01(\x02R\x04velY\"\xb2\x04\n" +
+	"\x06Replay\x12\x19\n" +
+	"\bmatch_id\x18\x01 \x01(\tR\amatchId\x12\x1b\n" +
+	"\troom_code\x18\x02 \x01(\tR\broomCode\x12\x14\n" +
+	"\x05name1\x18\x03 \x01(\tR\x05name1\x12\x14\n" +
+	"\x05name2\x18\x04 \x01(\tR\x05name2\x12\x1d\n" +
+	"\n" +
+	"started_at\x18\x05 \x01(\x03R\tstartedAt\x12+\n" +
+	"\x05rules\x18\x06 \x01(\v2\x15.pingpong.ReplayRulesR\x05rules\x12-\n" +
+	"\ainitial\x18\a \x01(\v2\x13.pingpong.GameStateR\ainitial\x12\x13\n" +
+	"\x05vel_x\x18\b \x01(\x02R\x04velX\x12\x13\n" +
+	"\x05vel_y\x18\t \x01(\x02R\x04velY\x12*\n" +
+	"\x05ticks\x18\n" +
+	" \x03(\v2\x14.pingpong.ReplayTickR\x05ticks\x12)\n" +
+	"\x10checkpoint_every\x18\v \x01(\rR\x0fcheckpointEvery\x12<\n" +
+	"\vcheckpoints\x18\f \x03(\v2\x1a.pingpong.ReplayCheckpointR\vcheckpoints\x12\x1f\n" +
+	"\vtotal_ticks\x18\r \x01(\rR\n" +
+	"totalTicks\x12)\n" +
+	"\x05final\x18\x0e \x01(\v2\x13.pingpong.GameStateR\x05final\x12\x1d\n" +
+	"\n" +
+	"end_reason\x18\x0f \x01(\tR\tendReason\x12\x1f\n" +
+	"\vheld_inputs\x18\x10 \x01(\bR\n" +
+	"heldInputs\"G\n" +
+	"\rReplayRequest\x12\x1b\n" +
+	"\troom_code\x18\x01 \x01(\tR\broomCode\x12\x19\n" +
+	"\bmatch_id\x18\x02 \x01(\tR\amatchId\"!\n" +
+	"\vReplayChunk\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data2M\n" +
+	"\aReplays\x12B\n" +
+	"\x0eDownloadReplay\x12\x17.pingpong.ReplayRequest\x1a\x15.pingpong.ReplayChunk0\x01B\x19Z\x17JuegoCeN/proto;pingpongb\x06proto3"
+
+var (
+	file_proto_replay_proto_rawDescOnce sync.Once
+	file_proto_replay_proto_rawDescData []byte
+)
+
+func file_proto_replay_proto_rawDescGZIP() []byte {
+	file_proto_replay_proto_rawDescOnce.Do(func() {
+		file_proto_replay_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_replay_proto_rawDesc), len(file_proto_replay_proto_rawDesc)))
+	})
+	return file_proto_replay_proto_rawDescData
+}
+
+var file_proto_replay_proto_msgTypes = make([]protoimpl.MessageInfo, 7)
+var file_proto_replay_proto_goTypes = []any{
+	(*ReplayRules)(nil),      // 0: pingpong.ReplayRules
+	(*ReplayInput)(nil),      // 1: pingpong.ReplayInput
+	(*ReplayTick)(nil),       // 2: pingpong.ReplayTick
+	(*ReplayCheckpoint)(nil), // 3: pingpong.ReplayCheckpoint
+	(*Replay)(nil),           // 4: pingpong.Replay
+	(*ReplayRequest)(nil),    // 5: pingpong.ReplayRequest
+	(*ReplayChunk)(nil),      // 6: pingpong.ReplayChunk
+	(*GameState)(nil),        // 7: pingpong.GameState
+}
+var file_proto_replay_proto_depIdxs = []int32{
+	1, // 0: pingpong.ReplayTick.inputs:type_name -> pingpong.ReplayInput
+	7, // 1: pingpong.ReplayCheckpoint.state:type_name -> pingpong.GameState
+	0, // 2: pingpong.Replay.rules:type_name -> pingpong.ReplayRules
+	7, // 3: pingpong.Replay.initial:type_name -> pingpong.GameState
+	2, // 4: pingpong.Replay.ticks:type_name -> pingpong.ReplayTick
+	3, // 5: pingpong.Replay.checkpoints:type_name -> pingpong.ReplayCheckpoint
+	7, // 6: pingpong.Replay.final:type_name -> pingpong.GameState
+	5, // 7: pingpong.Replays.DownloadReplay:input_type -> pingpong.ReplayRequest
+	6, // 8: pingpong.Replays.DownloadReplay:output_type -> pingpong.ReplayChunk
+	8, // [8:9] is the sub-list for method output_type
+	7, // [7:8] is the sub-list for method input_type
+	7, // [7:7] is the sub-list for extension type_name
+	7, // [7:7] is the sub-list for extension extendee
+	0, // [0:7] is the sub-list for field type_name
+}
+
+func init() { file_proto_replay_proto_init() }
+func file_proto_replay_proto_init() {
+	if File_proto_replay_proto != nil {
+		return
+	}
+	file_proto_pingpong_proto_init()
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_replay_proto_rawDesc), len(file_proto_replay_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   7,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_replay_proto_goTypes,
+		DependencyIndexes: file_proto_replay_proto_depIdxs,
+		MessageInfos:      file_proto_replay_proto_msgTypes,
+	}.Build()
+	File_proto_replay_proto = out.File
+	file_proto_replay_proto_goTypes = nil
+	file_proto_replay_proto_depIdxs = nil
+}
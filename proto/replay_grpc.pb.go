@@ -0,0 +1,124 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v6.30.2
+// source: proto/replay.proto
+
+package pingpong
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Replays_DownloadReplay_FullMethodName = "/pingpong.Replays/DownloadReplay"
+)
+
+// ReplaysClient is the client API for Replays service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ReplaysClient interface {
+	DownloadReplay(ctx context.Context, in *ReplayRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ReplayChunk], error)
+}
+
+type replaysClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewReplaysClient(cc grpc.ClientConnInterface) ReplaysClient {
+	return &replaysClient{cc}
+}
+
+func (c *replaysClient) DownloadReplay(ctx context.Context, in *ReplayRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[ReplayChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Replays_ServiceDesc.Streams[0], Replays_DownloadReplay_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ReplayRequest, ReplayChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Replays_DownloadReplayClient = grpc.ServerStreamingClient[ReplayChunk]
+
+// ReplaysServer is the server API for Replays service.
+// All implementations must embed UnimplementedReplaysServer
+// for forward compatibility.
+type ReplaysServer interface {
+	DownloadReplay(*ReplayRequest, grpc.ServerStreamingServer[ReplayChunk]) error
+	mustEmbedUnimplementedReplaysServer()
+}
+
+// UnimplementedReplaysServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedReplaysServer struct{}
+
+func (UnimplementedReplaysServer) DownloadReplay(*ReplayRequest, grpc.ServerStreamingServer[ReplayChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method DownloadReplay not implemented")
+}
+func (UnimplementedReplaysServer) mustEmbedUnimplementedReplaysServer() {}
+func (UnimplementedReplaysServer) testEmbeddedByValue()                 {}
+
+// UnsafeReplaysServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ReplaysServer will
+// result in compilation errors.
+type UnsafeReplaysServer interface {
+	mustEmbedUnimplementedReplaysServer()
+}
+
+func RegisterReplaysServer(s grpc.ServiceRegistrar, srv ReplaysServer) {
+	// If the following call pancis, it indicates UnimplementedReplaysServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Replays_ServiceDesc, srv)
+}
+
+func _Replays_DownloadReplay_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ReplayRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ReplaysServer).DownloadReplay(m, &grpc.GenericServerStream[ReplayRequest, ReplayChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Replays_DownloadReplayServer = grpc.ServerStreamingServer[ReplayChunk]
+
+// Replays_ServiceDesc is the grpc.ServiceDesc for Replays service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Replays_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "pingpong.Replays",
+	HandlerType: (*ReplaysServer)(nil),
+	Methods:     []grpc.MethodDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "DownloadReplay",
+			Handler:       _Replays_DownloadReplay_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "proto/replay.proto",
+}
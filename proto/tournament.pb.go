@@ -0,0 +1,961 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v6.30.2
+// source: proto/tournament.proto
+
+package pingpong
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type TournamentFormat int32
+
+const (
+	TournamentFormat_SINGLE_ELIMINATION TournamentFormat = 0
+	TournamentFormat_DOUBLE_ELIMINATION TournamentFormat = 1
+	TournamentFormat_ROUND_ROBIN        TournamentFormat = 2
+)
+
+// Enum value maps for TournamentFormat.
+var (
+	TournamentFormat_name = map[int32]string{
+		0: "SINGLE_ELIMINATION",
+		1: "DOUBLE_ELIMINATION",
+		2: "ROUND_ROBIN",
+	}
+	TournamentFormat_value = map[string]int32{
+		"SINGLE_ELIMINATION": 0,
+		"DOUBLE_ELIMINATION": 1,
+		"ROUND_ROBIN":        2,
+	}
+)
+
+func (x TournamentFormat) Enum() *TournamentFormat {
+	p := new(TournamentFormat)
+	*p = x
+	return p
+}
+
+func (x TournamentFormat) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TournamentFormat) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_tournament_proto_enumTypes[0].Descriptor()
+}
+
+func (TournamentFormat) Type() protoreflect.EnumType {
+	return &file_proto_tournament_proto_enumTypes[0]
+}
+
+func (x TournamentFormat) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TournamentFormat.Descriptor instead.
+func (TournamentFormat) EnumDescriptor() ([]byte, []int) {
+	return file_proto_tournament_proto_rawDescGZIP(), []int{0}
+}
+
+type TournamentStatus int32
+
+const (
+	TournamentStatus_TOURNAMENT_REGISTERING TournamentStatus = 0
+	TournamentStatus_TOURNAMENT_RUNNING     TournamentStatus = 1
+	TournamentStatus_TOURNAMENT_FINISHED    TournamentStatus = 2
+)
+
+// Enum value maps for TournamentStatus.
+var (
+	TournamentStatus_name = map[int32]string{
+		0: "TOURNAMENT_REGISTERING",
+		1: "TOURNAMENT_RUNNING",
+		2: "TOURNAMENT_FINISHED",
+	}
+	TournamentStatus_value = map[string]int32{
+		"TOURNAMENT_REGISTERING": 0,
+		"TOURNAMENT_RUNNING":     1,
+		"TOURNAMENT_FINISHED":    2,
+	}
+)
+
+func (x TournamentStatus) Enum() *TournamentStatus {
+	p := new(TournamentStatus)
+	*p = x
+	return p
+}
+
+func (x TournamentStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TournamentStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_tournament_proto_enumTypes[1].Descriptor()
+}
+
+func (TournamentStatus) Type() protoreflect.EnumType {
+	return &file_proto_tournament_proto_enumTypes[1]
+}
+
+func (x TournamentStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TournamentStatus.Descriptor instead.
+func (TournamentStatus) EnumDescriptor() ([]byte, []int) {
+	return file_proto_tournament_proto_rawDescGZIP(), []int{1}
+}
+
+// Parte del cuadro: WINNERS es el cuadro principal (o único), LOSERS el de
+// perdedores y FINAL la gran final de la doble eliminación; LEAGUE son las
+// jornadas de la liga.
+type BracketSide int32
+
+const (
+	BracketSide_WINNERS BracketSide = 0
+	BracketSide_LOSERS  BracketSide = 1
+	BracketSide_FINAL   BracketSide = 2
+	BracketSide_LEAGUE  BracketSide = 3
+)
+
+// Enum value maps for BracketSide.
+var (
+	BracketSide_name = map[int32]string{
+		0: "WINNERS",
+		1: "LOSERS",
+		2: "FINAL",
+		3: "LEAGUE",
+	}
+	BracketSide_value = map[string]int32{
+		"WINNERS": 0,
+		"LOSERS":  1,
+		"FINAL":   2,
+		"LEAGUE":  3,
+	}
+)
+
+func (x BracketSide) Enum() *BracketSide {
+	p := new(BracketSide)
+	*p = x
+	return p
+}
+
+func (x BracketSide) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BracketSide) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_tournament_proto_enumTypes[2].Descriptor()
+}
+
+func (BracketSide) Type() protoreflect.EnumType {
+	return &file_proto_tournament_proto_enumTypes[2]
+}
+
+func (x BracketSide) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BracketSide.Descriptor instead.
+func (BracketSide) EnumDescriptor() ([]byte, []int) {
+	return file_proto_tournament_proto_rawDescGZIP(), []int{2}
+}
+
+type BracketMatchStatus int32
+
+const (
+	BracketMatchStatus_MATCH_WAITING BracketMatchStatus = 0 // falta saber algún jugador
+	BracketMatchStatus_MATCH_READY   BracketMatchStatus = 1 // esperando a que ambos entren en la sala de espera
+	BracketMatchStatus_MATCH_PLAYING BracketMatchStatus = 2
+	BracketMatchStatus_MATCH_DONE    BracketMatchStatus = 3
+)
+
+// Enum value maps for BracketMatchStatus.
+var (
+	BracketMatchStatus_name = map[int32]string{
+		0: "MATCH_WAITING",
+		1: "MATCH_READY",
+		2: "MATCH_PLAYING",
+		3: "MATCH_DONE",
+	}
+	BracketMatchStatus_value = map[string]int32{
+		"MATCH_WAITING": 0,
+		"MATCH_READY":   1,
+		"MATCH_PLAYING": 2,
+		"MATCH_DONE":    3,
+	}
+)
+
+func (x BracketMatchStatus) Enum() *BracketMatchStatus {
+	p := new(BracketMatchStatus)
+	*p = x
+	return p
+}
+
+func (x BracketMatchStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (BracketMatchStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_proto_tournament_proto_enumTypes[3].Descriptor()
+}
+
+func (BracketMatchStatus) Type() protoreflect.EnumType {
+	return &file_proto_tournament_proto_enumTypes[3]
+}
+
+func (x BracketMatchStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use BracketMatchStatus.Descriptor instead.
+func (BracketMatchStatus) EnumDescriptor() ([]byte, []int) {
+	return file_proto_tournament_proto_rawDescGZIP(), []int{3}
+}
+
+type CreateTournamentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Format        TournamentFormat       `protobuf:"varint,2,opt,name=format,proto3,enum=pingpong.TournamentFormat" json:"format,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTournamentRequest) Reset() {
+	*x = CreateTournamentRequest{}
+	mi := &file_proto_tournament_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTournamentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTournamentRequest) ProtoMessage() {}
+
+func (x *CreateTournamentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tournament_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTournamentRequest.ProtoReflect.Descriptor instead.
+func (*CreateTournamentRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tournament_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *CreateTournamentRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateTournamentRequest) GetFormat() TournamentFormat {
+	if x != nil {
+		return x.Format
+	}
+	return TournamentFormat_SINGLE_ELIMINATION
+}
+
+type TournamentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TournamentId  string                 `protobuf:"bytes,1,opt,name=tournament_id,json=tournamentId,proto3" json:"tournament_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TournamentRequest) Reset() {
+	*x = TournamentRequest{}
+	mi := &file_proto_tournament_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TournamentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TournamentRequest) ProtoMessage() {}
+
+func (x *TournamentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tournament_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TournamentRequest.ProtoReflect.Descriptor instead.
+func (*TournamentRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tournament_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *TournamentRequest) GetTournamentId() string {
+	if x != nil {
+		return x.TournamentId
+	}
+	return ""
+}
+
+type ListTournamentsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTournamentsRequest) Reset() {
+	*x = ListTournamentsRequest{}
+	mi := &file_proto_tournament_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTournamentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTournamentsRequest) ProtoMessage() {}
+
+func (x *ListTournamentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tournament_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTournamentsRequest.ProtoReflect.Descriptor instead.
+func (*ListTournamentsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_tournament_proto_rawDescGZIP(), []int{2}
+}
+
+// seed empieza en 1; alive dice si aún le quedan partidos.
+type TournamentPlayer struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      string                 `protobuf:"bytes,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	DisplayName   string                 `protobuf:"bytes,2,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	Seed          int32                  `protobuf:"varint,3,opt,name=seed,proto3" json:"seed,omitempty"`
+	Alive         bool                   `protobuf:"varint,4,opt,name=alive,proto3" json:"alive,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TournamentPlayer) Reset() {
+	*x = TournamentPlayer{}
+	mi := &file_proto_tournament_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TournamentPlayer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TournamentPlayer) ProtoMessage() {}
+
+func (x *TournamentPlayer) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tournament_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TournamentPlayer.ProtoReflect.Descriptor instead.
+func (*TournamentPlayer) Descriptor() ([]byte, []int) {
+	return file_proto_tournament_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *TournamentPlayer) GetPlayerId() string {
+	if x != nil {
+		return x.PlayerId
+	}
+	return ""
+}
+
+func (x *TournamentPlayer) GetDisplayName() string {
+	if x != nil {
+		return x.DisplayName
+	}
+	return ""
+}
+
+func (x *TournamentPlayer) GetSeed() int32 {
+	if x != nil {
+		return x.Seed
+	}
+	return 0
+}
+
+func (x *TournamentPlayer) GetAlive() bool {
+	if x != nil {
+		return x.Alive
+	}
+	return false
+}
+
+// Los nombres van vacíos mientras no se sabe el jugador; bye indica que el
+// partido no se jugó porque faltaba un rival. winner es 1 o 2 (0 si aún no
+// hay ganador).
+type BracketMatch struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	MatchId  int32                  `protobuf:"varint,1,opt,name=match_id,json=matchId,proto3" json:"match_id,omitempty"`
+	Side     BracketSide            `protobuf:"varint,2,opt,name=side,proto3,enum=pingpong.BracketSide" json:"side,omitempty"`
+	Round    int32                  `protobuf:"varint,3,opt,name=round,proto3" json:"round,omitempty"`
+	Player1  string                 `protobuf:"bytes,4,opt,name=player1,proto3" json:"player1,omitempty"`
+	Player2  string                 `protobuf:"bytes,5,opt,name=player2,proto3" json:"player2,omitempty"`
+	Status   BracketMatchStatus     `protobuf:"varint,6,opt,name=status,proto3,enum=pingpong.BracketMatchStatus" json:"status,omitempty"`
+	Winner   int32                  `protobuf:"varint,7,opt,name=winner,proto3" json:"winner,omitempty"`
+	Score1   int32                  `protobuf:"varint,8,opt,name=Score1,proto3" json:"Score1,omitempty"`
+	Score2   int32                  `protobuf:"varint,9,opt,name=Score2,proto3" json:"Score2,omitempty"`
+	RoomCode string                 `protobuf:"bytes,10,opt,name=room_code,json=roomCode,proto3" json:"room_code,omitempty"`
+	Bye      bool                   `protobuf:"varint,11,opt,name=bye,proto3" json:"bye,omitempty"`
+	// Ganado sin jugar: el rival no se presentó a tiempo.
+	Walkover      bool `protobuf:"varint,12,opt,name=walkover,proto3" json:"walkover,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BracketMatch) Reset() {
+	*x = BracketMatch{}
+	mi := &file_proto_tournament_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BracketMatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BracketMatch) ProtoMessage() {}
+
+func (x *BracketMatch) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tournament_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BracketMatch.ProtoReflect.Descriptor instead.
+func (*BracketMatch) Descriptor() ([]byte, []int) {
+	return file_proto_tournament_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *BracketMatch) GetMatchId() int32 {
+	if x != nil {
+		return x.MatchId
+	}
+	return 0
+}
+
+func (x *BracketMatch) GetSide() BracketSide {
+	if x != nil {
+		return x.Side
+	}
+	return BracketSide_WINNERS
+}
+
+func (x *BracketMatch) GetRound() int32 {
+	if x != nil {
+		return x.Round
+	}
+	return 0
+}
+
+func (x *BracketMatch) GetPlayer1() string {
+	if x != nil {
+		return x.Player1
+	}
+	return ""
+}
+
+func (x *BracketMatch) GetPlayer2() string {
+	if x != nil {
+		return x.Player2
+	}
+	return ""
+}
+
+func (x *BracketMatch) GetStatus() BracketMatchStatus {
+	if x != nil {
+		return x.Status
+	}
+	return BracketMatchStatus_MATCH_WAITING
+}
+
+func (x *BracketMatch) GetWinner() int32 {
+	if x != nil {
+		return x.Winner
+	}
+	return 0
+}
+
+func (x *BracketMatch) GetScore1() int32 {
+	if x != nil {
+		return x.Score1
+	}
+	return 0
+}
+
+func (x *BracketMatch) GetScore2() int32 {
+	if x != nil {
+		return x.Score2
+	}
+	return 0
+}
+
+func (x *BracketMatch) GetRoomCode() string {
+	if x != nil {
+		return x.RoomCode
+	}
+	return ""
+}
+
+func (x *BracketMatch) GetBye() bool {
+	if x != nil {
+		return x.Bye
+	}
+	return false
+}
+
+func (x *BracketMatch) GetWalkover() bool {
+	if x != nil {
+		return x.Walkover
+	}
+	return false
+}
+
+type TournamentStanding struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DisplayName   string                 `protobuf:"bytes,1,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	Played        int32                  `protobuf:"varint,2,opt,name=played,proto3" json:"played,omitempty"`
+	Wins          int32                  `protobuf:"varint,3,opt,name=wins,proto3" json:"wins,omitempty"`
+	Losses        int32                  `protobuf:"varint,4,opt,name=losses,proto3" json:"losses,omitempty"`
+	PointsFor     int32                  `protobuf:"varint,5,opt,name=points_for,json=pointsFor,proto3" json:"points_for,omitempty"`
+	PointsAgainst int32                  `protobuf:"varint,6,opt,name=points_against,json=pointsAgainst,proto3" json:"points_against,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TournamentStanding) Reset() {
+	*x = TournamentStanding{}
+	mi := &file_proto_tournament_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TournamentStanding) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TournamentStanding) ProtoMessage() {}
+
+func (x *TournamentStanding) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tournament_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TournamentStanding.ProtoReflect.Descriptor instead.
+func (*TournamentStanding) Descriptor() ([]byte, []int) {
+	return file_proto_tournament_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *TournamentStanding) GetDisplayName() string {
+	if x != nil {
+		return x.DisplayName
+	}
+	return ""
+}
+
+func (x *TournamentStanding) GetPlayed() int32 {
+	if x != nil {
+		return x.Played
+	}
+	return 0
+}
+
+func (x *TournamentStanding) GetWins() int32 {
+	if x != nil {
+		return x.Wins
+	}
+	return 0
+}
+
+func (x *TournamentStanding) GetLosses() int32 {
+	if x != nil {
+		return x.Losses
+	}
+	return 0
+}
+
+func (x *TournamentStanding) GetPointsFor() int32 {
+	if x != nil {
+		return x.PointsFor
+	}
+	return 0
+}
+
+func (x *TournamentStanding) GetPointsAgainst() int32 {
+	if x != nil {
+		return x.PointsAgainst
+	}
+	return 0
+}
+
+// standings solo se rellena en liga.
+type TournamentBracket struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TournamentId  string                 `protobuf:"bytes,1,opt,name=tournament_id,json=tournamentId,proto3" json:"tournament_id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Format        TournamentFormat       `protobuf:"varint,3,opt,name=format,proto3,enum=pingpong.TournamentFormat" json:"format,omitempty"`
+	Status        TournamentStatus       `protobuf:"varint,4,opt,name=status,proto3,enum=pingpong.TournamentStatus" json:"status,omitempty"`
+	Creator       string                 `protobuf:"bytes,5,opt,name=creator,proto3" json:"creator,omitempty"`
+	Players       []*TournamentPlayer    `protobuf:"bytes,6,rep,name=players,proto3" json:"players,omitempty"`
+	Matches       []*BracketMatch        `protobuf:"bytes,7,rep,name=matches,proto3" json:"matches,omitempty"`
+	Standings     []*TournamentStanding  `protobuf:"bytes,8,rep,name=standings,proto3" json:"standings,omitempty"`
+	Champion      string                 `protobuf:"bytes,9,opt,name=champion,proto3" json:"champion,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TournamentBracket) Reset() {
+	*x = TournamentBracket{}
+	mi := &file_proto_tournament_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TournamentBracket) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TournamentBracket) ProtoMessage() {}
+
+func (x *TournamentBracket) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tournament_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TournamentBracket.ProtoReflect.Descriptor instead.
+func (*TournamentBracket) Descriptor() ([]byte, []int) {
+	return file_proto_tournament_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *TournamentBracket) GetTournamentId() string {
+	if x != nil {
+		return x.TournamentId
+	}
+	return ""
+}
+
+func (x *TournamentBracket) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TournamentBracket) GetFormat() TournamentFormat {
+	if x != nil {
+		return x.Format
+	}
+	return TournamentFormat_SINGLE_ELIMINATION
+}
+
+func (x *TournamentBracket) GetStatus() TournamentStatus {
+	if x != nil {
+		return x.Status
+	}
+	return TournamentStatus_TOURNAMENT_REGISTERING
+}
+
+func (x *TournamentBracket) GetCreator() string {
+	if x != nil {
+		return x.Creator
+	}
+	return ""
+}
+
+func (x *TournamentBracket) GetPlayers() []*TournamentPlayer {
+	if x != nil {
+		return x.Players
+	}
+	return nil
+}
+
+func (x *TournamentBracket) GetMatches() []*BracketMatch {
+	if x != nil {
+		return x.Matches
+	}
+	return nil
+}
+
+func (x *TournamentBracket) GetStandings() []*TournamentStanding {
+	if x != nil {
+		return x.Standings
+	}
+	return nil
+}
+
+func (x *TournamentBracket) GetChampion() string {
+	if x != nil {
+		return x.Champion
+	}
+	return ""
+}
+
+type ListTournamentsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tournaments   []*TournamentBracket   `protobuf:"bytes,1,rep,name=tournaments,proto3" json:"tournaments,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTournamentsResponse) Reset() {
+	*x = ListTournamentsResponse{}
+	mi := &file_proto_tournament_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTournamentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTournamentsResponse) ProtoMessage() {}
+
+func (x *ListTournamentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_tournament_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTournamentsResponse.ProtoReflect.Descriptor instead.
+func (*ListTournamentsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_tournament_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListTournamentsResponse) GetTournaments() []*TournamentBracket {
+	if x != nil {
+		return x.Tournaments
+	}
+	return nil
+}
+
+var File_proto_tournament_proto protoreflect.FileDescriptor
+
+const file_proto_tournament_proto_rawDesc = "" +
+	"\n" +
+	"\x16proto/tournament.proto\x12\bpingpong\"a\n" +
+	"\x17CreateTournamentRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x122\n" +
+	"\x06format\x18\x02 \x01(\x0e2\x1a.pingpong.TournamentFormatR\x06format\"8\n" +
+	"\x11TournamentRequest\x12#\n" +
+	"\rtournament_id\x18\x01 \x01(\tR\ftournamentId\"\x18\n" +
+	"\x16ListTournamentsRequest\"|\n" +
+	"\x10TournamentPlayer\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\tR\bplayerId\x12!\n" +
+	"\fdisplay_name\x18\x02 \x01(\tR\vdisplayName\x12\x12\n" +
+	"\x04seed\x18\x03 \x01(\x05R\x04seed\x12\x14\n" +
+	"\x05alive\x18\x04 \x01(\bR\x05alive\"\xe7\x02\n" +
+	"\fBracketMatch\x12\x19\n" +
+	"\bmatch_id\x18\x01 \x01(\x05R\amatchId\x12)\n" +
+	"\x04side\x18\x02 \x01(\x0e2\x15.pingpong.BracketSideR\x04side\x12\x14\n" +
+	"\x05round\x18\x03 \x01(\x05R\x05round\x12\x18\n" +
+	"\aplayer1\x18\x04 \x01(\tR\aplayer1\x12\x18\n" +
+	"\aplayer2\x18\x05 \x01(\tR\aplayer2\x124\n" +
+	"\x06status\x18\x06 \x01(\x0e2\x1c.pingpong.BracketMatchStatusR\x06status\x12\x16\n" +
+	"\x06winner\x18\a \x01(\x05R\x06winner\x12\x16\n" +
+	"\x06Score1\x18\b \x01(\x05R\x06Score1\x12\x16\n" +
+	"\x06Score2\x18\t \x01(\x05R\x06Score2\x12\x1b\n" +
+	"\troom_code\x18\n" +
+	" \x01(\tR\broomCode\x12\x10\n" +
+	"\x03bye\x18\v \x01(\bR\x03bye\x12\x1a\n" +
+	"\bwalkover\x18\f \x01(\bR\bwalkover\"\xc1\x01\n" +
+	"\x12TournamentStanding\x12!\n" +
+	"\fdisplay_name\x18\x01 \x01(\tR\vdisplayName\x12\x16\n" +
+	"\x06played\x18\x02 \x01(\x05R\x06played\x12\x12\n" +
+	"\x04wins\x18\x03 \x01(\x05R\x04wins\x12\x16\n" +
+	"\x06losses\x18\x04 \x01(\x05R\x06losses\x12\x1d\n" +
+	"\n" +
+	"points_for\x18\x05 \x01(\x05R\tpointsFor\x12%\n" +
+	"\x0epoints_against\x18\x06 \x01(\x05R\rpointsAgainst\"\x8e\x03\n" +
+	"\x11TournamentBracket\x12#\n" +
+	"\rtournament_id\x18\x01 \x01(\tR\ftournamentId\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x122\n" +
+	"\x06format\x18\x03 \x01(\x0e2\x1a.pingpong.TournamentFormatR\x06format\x122\n" +
+	"\x06status\x18\x04 \x01(\x0e2\x1a.pingpong.TournamentStatusR\x06status\x12\x18\n" +
+	"\acreator\x18\x05 \x01(\tR\acreator\x124\n" +
+	"\aplayers\x18\x06 \x03(\v2\x1a.pingpong.TournamentPlayerR\aplayers\x120\n" +
+	"\amatches\x18\a \x03(\v2\x16.pingpong.BracketMatchR\amatches\x12:\n" +
+	"\tstandings\x18\b \x03(\v2\x1c.pingpong.TournamentStandingR\tstandings\x12\x1a\n" +
+	"\bchampion\x18\t \x01(\tR\bchampion\"X\n" +
+	"\x17ListTournamentsResponse\x12=\n" +
+	"\vtournaments\x18\x01 \x03(\v2\x1b.pingpong.TournamentBracketR\vtournaments*S\n" +
+	"\x10TournamentFormat\x12\x16\n" +
+	"\x12SINGLE_ELIMINATION\x10\x00\x12\x16\n" +
+	"\x12DOUBLE_ELIMINATION\x10\x01\x12\x0f\n" +
+	"\vROUND_ROBIN\x10\x02*_\n" +
+	"\x10TournamentStatus\x12\x1a\n" +
+	"\x16TOURNAMENT_REGISTERING\x10\x00\x12\x16\n" +
+	"\x12TOURNAMENT_RUNNING\x10\x01\x12\x17\n" +
+	"\x13TOURNAMENT_FINISHED\x10\x02*=\n" +
+	"\vBracketSide\x12\v\n" +
+	"\aWINNERS\x10\x00\x12\n" +
+	"\n" +
+	"\x06LOSERS\x10\x01\x12\t\n" +
+	"\x05FINAL\x10\x02\x12\n" +
+	"\n" +
+	"\x06LEAGUE\x10\x03*[\n" +
+	"\x12BracketMatchStatus\x12\x11\n" +
+	"\rMATCH_WAITING\x10\x00\x12\x0f\n" +
+	"\vMATCH_READY\x10\x01\x12\x11\n" +
+	"\rMATCH_PLAYING\x10\x02\x12\x0e\n" +
+	"\n" +
+	"MATCH_DONE\x10\x032\x99\x03\n" +
+	"\n" +
+	"Tournament\x12R\n" +
+	"\x10CreateTournament\x12!.pingpong.CreateTournamentRequest\x1a\x1b.pingpong.TournamentBracket\x12J\n" +
+	"\x0eJoinTournament\x12\x1b.pingpong.TournamentRequest\x1a\x1b.pingpong.TournamentBracket\x12K\n" +
+	"\x0fStartTournament\x12\x1b.pingpong.TournamentRequest\x1a\x1b.pingpong.TournamentBracket\x12F\n" +
+	"\n" +
+	"GetBracket\x12\x1b.pingpong.TournamentRequest\x1a\x1b.pingpong.TournamentBracket\x12V\n" +
+	"\x0fListTournaments\x12 .pingpong.ListTournamentsRequest\x1a!.pingpong.ListTournamentsResponseB\x19Z\x17JuegoCeN/proto;pingpongb\x06proto3"
+
+var (
+	file_proto_tournament_proto_rawDescOnce sync.Once
+	file_proto_tournament_proto_rawDescData []byte
+)
+
+func file_proto_tournament_proto_rawDescGZIP() []byte {
+	file_proto_tournament_proto_rawDescOnce.Do(func() {
+		file_proto_tournament_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_tournament_proto_rawDesc), len(file_proto_tournament_proto_rawDesc)))
+	})
+	return file_proto_tournament_proto_rawDescData
+}
+
+var file_proto_tournament_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_proto_tournament_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_proto_tournament_proto_goTypes = []any{
+	(TournamentFormat)(0),           // 0: pingpong.TournamentFormat
+	(TournamentStatus)(0),           // 1: pingpong.TournamentStatus
+	(BracketSide)(0),                // 2: pingpong.BracketSide
+	(BracketMatchStatus)(0),         // 3: pingpong.BracketMatchStatus
+	(*CreateTournamentRequest)(nil), // 4: pingpong.CreateTournamentRequest
+	(*TournamentRequest)(nil),       // 5: pingpong.TournamentRequest
+	(*ListTournamentsRequest)(nil),  // 6: pingpong.ListTournamentsRequest
+	(*TournamentPlayer)(nil),        // 7: pingpong.TournamentPlayer
+	(*BracketMatch)(nil),            // 8: pingpong.BracketMatch
+	(*TournamentStanding)(nil),      // 9: pingpong.TournamentStanding
+	(*TournamentBracket)(nil),       // 10: pingpong.TournamentBracket
+	(*ListTournamentsResponse)(nil), // 11: pingpong.ListTournamentsResponse
+}
+var file_proto_tournament_proto_depIdxs = []int32{
+	0,  // 0: pingpong.CreateTournamentRequest.format:type_name -> pingpong.TournamentFormat
+	2,  // 1: pingpong.BracketMatch.side:type_name -> pingpong.BracketSide
+	3,  // 2: pingpong.BracketMatch.status:type_name -> pingpong.BracketMatchStatus
+	0,  // 3: pingpong.TournamentBracket.format:type_name -> pingpong.TournamentFormat
+	1,  // 4: pingpong.TournamentBracket.status:type_name -> pingpong.TournamentStatus
+	7,  // 5: pingpong.TournamentBracket.players:type_name -> pingpong.TournamentPlayer
+	8,  // 6: pingpong.TournamentBracket.matches:type_name -> pingpong.BracketMatch
+	9,  // 7: pingpong.TournamentBracket.standings:type_name -> pingpong.TournamentStanding
+	10, // 8: pingpong.ListTournamentsResponse.tournaments:type_name -> pingpong.TournamentBracket
+	4,  // 9: pingpong.Tournament.CreateTournament:input_type -> pingpong.CreateTournamentRequest
+	5,  // 10: pingpong.Tournament.JoinTournament:input_type -> pingpong.TournamentRequest
+	5,  // 11: pingpong.Tournament.StartTournament:input_type -> pingpong.TournamentRequest
+	5,  // 12: pingpong.Tournament.GetBracket:input_type -> pingpong.TournamentRequest
+	6,  // 13: pingpong.Tournament.ListTournaments:input_type -> pingpong.ListTournamentsRequest
+	10, // 14: pingpong.Tournament.CreateTournament:output_type -> pingpong.TournamentBracket
+	10, // 15: pingpong.Tournament.JoinTournament:output_type -> pingpong.TournamentBracket
+	10, // 16: pingpong.Tournament.StartTournament:output_type -> pingpong.TournamentBracket
+	10, // 17: pingpong.Tournament.GetBracket:output_type -> pingpong.TournamentBracket
+	11, // 18: pingpong.Tournament.ListTournaments:output_type -> pingpong.ListTournamentsResponse
+	14, // [14:19] is the sub-list for method output_type
+	9,  // [9:14] is the sub-list for method input_type
+	9,  // [9:9] is the sub-list for extension type_name
+	9,  // [9:9] is the sub-list for extension extendee
+	0,  // [0:9] is the sub-list for field type_name
+}
+
+func init() { file_proto_tournament_proto_init() }
+func file_proto_tournament_proto_init() {
+	if File_proto_tournament_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_tournament_proto_rawDesc), len(file_proto_tournament_proto_rawDesc)),
+			NumEnums:      4,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_proto_tournament_proto_goTypes,
+		DependencyIndexes: file_proto_tournament_proto_depIdxs,
+		EnumInfos:         file_proto_tournament_proto_enumTypes,
+		MessageInfos:      file_proto_tournament_proto_msgTypes,
+	}.Build()
+	File_proto_tournament_proto = out.File
+	file_proto_tournament_proto_goTypes = nil
+	file_proto_tournament_proto_depIdxs = nil
+}
@@ -0,0 +1,283 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v6.30.2
+// source: proto/tournament.proto
+
+package pingpong
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Tournament_CreateTournament_FullMethodName = "/pingpong.Tournament/CreateTournament"
+	Tournament_JoinTournament_FullMethodName   = "/pingpong.Tournament/JoinTournament"
+	Tournament_StartTournament_FullMethodName  = "/pingpong.Tournament/StartTournament"
+	Tournament_GetBracket_FullMethodName       = "/pingpong.Tournament/GetBracket"
+	Tournament_ListTournaments_FullMethodName  = "/pingpong.Tournament/ListTournaments"
+)
+
+// TournamentClient is the client API for Tournament service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Create, Join y Start necesitan el token de jugador en la cabecera
+// "authorization"; solo quien crea el torneo puede empezarlo. Para jugar,
+// la primera acción de Play lleva el tournament_id y el servidor crea la
+// sala de cada partido cuando ambos jugadores están esperando.
+type TournamentClient interface {
+	CreateTournament(ctx context.Context, in *CreateTournamentRequest, opts ...grpc.CallOption) (*TournamentBracket, error)
+	JoinTournament(ctx context.Context, in *TournamentRequest, opts ...grpc.CallOption) (*TournamentBracket, error)
+	StartTournament(ctx context.Context, in *TournamentRequest, opts ...grpc.CallOption) (*TournamentBracket, error)
+	GetBracket(ctx context.Context, in *TournamentRequest, opts ...grpc.CallOption) (*TournamentBracket, error)
+	ListTournaments(ctx context.Context, in *ListTournamentsRequest, opts ...grpc.CallOption) (*ListTournamentsResponse, error)
+}
+
+type tournamentClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewTournamentClient(cc grpc.ClientConnInterface) TournamentClient {
+	return &tournamentClient{cc}
+}
+
+func (c *tournamentClient) CreateTournament(ctx context.Context, in *CreateTournamentRequest, opts ...grpc.CallOption) (*TournamentBracket, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TournamentBracket)
+	err := c.cc.Invoke(ctx, Tournament_CreateTournament_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tournamentClient) JoinTournament(ctx context.Context, in *TournamentRequest, opts ...grpc.CallOption) (*TournamentBracket, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TournamentBracket)
+	err := c.cc.Invoke(ctx, Tournament_JoinTournament_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tournamentClient) StartTournament(ctx context.Context, in *TournamentRequest, opts ...grpc.CallOption) (*TournamentBracket, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TournamentBracket)
+	err := c.cc.Invoke(ctx, Tournament_StartTournament_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tournamentClient) GetBracket(ctx context.Context, in *TournamentRequest, opts ...grpc.CallOption) (*TournamentBracket, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TournamentBracket)
+	err := c.cc.Invoke(ctx, Tournament_GetBracket_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tournamentClient) ListTournaments(ctx context.Context, in *ListTournamentsRequest, opts ...grpc.CallOption) (*ListTournamentsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTournamentsResponse)
+	err := c.cc.Invoke(ctx, Tournament_ListTournaments_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TournamentServer is the server API for Tournament service.
+// All implementations must embed UnimplementedTournamentServer
+// for forward compatibility.
+//
+// Create, Join y Start necesitan el token de jugador en la cabecera
+// "authorization"; solo quien crea el torneo puede empezarlo. Para jugar,
+// la primera acción de Play lleva el tournament_id y el servidor crea la
+// sala de cada partido cuando ambos jugadores están esperando.
+type TournamentServer interface {
+	CreateTournament(context.Context, *CreateTournamentRequest) (*TournamentBracket, error)
+	JoinTournament(context.Context, *TournamentRequest) (*TournamentBracket, error)
+	StartTournament(context.Context, *TournamentRequest) (*TournamentBracket, error)
+	GetBracket(context.Context, *TournamentRequest) (*TournamentBracket, error)
+	ListTournaments(context.Context, *ListTournamentsRequest) (*ListTournamentsResponse, error)
+	mustEmbedUnimplementedTournamentServer()
+}
+
+// UnimplementedTournamentServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedTournamentServer struct{}
+
+func (UnimplementedTournamentServer) CreateTournament(context.Context, *CreateTournamentRequest) (*TournamentBracket, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateTournament not implemented")
+}
+func (UnimplementedTournamentServer) JoinTournament(context.Context, *TournamentRequest) (*TournamentBracket, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method JoinTournament not implemented")
+}
+func (UnimplementedTournamentServer) StartTournament(context.Context, *TournamentRequest) (*TournamentBracket, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartTournament not implemented")
+}
+func (UnimplementedTournamentServer) GetBracket(context.Context, *TournamentRequest) (*TournamentBracket, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBracket not implemented")
+}
+func (UnimplementedTournamentServer) ListTournaments(context.Context, *ListTournamentsRequest) (*ListTournamentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListTournaments not implemented")
+}
+func (UnimplementedTournamentServer) mustEmbedUnimplementedTournamentServer() {}
+func (UnimplementedTournamentServer) testEmbeddedByValue()                    {}
+
+// UnsafeTournamentServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TournamentServer will
+// result in compilation errors.
+type UnsafeTournamentServer interface {
+	mustEmbedUnimplementedTournamentServer()
+}
+
+func RegisterTournamentServer(s grpc.ServiceRegistrar, srv TournamentServer) {
+	// If the following call pancis, it indicates UnimplementedTournamentServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Tournament_ServiceDesc, srv)
+}
+
+func _Tournament_CreateTournament_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateTournamentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TournamentServer).CreateTournament(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Tournament_CreateTournament_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TournamentServer).CreateTournament(ctx, req.(*CreateTournamentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tournament_JoinTournament_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TournamentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TournamentServer).JoinTournament(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Tournament_JoinTournament_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TournamentServer).JoinTournament(ctx, req.(*TournamentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tournament_StartTournament_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TournamentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TournamentServer).StartTournament(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Tournament_StartTournament_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TournamentServer).StartTournament(ctx, req.(*TournamentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tournament_GetBracket_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TournamentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TournamentServer).GetBracket(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Tournament_GetBracket_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TournamentServer).GetBracket(ctx, req.(*TournamentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Tournament_ListTournaments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTournamentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TournamentServer).ListTournaments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Tournament_ListTournaments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TournamentServer).ListTournaments(ctx, req.(*ListTournamentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Tournament_ServiceDesc is the grpc.ServiceDesc for Tournament service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Tournament_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "pingpong.Tournament",
+	HandlerType: (*TournamentServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateTournament",
+			Handler:    _Tournament_CreateTournament_Handler,
+		},
+		{
+			MethodName: "JoinTournament",
+			Handler:    _Tournament_JoinTournament_Handler,
+		},
+		{
+			MethodName: "StartTournament",
+			Handler:    _Tournament_StartTournament_Handler,
+		},
+		{
+			MethodName: "GetBracket",
+			Handler:    _Tournament_GetBracket_Handler,
+		},
+		{
+			MethodName: "ListTournaments",
+			Handler:    _Tournament_ListTournaments_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "proto/tournament.proto",
+}
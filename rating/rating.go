@@ -0,0 +1,66 @@
+// Package rating implementa el sistema Elo con el que se puntúa a los
+// jugadores y se buscan rivales de nivel parecido.
+package rating
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	// Initial es la puntuación de un jugador sin partidas.
+	Initial = 1500.0
+
+	// Durante las primeras ProvisionalGames partidas la puntuación se mueve
+	// más deprisa para colocar antes al jugador en su nivel.
+	ProvisionalGames = 10
+	kProvisional     = 40.0
+	kEstablished     = 20.0
+
+	// Ventana de emparejamiento: diferencia máxima de puntuación aceptada,
+	// que crece sin límite con el tiempo de espera, de modo que acaba
+	// cubriendo a cualquiera.
+	windowBase   = 100.0
+	windowGrowth = 50.0 // puntos por segundo de espera
+)
+
+// Expected devuelve la probabilidad de que un jugador con puntuación a gane
+// a uno con puntuación b.
+func Expected(a, b float64) float64 {
+	return 1 / (1 + math.Pow(10, (b-a)/400))
+}
+
+// K devuelve el factor de ajuste según las partidas jugadas.
+func K(played int) float64 {
+	if played < ProvisionalGames {
+		return kProvisional
+	}
+	return kEstablished
+}
+
+// Result es el resultado de una partida para el primer jugador: 1 gana,
+// 0.5 empate, 0 pierde.
+func Result(score1, score2 int32) float64 {
+	switch {
+	case score1 > score2:
+		return 1
+	case score1 < score2:
+		return 0
+	}
+	return 0.5
+}
+
+// Update devuelve las nuevas puntuaciones de a y b tras una partida cuyo
+// resultado para a es result. playedA y playedB son las partidas que cada
+// uno había jugado antes de esta.
+func Update(a, b float64, playedA, playedB int, result float64) (float64, float64) {
+	ea := Expected(a, b)
+	newA := a + K(playedA)*(result-ea)
+	newB := b + K(playedB)*((1-result)-(1-ea))
+	return newA, newB
+}
+
+// Window devuelve la diferencia de puntuación aceptable tras esperar wait.
+func Window(wait time.Duration) float64 {
+	return windowBase + windowGrowth*wait.Seconds()
+}
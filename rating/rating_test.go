@@ -0,0 +1,66 @@
+package rating
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func near(a, b float64) bool { return math.Abs(a-b) < 1e-9 }
+
+func TestExpected(t *testing.T) {
+	if e := Expected(1500, 1500); !near(e, 0.5) {
+		t.Fatalf("mismo nivel: %v", e)
+	}
+	// 400 puntos de diferencia son 10 a 1
+	if e := Expected(1900, 1500); !near(e, 10.0/11) {
+		t.Fatalf("400 puntos: %v", e)
+	}
+	if e := Expected(1300, 1700) + Expected(1700, 1300); !near(e, 1) {
+		t.Fatalf("las probabilidades no suman 1: %v", e)
+	}
+}
+
+func TestUpdate(t *testing.T) {
+	a, b := Update(1500, 1500, 0, 0, 1)
+	if !near(a, 1520) || !near(b, 1480) {
+		t.Fatalf("novatos, gana a: %v %v", a, b)
+	}
+
+	// Un veterano que gana a un novato apenas sube
+	a, b = Update(1800, 1400, 50, 0, 1)
+	if a-1800 > 2 || 1400-b > 4 || a <= 1800 {
+		t.Fatalf("favorito gana: %v %v", a, b)
+	}
+
+	// Empate entre iguales no cambia nada
+	a, b = Update(1600, 1600, 20, 20, 0.5)
+	if !near(a, 1600) || !near(b, 1600) {
+		t.Fatalf("empate: %v %v", a, b)
+	}
+
+	// Con el mismo K la suma se conserva
+	a, b = Update(1700, 1450, 30, 30, 0)
+	if !near(a+b, 1700+1450) {
+		t.Fatalf("suma no conservada: %v", a+b)
+	}
+}
+
+func TestResult(t *testing.T) {
+	if Result(5, 3) != 1 || Result(3, 5) != 0 || Result(2, 2) != 0.5 {
+		t.Fatal("Result mal calculado")
+	}
+}
+
+func TestWindowWidens(t *testing.T) {
+	if Window(0) != windowBase {
+		t.Fatalf("ventana inicial %v", Window(0))
+	}
+	if Window(4*time.Second) <= Window(time.Second) {
+		t.Fatal("la ventana debería crecer con la espera")
+	}
+	// Sin tope: con la espera suficiente entra cualquier diferencia
+	if Window(time.Minute) != windowBase+60*windowGrowth || Window(time.Hour) < 100000 {
+		t.Fatalf("ventana tras un minuto %v, tras una hora %v", Window(time.Minute), Window(time.Hour))
+	}
+}
@@ -0,0 +1,294 @@
+// Package replay graba partidas en ficheros de repetición y las reproduce.
+//
+// Un fichero es un pb.Replay serializado y comprimido con gzip. Contiene el
+// estado inicial, las reglas, las acciones de cada tick y un checkpoint del
+// estado cada CheckpointEvery ticks; cualquier tick se reconstruye partiendo
+// del checkpoint anterior y volviendo a simular con sim.
+package replay
+
+import (
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"time"
+
+	pb "JuegoCeN/proto"
+	"JuegoCeN/sim"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// CheckpointEvery es cada cuántos ticks se guarda el estado completo (~1 s).
+const CheckpointEvery = 60
+
+// Ext es la extensión de los ficheros de repetición.
+const Ext = ".replay"
+
+// RulesToProto convierte las reglas de la simulación al mensaje del fichero.
+func RulesToProto(r sim.Rules) *pb.ReplayRules {
+	return &pb.ReplayRules{
+		TickMs:      r.TickMs,
+		PaddleDelta: r.PaddleDelta,
+		BallVelX:    r.BallVelX,
+		BallVelY:    r.BallVelY,
+		ScreenW:     r.ScreenW,
+		ScreenH:     r.ScreenH,
+		PaddleW:     r.PaddleW,
+		PaddleH:     r.PaddleH,
+		BallRadius:  r.BallRadius,
+		Wall:        r.Wall,
+	}
+}
+
+// RulesFromProto es la inversa de RulesToProto.
+func RulesFromProto(r *pb.ReplayRules) sim.Rules {
+	return sim.Rules{
+		TickMs:      r.GetTickMs(),
+		PaddleDelta: r.GetPaddleDelta(),
+		BallVelX:    r.GetBallVelX(),
+		BallVelY:    r.GetBallVelY(),
+		ScreenW:     r.GetScreenW(),
+		ScreenH:     r.GetScreenH(),
+		PaddleW:     r.GetPaddleW(),
+		PaddleH:     r.GetPaddleH(),
+		BallRadius:  r.GetBallRadius(),
+		Wall:        r.GetWall(),
+	}
+}
+
+// physical copia solo lo que interviene en la simulación, potenciadores y
+// entradas de los jugadores incluidos, y el modo, con cuyas medidas se
+// dibuja la repetición.
+func physical(st *pb.GameState) *pb.GameState {
+	return proto.Clone(&pb.GameState{
+		Ball:       st.Ball,
+		Paddle1:    st.Paddle1,
+		Paddle2:    st.Paddle2,
+		Score1:     st.Score1,
+		Score2:     st.Score2,
+		LastHit:    st.LastHit,
+		PowerUps:   st.PowerUps,
+		Seed:       st.Seed,
+		NextItemIn: st.NextItemIn,
+		Items:      st.Items,
+		Effects:    st.Effects,
+		Balls:      st.Balls,
+		Map:        st.Map,
+		Mode:       st.Mode,
+		Inputs:     st.Inputs,
+	}).(*pb.GameState)
+}
+
+// Recorder va construyendo la repetición de una sala. No es seguro para uso
+// concurrente: la sala lo usa con su propio mutex.
+type Recorder struct {
+	rep     *pb.Replay
+	tick    uint32
+	pending []*pb.ReplayInput
+}
+
+// NewRecorder empieza una grabación con el estado y la velocidad de saque.
+func NewRecorder(matchID, roomCode, name1, name2 string, startedAt time.Time,
+	rules sim.Rules, initial *pb.GameState, vel sim.Velocity) *Recorder {
+	return &Recorder{rep: &pb.Replay{
+		MatchId:         matchID,
+		RoomCode:        roomCode,
+		Name1:           name1,
+		Name2:           name2,
+		StartedAt:       startedAt.Unix(),
+		Rules:           RulesToProto(rules),
+		Initial:         physical(initial),
+		VelX:            vel.X,
+		VelY:            vel.Y,
+		CheckpointEvery: CheckpointEvery,
+		HeldInputs:      true,
+	}}
+}
+
+// Input anota un cambio de dirección (sim.SetInput) aplicado antes del
+// próximo tick.
+func (r *Recorder) Input(player, dir int32) {
+	r.pending = append(r.pending, &pb.ReplayInput{Player: player, Dir: dir})
+}
+
+// EndTick cierra un tick ya simulado, cuyo resultado es st con velocidad vel.
+func (r *Recorder) EndTick(st *pb.GameState, vel sim.Velocity) {
+	r.tick++
+	if len(r.pending) > 0 {
+		r.rep.Ticks = append(r.rep.Ticks, &pb.ReplayTick{Tick: r.tick, Inputs: r.pending})
+		r.pending = nil
+	}
+	if r.tick%CheckpointEvery == 0 {
+		r.rep.Checkpoints = append(r.rep.Checkpoints, &pb.ReplayCheckpoint{
+			Tick:  r.tick,
+			State: physical(st),
+			VelX:  vel.X,
+			VelY:  vel.Y,
+		})
+	}
+}
+
+// Finish termina la grabación y devuelve la repetición completa.
+func (r *Recorder) Finish(final *pb.GameState, reason string) *pb.Replay {
+	r.rep.TotalTicks = r.tick
+	r.rep.Final = physical(final)
+	r.rep.EndReason = reason
+	return r.rep
+}
+
+// Encode escribe la repetición comprimida.
+func Encode(w io.Writer, rep *pb.Replay) error {
+	data, err := proto.Marshal(rep)
+	if err != nil {
+		return err
+	}
+	zw := gzip.NewWriter(w)
+	if _, err := zw.Write(data); err != nil {
+		return err
+	}
+	return zw.Close()
+}
+
+// Decode lee una repetición escrita con Encode.
+func Decode(r io.Reader) (*pb.Replay, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("replay: %w", err)
+	}
+	defer zr.Close()
+	data, err := io.ReadAll(zr)
+	if err != nil {
+		return nil, fmt.Errorf("replay: %w", err)
+	}
+	rep := &pb.Replay{}
+	if err := proto.Unmarshal(data, rep); err != nil {
+		return nil, fmt.Errorf("replay: %w", err)
+	}
+	if rep.Initial == nil || rep.Rules == nil {
+		return nil, fmt.Errorf("replay: fichero incompleto")
+	}
+	return rep, nil
+}
+
+// WriteFile guarda la repetición en path.
+func WriteFile(path string, rep *pb.Replay) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := Encode(f, rep); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// ReadFile carga una repetición de disco.
+func ReadFile(path string) (*pb.Replay, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Decode(f)
+}
+
+// Player reconstruye el estado de cualquier tick de una repetición.
+type Player struct {
+	rep   *pb.Replay
+	rules sim.Rules
+}
+
+// NewPlayer prepara la reproducción de rep.
+func NewPlayer(rep *pb.Replay) *Player {
+	return &Player{rep: rep, rules: RulesFromProto(rep.Rules)}
+}
+
+// Replay devuelve la repetición que se reproduce.
+func (p *Player) Replay() *pb.Replay { return p.rep }
+
+// TotalTicks es el número de ticks simulados en la partida.
+func (p *Player) TotalTicks() uint32 { return p.rep.TotalTicks }
+
+// TickDuration es el tiempo real que duraba un tick.
+func (p *Player) TickDuration() time.Duration {
+	return time.Duration(p.rules.TickMs) * time.Millisecond
+}
+
+// StateAt devuelve el estado tras simular tick (0 es el saque). Parte del
+// último checkpoint anterior y vuelve a aplicar las acciones grabadas.
+func (p *Player) StateAt(tick uint32) *pb.GameState {
+	if tick > p.rep.TotalTicks {
+		tick = p.rep.TotalTicks
+	}
+
+	st := physical(p.rep.Initial)
+	vel := sim.Velocity{X: p.rep.VelX, Y: p.rep.VelY}
+	from := uint32(0)
+	cps := p.rep.Checkpoints
+	if i := sort.Search(len(cps), func(i int) bool { return cps[i].Tick > tick }); i > 0 {
+		cp := cps[i-1]
+		st = physical(cp.State)
+		vel = sim.Velocity{X: cp.VelX, Y: cp.VelY}
+		from = cp.Tick
+	}
+
+	ticks := p.rep.Ticks
+	next := sort.Search(len(ticks), func(i int) bool { return ticks[i].Tick > from })
+	for t := from + 1; t <= tick; t++ {
+		if next < len(ticks) && ticks[next].Tick == t {
+			p.apply(st, ticks[next].Inputs)
+			next++
+		}
+		sim.Step(st, &vel, p.rules)
+	}
+	return st
+}
+
+// apply aplica las acciones grabadas de un tick.
+func (p *Player) apply(st *pb.GameState, inputs []*pb.ReplayInput) {
+	for _, in := range inputs {
+		if p.rep.HeldInputs {
+			sim.SetInput(st, in.Player, in.Dir)
+		} else {
+			// Repetición antigua: cada acción movía la pala una vez
+			sim.ApplyMove(st, in.Player, in.Dir, p.rules)
+		}
+	}
+}
+
+// Point es un punto marcado durante la partida.
+type Point struct {
+	Tick   uint32 // tick en el que la bola salió del campo
+	Player int32  // quién marcó: 1 o 2
+	Score1 int32  // marcador tras el punto
+	Score2 int32
+}
+
+// Points simula la partida entera y devuelve los puntos en orden, para
+// dibujar la línea temporal del marcador.
+func (p *Player) Points() []Point {
+	var out []Point
+	st := physical(p.rep.Initial)
+	vel := sim.Velocity{X: p.rep.VelX, Y: p.rep.VelY}
+	ticks := p.rep.Ticks
+	next := 0
+	for t := uint32(1); t <= p.rep.TotalTicks; t++ {
+		if next < len(ticks) && ticks[next].Tick == t {
+			p.apply(st, ticks[next].Inputs)
+			next++
+		}
+		s1, s2 := st.Score1, st.Score2
+		sim.Step(st, &vel, p.rules)
+		// Con varias bolas puede haber más de un punto en el mismo tick
+		for ; s1 < st.Score1; s1++ {
+			out = append(out, Point{Tick: t, Player: 1, Score1: s1 + 1, Score2: s2})
+		}
+		for ; s2 < st.Score2; s2++ {
+			out = append(out, Point{Tick: t, Player: 2, Score1: s1, Score2: s2 + 1})
+		}
+	}
+	return out
+}
@@ -0,0 +1,161 @@
+package replay
+
+import (
+	"bytes"
+	"math/rand"
+	"path/filepath"
+	"testing"
+	"time"
+
+	pb "JuegoCeN/proto"
+	"JuegoCeN/sim"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// record simula una partida con acciones aleatorias y devuelve la
+// repetición junto con el estado real tras cada tick.
+func record(t *testing.T, ticks int) (*pb.Replay, []*pb.GameState) {
+	t.Helper()
+	return recordFrom(t, sim.NewState("ABCD"), ticks)
+}
+
+// recordFrom es record partiendo del estado de saque dado.
+func recordFrom(t *testing.T, st *pb.GameState, ticks int) (*pb.Replay, []*pb.GameState) {
+	t.Helper()
+	rng := rand.New(rand.NewSource(1))
+	vel := sim.Classic.InitialVelocity()
+	rec := NewRecorder("ABCD-1", "ABCD", "Ana", "Bea", time.Unix(1700000000, 0), sim.Classic, st, vel)
+
+	truth := []*pb.GameState{physical(st)}
+	for i := 0; i < ticks; i++ {
+		// Entre cero y tres acciones por tick, como llegan por la red
+		for n := rng.Intn(4); n > 0; n-- {
+			player, dir := int32(rng.Intn(2)+1), int32(rng.Intn(3)-1)
+			if sim.SetInput(st, player, dir) {
+				rec.Input(player, dir)
+			}
+		}
+		sim.Step(st, &vel, sim.Classic)
+		rec.EndTick(st, vel)
+		truth = append(truth, physical(st))
+	}
+	return rec.Finish(st, "fin"), truth
+}
+
+func TestRecordAndReplayEveryTick(t *testing.T) {
+	rep, truth := record(t, 1000)
+	if rep.TotalTicks != 1000 {
+		t.Fatalf("TotalTicks = %d", rep.TotalTicks)
+	}
+	if len(rep.Checkpoints) != 1000/CheckpointEvery {
+		t.Fatalf("%d checkpoints", len(rep.Checkpoints))
+	}
+	if rep.Final.Score1+rep.Final.Score2 == 0 {
+		t.Fatal("en 1000 ticks debería haberse marcado algún punto")
+	}
+
+	p := NewPlayer(rep)
+	for tick := range truth {
+		got := p.StateAt(uint32(tick))
+		if !proto.Equal(got, truth[tick]) {
+			t.Fatalf("tick %d: reproducido %v, real %v", tick, got, truth[tick])
+		}
+	}
+	if !proto.Equal(p.StateAt(5000), truth[len(truth)-1]) {
+		t.Fatal("un tick posterior al final debería devolver el estado final")
+	}
+}
+
+func TestReplayWithPowerUps(t *testing.T) {
+	st := sim.NewState("ABCD")
+	sim.EnablePowerUps(st, 42)
+	rep, truth := recordFrom(t, st, 3000)
+
+	// Los objetos salen del generador del estado, así que la repetición
+	// los reproduce igual
+	p := NewPlayer(rep)
+	collected := false
+	for tick := range truth {
+		if got := p.StateAt(uint32(tick)); !proto.Equal(got, truth[tick]) {
+			t.Fatalf("tick %d: reproducido %v, real %v", tick, got, truth[tick])
+		}
+		collected = collected || len(truth[tick].Effects) > 0 || len(truth[tick].Balls) > 0
+	}
+	if !collected {
+		t.Fatal("en 3000 ticks nadie recogió ningún objeto")
+	}
+	if n := int32(len(p.Points())); n != rep.Final.Score1+rep.Final.Score2 {
+		t.Fatalf("%d puntos para un marcador %d-%d", n, rep.Final.Score1, rep.Final.Score2)
+	}
+}
+
+func TestPoints(t *testing.T) {
+	rep, truth := record(t, 1000)
+	points := NewPlayer(rep).Points()
+	if int32(len(points)) != rep.Final.Score1+rep.Final.Score2 {
+		t.Fatalf("%d puntos para un marcador %d-%d", len(points), rep.Final.Score1, rep.Final.Score2)
+	}
+	for _, pt := range points {
+		before, after := truth[pt.Tick-1], truth[pt.Tick]
+		if after.Score1 != pt.Score1 || after.Score2 != pt.Score2 ||
+			before.Score1+before.Score2+1 != after.Score1+after.Score2 {
+			t.Fatalf("punto %+v no coincide con el marcador real %d-%d", pt, after.Score1, after.Score2)
+		}
+	}
+}
+
+func TestLegacyInputs(t *testing.T) {
+	// En las repeticiones antiguas cada acción movía la pala una vez
+	st := sim.NewState("ABCD")
+	rep := &pb.Replay{
+		Rules:      RulesToProto(sim.Classic),
+		Initial:    physical(st),
+		Ticks:      []*pb.ReplayTick{{Tick: 1, Inputs: []*pb.ReplayInput{{Player: 1, Dir: -1}}}},
+		TotalTicks: 5,
+	}
+	want := st.Paddle1.Y - sim.Classic.PaddleDelta
+	if got := NewPlayer(rep).StateAt(5).Paddle1.Y; got != want {
+		t.Fatalf("repetición antigua: pala en %v, se esperaba %v", got, want)
+	}
+	rep.HeldInputs = true
+	want = st.Paddle1.Y - 5*sim.Classic.PaddleDelta
+	if got := NewPlayer(rep).StateAt(5).Paddle1.Y; !(got-want < 1e-6 && want-got < 1e-6) {
+		t.Fatalf("dirección mantenida: pala en %v, se esperaba %v", got, want)
+	}
+}
+
+func TestEncodeDecode(t *testing.T) {
+	rep, _ := record(t, 300)
+
+	var buf bytes.Buffer
+	if err := Encode(&buf, rep); err != nil {
+		t.Fatal(err)
+	}
+	got, err := Decode(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !proto.Equal(got, rep) {
+		t.Fatal("la repetición decodificada no coincide")
+	}
+
+	path := filepath.Join(t.TempDir(), "ABCD-1"+Ext)
+	if err := WriteFile(path, rep); err != nil {
+		t.Fatal(err)
+	}
+	got, err = ReadFile(path)
+	if err != nil || !proto.Equal(got, rep) {
+		t.Fatalf("ReadFile: %v", err)
+	}
+
+	if _, err := Decode(bytes.NewReader([]byte("no es gzip"))); err == nil {
+		t.Fatal("datos corruptos deberían dar error")
+	}
+}
+
+func TestRulesRoundTrip(t *testing.T) {
+	if got := RulesFromProto(RulesToProto(sim.Classic)); got != sim.Classic {
+		t.Fatalf("reglas = %+v", got)
+	}
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"crypto/hmac"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+
+	pb "JuegoCeN/proto"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// adminServer expone operaciones de inspección y control sobre las salas.
+type adminServer struct {
+	pb.UnimplementedAdminServer
+	srv *Server
+}
+
+// adminAuthInterceptor exige la clave de administración ("Bearer <clave>")
+// en las llamadas a Admin; el resto de servicios no se tocan. Con la clave
+// vacía no comprueba nada: el servidor confía entonces en el certificado
+// de cliente del TLS mutuo.
+func adminAuthInterceptor(secret string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if secret == "" || !strings.HasPrefix(info.FullMethod, "/"+pb.Admin_ServiceDesc.ServiceName+"/") {
+			return handler(ctx, req)
+		}
+		md, _ := metadata.FromIncomingContext(ctx)
+		vals := md.Get(authMetadata)
+		if len(vals) == 0 || !hmac.Equal([]byte(vals[0]), []byte(bearerPrefix+secret)) {
+			return nil, status.Error(codes.Unauthenticated, "falta la clave de administración")
+		}
+		return handler(ctx, req)
+	}
+}
+
+// lookupRoom busca una sala activa por su código.
+func (s *Server) lookupRoom(code string) (*GameRoom, error) {
+	s.roomsMu.Lock()
+	room := s.rooms[code]
+	s.roomsMu.Unlock()
+	if room == nil {
+		return nil, status.Errorf(codes.NotFound, "sala %q no encontrada", code)
+	}
+	return room, nil
+}
+
+// ListRooms devuelve las salas activas con sus jugadores y marcador.
+func (s *adminServer) ListRooms(ctx context.Context, _ *pb.ListRoomsRequest) (*pb.ListRoomsResponse, error) {
+	s.srv.roomsMu.Lock()
+	list := make([]*GameRoom, 0, len(s.srv.rooms))
+	for _, r := range s.srv.rooms {
+		list = append(list, r)
+	}
+	s.srv.roomsMu.Unlock()
+
+	resp := &pb.ListRoomsResponse{}
+	for _, r := range list {
+		r.mu.Lock()
+		info := &pb.RoomInfo{
+			RoomCode: r.roomCode,
+			Score1:   r.state.Score1,
+			Score2:   r.state.Score2,
+
+			Violations: append([]int32(nil), r.violations...),
+		}
+		for i, seat := range r.seats {
+			for _, p := range r.players {
+				if p == seat {
+					info.Players = append(info.Players, fmt.Sprintf("%d:%s", i+1, r.ids[i].Name))
+					break
+				}
+			}
+		}
+		r.mu.Unlock()
+		resp.Rooms = append(resp.Rooms, info)
+	}
+	sort.Slice(resp.Rooms, func(i, j int) bool {
+		return resp.Rooms[i].RoomCode < resp.Rooms[j].RoomCode
+	})
+	return resp, nil
+}
+
+// GetRoomState devuelve una copia del GameState actual de la sala.
+func (s *adminServer) GetRoomState(ctx context.Context, req *pb.RoomRequest) (*pb.GameState, error) {
+	room, err := s.srv.lookupRoom(req.RoomCode)
+	if err != nil {
+		return nil, err
+	}
+	room.mu.Lock()
+	defer room.mu.Unlock()
+	return proto.Clone(room.state).(*pb.GameState), nil
+}
+
+// EndMatch termina la partida y cierra los streams de ambos jugadores.
+func (s *adminServer) EndMatch(ctx context.Context, req *pb.RoomRequest) (*pb.AdminReply, error) {
+	room, err := s.srv.lookupRoom(req.RoomCode)
+	if err != nil {
+		return nil, err
+	}
+	room.finish("partida finalizada por el administrador")
+	log.Printf("Admin: partida %s finalizada", req.RoomCode)
+	return &pb.AdminReply{Message: fmt.Sprintf("partida %s finalizada", req.RoomCode)}, nil
+}
+
+// KickPlayer expulsa a un jugador; la sala termina al quedarse sin rival.
+func (s *adminServer) KickPlayer(ctx context.Context, req *pb.KickPlayerRequest) (*pb.AdminReply, error) {
+	room, err := s.srv.lookupRoom(req.RoomCode)
+	if err != nil {
+		return nil, err
+	}
+	if !room.kick(req.PlayerId) {
+		return nil, status.Errorf(codes.NotFound, "jugador %q no está en la sala %s", req.PlayerId, req.RoomCode)
+	}
+	log.Printf("Admin: jugador %s expulsado de %s", req.PlayerId, req.RoomCode)
+	return &pb.AdminReply{Message: fmt.Sprintf("jugador %s expulsado de %s", req.PlayerId, req.RoomCode)}, nil
+}
+
+// DrainQueue vacía la cola de emparejamiento; los jugadores en espera
+// reciben un error Unavailable.
+func (s *adminServer) DrainQueue(ctx context.Context, _ *pb.DrainQueueRequest) (*pb.DrainQueueResponse, error) {
+	s.srv.waitingQueueMu.Lock()
+	n := len(s.srv.waitingQueue)
+	for _, e := range s.srv.waitingQueue {
+		close(e.dequeued)
+	}
+	s.srv.waitingQueue = nil
+	s.srv.waitingQueueMu.Unlock()
+	log.Printf("Admin: cola vaciada (%d jugadores)", n)
+	return &pb.DrainQueueResponse{Drained: int32(n)}, nil
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"time"
+
+	pb "JuegoCeN/proto"
+	"JuegoCeN/sim"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Infracciones que se cuentan a un jugador. La acción que la comete se
+// descarta.
+const (
+	violationRate   = "demasiadas acciones por segundo"
+	violationMove   = "acción desconocida"
+	violationAxis   = "movimiento en el eje que no es el de su pala"
+	violationFields = "campos que solo van en la primera acción"
+)
+
+// knownMoves son las acciones que puede enviar un cliente durante la
+// partida. REMATCH y LEAVE pueden cruzarse con el final.
+var knownMoves = map[string]bool{
+	"": true, "NONE": true, "UP": true, "DOWN": true, "LEFT": true, "RIGHT": true,
+	movePause: true, moveResume: true, moveRematch: true, moveLeave: true,
+}
+
+// inputGuard limita las acciones de un jugador a Config.MaxActionRate por
+// segundo con un cubo de fichas, que admite ráfagas de hasta un segundo.
+type inputGuard struct {
+	rate   float64
+	tokens float64
+	last   time.Time
+}
+
+func newInputGuard(rate int, now time.Time) *inputGuard {
+	return &inputGuard{rate: float64(rate), tokens: float64(rate), last: now}
+}
+
+// allow gasta una ficha; false si el jugador va por encima del límite.
+func (g *inputGuard) allow(now time.Time) bool {
+	if now.After(g.last) {
+		g.tokens += now.Sub(g.last).Seconds() * g.rate
+		if g.tokens > g.rate {
+			g.tokens = g.rate
+		}
+		g.last = now
+	}
+	if g.tokens < 1 {
+		return false
+	}
+	g.tokens--
+	return true
+}
+
+// check devuelve la infracción que comete la acción del asiento i, o "".
+func (gr *GameRoom) check(i int, a *pb.GameAction) string {
+	if !knownMoves[a.Move] {
+		return violationMove
+	}
+	if a.RoomCode != "" || a.TournamentId != "" || a.Mode != "" || a.MapName != "" {
+		return violationFields
+	}
+	dir := sim.Move(a.Move)
+	if dir == 0 {
+		return ""
+	}
+	gr.mu.Lock()
+	horizontal := len(gr.state.Paddles) > i && sim.Horizontal(gr.state.Paddles[i].Side)
+	gr.mu.Unlock()
+	if horizontal != (a.Move == "LEFT" || a.Move == "RIGHT") {
+		return violationAxis
+	}
+	return ""
+}
+
+// violation anota una infracción del asiento i y lo expulsa al llegar a
+// Config.MaxViolations; la sala termina entonces con ese motivo, que queda
+// en el historial. Devuelve el motivo si lo expulsó, o "".
+func (gr *GameRoom) violation(i int, what string) string {
+	gr.mu.Lock()
+	defer gr.mu.Unlock()
+	gr.violations[i]++
+	n, max := gr.violations[i], gr.srv.cfg.MaxViolations
+	log.Printf("Sala %s: infracción %d/%d del jugador %d: %s", gr.roomCode, n, max, i+1, what)
+	if n < max {
+		return ""
+	}
+	reason := fmt.Sprintf("%s expulsado por trampas: %s", gr.ids[i].Name, what)
+	if !gr.kickSeat(i, reason) {
+		return ""
+	}
+	gr.cheated = reason
+	log.Printf("Sala %s: %s", gr.roomCode, reason)
+	return reason
+}
+
+// screen pasa la acción del asiento i por check y por el límite de guard.
+// Devuelve si se acepta y, si con ella el jugador queda expulsado, el error
+// con el que termina Play.
+func (s *Server) screen(room *GameRoom, i int, guard *inputGuard, a *pb.GameAction) (bool, error) {
+	what := room.check(i, a)
+	if what == "" && !guard.allow(s.now()) {
+		what = violationRate
+	}
+	if what == "" {
+		return true, nil
+	}
+	if reason := room.violation(i, what); reason != "" {
+		return false, status.Error(codes.PermissionDenied, reason)
+	}
+	return false, nil
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"JuegoCeN/clock"
+	pb "JuegoCeN/proto"
+	"JuegoCeN/store"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// waitViolations espera a que el asiento i de la sala llegue a n infracciones.
+func waitViolations(t *testing.T, s *Server, code string, i int, n int32) {
+	t.Helper()
+	room, err := s.lookupRoom(code)
+	if err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(waitFor)
+	for {
+		room.mu.Lock()
+		got := room.violations[i]
+		room.mu.Unlock()
+		if got == n {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("%d infracciones, se esperaban %d", got, n)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestActionRateLimit(t *testing.T) {
+	clk := clock.NewManual(t0)
+	mem := store.NewMemory()
+	h := newHarness(t, Config{ManualTicks: true, Clock: clk, Store: mem, MaxActionRate: 10, MaxViolations: 5})
+	a, b, first := h.pair()
+
+	// Una ráfaga de un segundo de acciones cabe; las que sobran se descartan
+	for i := 0; i < 12; i++ {
+		a.send("UP")
+	}
+	waitViolations(t, h.srv, first.RoomCode, 0, 2)
+
+	// Pasado un segundo vuelve a haber margen
+	clk.Advance(time.Second)
+	for i := 0; i < 10; i++ {
+		a.send("DOWN")
+	}
+	waitRoom(t, h.srv, first.RoomCode, holding(1, 1))
+	waitViolations(t, h.srv, first.RoomCode, 0, 2)
+
+	// Un cliente que inunda el servidor acaba expulsado, aunque vaya ganando
+	room, _ := h.srv.lookupRoom(first.RoomCode)
+	room.mu.Lock()
+	room.state.Score1 = 3
+	room.mu.Unlock()
+	for i := 0; i < 20; i++ {
+		a.send("UP")
+	}
+	err := a.waitEnd()
+	want := "Ana expulsado por trampas: " + violationRate
+	if status.Code(err) != codes.PermissionDenied || status.Convert(err).Message() != want {
+		t.Fatalf("Ana: %v", err)
+	}
+
+	// La sala termina con ese motivo, que queda en el historial
+	waitSeats(t, h.srv, first.RoomCode, 1)
+	h.srv.Step(first.RoomCode, 1)
+	if err := b.waitEnd(); status.Convert(err).Message() != want {
+		t.Fatalf("Bea: %v", err)
+	}
+	ms, total, _ := mem.PlayerMatches(b.id, 10, 0)
+	if total != 1 || ms[0].EndReason != want || ms[0].Forfeit != 1 || ms[0].Winner() != b.id {
+		t.Fatalf("historial de Bea = %+v", ms)
+	}
+}
+
+func TestImplausibleActions(t *testing.T) {
+	h := newHarness(t, Config{ManualTicks: true, Clock: clock.NewManual(t0), MaxViolations: 3})
+	a, _, first := h.pair()
+
+	// Ninguna de estas acciones mueve la pala de Ana
+	a.send("TELEPORT")
+	a.send("LEFT")
+	waitViolations(t, h.srv, first.RoomCode, 0, 2)
+	if st, _ := h.srv.Step(first.RoomCode, 1); st.Paddle1.Y != first.Paddle1.Y {
+		t.Fatalf("la pala se movió: %v", st.Paddle1)
+	}
+
+	// A la tercera, expulsado
+	if err := a.stream.Send(&pb.GameAction{Move: "UP", Mode: "SPEED"}); err != nil {
+		t.Fatal(err)
+	}
+	err := a.waitEnd()
+	if status.Code(err) != codes.PermissionDenied || status.Convert(err).Message() != "Ana expulsado por trampas: "+violationFields {
+		t.Fatalf("err = %v", err)
+	}
+}
+
+func TestRematchActionsChecked(t *testing.T) {
+	h := newHarness(t, Config{ManualTicks: true, Clock: clock.NewManual(t0), PointsToWin: 1, MaxViolations: 3})
+	a, b, first := h.pair()
+	playOut(t, h.srv, first.RoomCode)
+	finished := func(st *pb.GameState) bool { return st.Finished }
+	a.waitState(finished)
+	b.waitState(finished)
+
+	// En el plazo de revancha cuentan las mismas infracciones que jugando
+	// (a la tercera, expulsado) y la revancha se cierra con ese motivo
+	for i := 0; i < 3; i++ {
+		a.send("TELEPORT")
+	}
+	want := "Ana expulsado por trampas: " + violationMove
+	if err := a.waitEnd(); status.Code(err) != codes.PermissionDenied || status.Convert(err).Message() != want {
+		t.Fatalf("Ana: %v", err)
+	}
+	if err := b.waitEnd(); status.Convert(err).Message() != want {
+		t.Fatalf("Bea: %v", err)
+	}
+}
@@ -0,0 +1,230 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"JuegoCeN/clock"
+	pb "JuegoCeN/proto"
+	"JuegoCeN/sim"
+	"JuegoCeN/store"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// four conecta a cuatro jugadores en modo FOUR, uno detrás de otro, y
+// espera el estado inicial de todos. Ya puede haber otros en la cola.
+func (h *harness) four() (ps []*player, first *pb.GameState) {
+	h.t.Helper()
+	h.srv.waitingQueueMu.Lock()
+	queued := len(h.srv.waitingQueue)
+	h.srv.waitingQueueMu.Unlock()
+	for i, name := range []string{"Ana", "Bea", "Carlos", "Dani"} {
+		ps = append(ps, h.playWith(h.login(name), &pb.GameAction{Mode: modeFour}))
+		if i < 3 {
+			waitQueueLen(h.t, h.srv, queued+i+1)
+		}
+	}
+	first = ps[0].next()
+	for _, p := range ps[1:] {
+		if st := p.next(); st.RoomCode != first.RoomCode {
+			h.t.Fatalf("salas distintas: %s y %s", first.RoomCode, st.RoomCode)
+		}
+	}
+	return ps, first
+}
+
+func TestFourPlayerRoom(t *testing.T) {
+	h := newHarness(t, Config{ManualTicks: true, Clock: clock.NewManual(t0)})
+
+	// Quien busca un duelo no entra en la sala de cuatro
+	h.join("Eva")
+	waitQueueLen(t, h.srv, 1)
+	ps, first := h.four()
+	if len(first.Paddles) != 4 {
+		t.Fatalf("palas = %v", first.Paddles)
+	}
+	sides := []pb.Side{pb.Side_LEFT, pb.Side_RIGHT, pb.Side_TOP, pb.Side_BOTTOM}
+	for i, p := range ps {
+		if want := string(rune('1' + i)); p.seat != want {
+			t.Fatalf("jugador %d en el asiento %s", i+1, p.seat)
+		}
+		if pad := first.Paddles[i]; pad.Side != sides[i] || pad.Name != []string{"Ana", "Bea", "Carlos", "Dani"}[i] {
+			t.Fatalf("pala %d = %v", i+1, pad)
+		}
+	}
+	waitQueueLen(t, h.srv, 1)
+
+	// La pala de arriba se mueve en horizontal
+	ps[2].send("RIGHT")
+	waitRoom(t, h.srv, first.RoomCode, holding(3, 1))
+	if st, _ := h.srv.Step(first.RoomCode, 1); st.Paddles[2].Pos.X <= first.Paddles[2].Pos.X ||
+		st.Paddles[2].Pos.Y != first.Paddles[2].Pos.Y {
+		t.Fatalf("pala de arriba en %v", st.Paddles[2].Pos)
+	}
+
+	// Si alguien se va, la sala termina para los demás
+	ps[3].cancel()
+	waitSeats(t, h.srv, first.RoomCode, 3)
+	h.srv.Step(first.RoomCode, 1)
+	for _, p := range ps[:3] {
+		if err := p.waitEnd(); status.Convert(err).Message() != playerLeft {
+			t.Fatalf("err = %v", err)
+		}
+	}
+}
+
+func TestFourPlayerWin(t *testing.T) {
+	mem := store.NewMemory()
+	h := newHarness(t, Config{ManualTicks: true, Clock: clock.NewManual(t0), PointsToWin: 2, Store: mem})
+	ps, first := h.four()
+
+	reason := playOut(t, h.srv, first.RoomCode)
+	if !strings.HasPrefix(reason, "Gana ") || !strings.HasSuffix(reason, "con 2 puntos") {
+		t.Fatalf("motivo = %q", reason)
+	}
+	for _, p := range ps {
+		if err := p.waitEnd(); status.Code(err) != codes.Aborted || status.Convert(err).Message() != reason {
+			t.Fatalf("err = %v", err)
+		}
+	}
+	// Las partidas a cuatro no cuentan para el historial
+	if _, total, _ := mem.PlayerMatches(ps[0].id, 10, 0); total != 0 {
+		t.Fatalf("%d partidas en el historial", total)
+	}
+}
+
+func TestMultiBallRoom(t *testing.T) {
+	clk := clock.NewManual(t0)
+	modes := sim.DefaultModes()
+	multi := modes[modeMulti]
+	multi.Balls = 4
+	modes[modeMulti] = multi
+	h := newHarness(t, Config{ManualTicks: true, Clock: clk, PointsToWin: 3, Modes: modes})
+
+	// Quien busca un duelo normal no entra en la sala de varias bolas
+	h.join("Eva")
+	waitQueueLen(t, h.srv, 1)
+	a := h.playWith(h.login("Ana"), &pb.GameAction{Mode: modeMulti})
+	waitQueueLen(t, h.srv, 2)
+	b := h.playWith(h.login("Bea"), &pb.GameAction{Mode: modeMulti})
+	first := a.next()
+	b.next()
+	if len(first.Balls) != 4 || first.Name1 != "Ana" || first.Name2 != "Bea" {
+		t.Fatalf("estado inicial = %v", first)
+	}
+	waitQueueLen(t, h.srv, 1)
+
+	// Es un duelo: se gana a PointsToWin y la revancha sigue con varias bolas
+	if reason := playOut(t, h.srv, first.RoomCode); !strings.HasPrefix(reason, "Gana ") {
+		t.Fatalf("motivo = %q", reason)
+	}
+	finished := func(st *pb.GameState) bool { return st.Finished }
+	a.waitState(finished)
+	b.waitState(finished)
+	clk.Advance(time.Second)
+	a.send(moveRematch)
+	b.waitState(func(st *pb.GameState) bool { return st.RematchOffer1 })
+	b.send(moveRematch)
+	if st := a.waitState(func(st *pb.GameState) bool { return !st.Finished }); len(st.Balls) != 4 {
+		t.Fatalf("revancha con %d bolas", len(st.Balls))
+	}
+}
+
+func TestSpeedModeRules(t *testing.T) {
+	h := newHarness(t, Config{ManualTicks: true, Clock: clock.NewManual(t0)})
+	a := h.playWith(h.login("Ana"), &pb.GameAction{Mode: "SPEED"})
+	waitQueueLen(t, h.srv, 1)
+	h.playWith(h.login("Bea"), &pb.GameAction{Mode: "SPEED"})
+	first := a.next()
+	if first.Mode.GetName() != "SPEED" || sim.RulesOf(first) != sim.Speed {
+		t.Fatalf("modo = %v", first.Mode)
+	}
+	// La configuración va solo en el primer estado de la sala
+	h.srv.Step(first.RoomCode, 1)
+	if st := a.next(); st.Mode != nil {
+		t.Fatalf("el modo se repite: %v", st.Mode)
+	}
+
+	// La pala se mueve con el paso de las reglas del modo
+	a.send("UP")
+	waitRoom(t, h.srv, first.RoomCode, holding(1, -1))
+	st, _ := h.srv.Step(first.RoomCode, 1)
+	if want := first.Paddle1.Y - sim.Speed.PaddleDelta; st.Paddle1.Y != want {
+		t.Fatalf("pala en %v, se esperaba %v", st.Paddle1.Y, want)
+	}
+}
+
+func TestPracticeAlone(t *testing.T) {
+	mem := store.NewMemory()
+	h := newHarness(t, Config{ManualTicks: true, Clock: clock.NewManual(t0), PointsToWin: 1, Store: mem})
+	p := h.playWith(h.login("Ana"), &pb.GameAction{Mode: modePractice})
+	first := p.next()
+	if p.seat != "1" || first.Name2 != practiceWall || !first.Mode.GetWall() {
+		t.Fatalf("estado inicial = %v", first)
+	}
+
+	// Sin mover la pala la pared suma puntos, pero la práctica no termina
+	st, err := h.srv.Step(first.RoomCode, 2000)
+	if err != nil || st.Score2 < 2 {
+		t.Fatalf("tras 2000 ticks: %v, %v", st, err)
+	}
+
+	p.cancel()
+	waitSeats(t, h.srv, first.RoomCode, 0)
+	h.srv.Step(first.RoomCode, 1)
+	if _, err := h.srv.lookupRoom(first.RoomCode); err == nil {
+		t.Fatal("la sala de práctica sigue abierta")
+	}
+	if _, total, _ := mem.PlayerMatches(p.id, 10, 0); total != 0 {
+		t.Fatalf("%d partidas en el historial", total)
+	}
+}
+
+func TestUnknownMode(t *testing.T) {
+	h := newHarness(t, Config{})
+	p := h.playWith(h.login("Ana"), &pb.GameAction{Mode: "SIX"})
+	if err := p.waitEnd(); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("err = %v", err)
+	}
+}
+
+func TestTeamsBalancedByRating(t *testing.T) {
+	rs := ratedStore{Store: store.NewMemory(), ratings: map[string]float64{}}
+	h := newHarness(t, Config{ManualTicks: true, Clock: clock.NewManual(t0), PointsToWin: 2, Store: rs})
+
+	var ps []*player
+	for i, name := range []string{"Ana", "Bea", "Carlos", "Dani"} {
+		login := h.login(name)
+		rs.ratings[login.PlayerId] = []float64{1560, 1540, 1510, 1470}[i]
+		ps = append(ps, h.playWith(login, &pb.GameAction{Mode: modeTeams}))
+		if i < 3 {
+			waitQueueLen(t, h.srv, i+1)
+		}
+	}
+	first := ps[0].next()
+
+	// La mejor y el peor juntos a la izquierda
+	if first.Name1 != "Ana y Dani" || first.Name2 != "Bea y Carlos" {
+		t.Fatalf("equipos %q contra %q", first.Name1, first.Name2)
+	}
+	if first.Rating1 != 1515 || first.Rating2 != 1525 {
+		t.Fatalf("puntuaciones %v y %v", first.Rating1, first.Rating2)
+	}
+	for _, p := range ps[1:] {
+		p.next()
+	}
+	for i, want := range []string{"1", "3", "4", "2"} {
+		if ps[i].seat != want {
+			t.Fatalf("jugador %d en el asiento %s", i+1, ps[i].seat)
+		}
+	}
+
+	// El marcador es de los equipos
+	reason := playOut(t, h.srv, first.RoomCode)
+	if reason != "Gana Ana y Dani 2-0" && reason != "Gana Bea y Carlos 2-0" {
+		t.Fatalf("motivo = %q", reason)
+	}
+}
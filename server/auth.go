@@ -0,0 +1,207 @@
+package main
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"log"
+	"strings"
+	"time"
+
+	pb "JuegoCeN/proto"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	tokenTTL       = 30 * 24 * time.Hour
+	maxNameLen     = 16
+	defaultName    = "Jugador"
+	authMetadata   = "authorization"
+	bearerPrefix   = "Bearer "
+	playerIDLength = 8 // bytes aleatorios, se codifican en hex
+)
+
+var errBadToken = errors.New("token inválido")
+
+// Identity identifica de forma estable a un jugador autenticado.
+type Identity struct {
+	ID   string `json:"sub"`
+	Name string `json:"name"`
+	Exp  int64  `json:"exp"`
+}
+
+type identityKey struct{}
+
+// identityFrom devuelve la identidad que el interceptor dejó en el contexto.
+func identityFrom(ctx context.Context) (Identity, bool) {
+	id, ok := ctx.Value(identityKey{}).(Identity)
+	return id, ok
+}
+
+// tokenSigner emite y verifica tokens firmados con HMAC-SHA256 con el
+// formato base64(payload JSON) "." base64(firma).
+type tokenSigner struct {
+	secret []byte
+	now    func() time.Time
+}
+
+// newTokenSigner usa el secreto dado o, si está vacío, genera uno aleatorio
+// (los tokens dejan de valer al reiniciar el servidor).
+func newTokenSigner(secret string) *tokenSigner {
+	if secret != "" {
+		return &tokenSigner{secret: []byte(secret), now: time.Now}
+	}
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		log.Fatalf("no se pudo generar el secreto de tokens: %v", err)
+	}
+	log.Println("Aviso: sin -auth-secret, los tokens no sobrevivirán a un reinicio")
+	return &tokenSigner{secret: key, now: time.Now}
+}
+
+func (ts *tokenSigner) sign(id Identity) string {
+	payload, _ := json.Marshal(id)
+	mac := hmac.New(sha256.New, ts.secret)
+	mac.Write(payload)
+	enc := base64.RawURLEncoding
+	return enc.EncodeToString(payload) + "." + enc.EncodeToString(mac.Sum(nil))
+}
+
+// parse comprueba la firma y devuelve la identidad, sin mirar la caducidad.
+func (ts *tokenSigner) parse(token string) (Identity, error) {
+	var id Identity
+	payloadB64, sigB64, ok := strings.Cut(token, ".")
+	if !ok {
+		return id, errBadToken
+	}
+	enc := base64.RawURLEncoding
+	payload, err := enc.DecodeString(payloadB64)
+	if err != nil {
+		return id, errBadToken
+	}
+	sig, err := enc.DecodeString(sigB64)
+	if err != nil {
+		return id, errBadToken
+	}
+	mac := hmac.New(sha256.New, ts.secret)
+	mac.Write(payload)
+	if !hmac.Equal(sig, mac.Sum(nil)) {
+		return id, errBadToken
+	}
+	if err := json.Unmarshal(payload, &id); err != nil || id.ID == "" {
+		return id, errBadToken
+	}
+	return id, nil
+}
+
+// verify es parse más la comprobación de caducidad.
+func (ts *tokenSigner) verify(token string) (Identity, error) {
+	id, err := ts.parse(token)
+	if err != nil {
+		return id, err
+	}
+	if ts.now().Unix() > id.Exp {
+		return id, errors.New("token caducado")
+	}
+	return id, nil
+}
+
+// cleanName recorta el nombre visible y aplica un valor por defecto.
+func cleanName(name string) string {
+	if r := []rune(strings.TrimSpace(name)); len(r) > maxNameLen {
+		name = string(r[:maxNameLen])
+	}
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return defaultName
+	}
+	return name
+}
+
+func newPlayerID() string {
+	b := make([]byte, playerIDLength)
+	if _, err := rand.Read(b); err != nil {
+		log.Fatalf("no se pudo generar un id de jugador: %v", err)
+	}
+	return hex.EncodeToString(b)
+}
+
+// authServer emite tokens a los jugadores.
+type authServer struct {
+	pb.UnimplementedAuthServer
+	srv *Server
+}
+
+// Login emite un token nuevo. Con un token previo bien firmado (aunque haya
+// caducado) se conserva el mismo player_id.
+func (s *authServer) Login(ctx context.Context, req *pb.LoginRequest) (*pb.LoginResponse, error) {
+	id := Identity{ID: newPlayerID()}
+	if req.Token != "" {
+		prev, err := s.srv.signer.parse(req.Token)
+		if err != nil {
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+		id.ID = prev.ID
+	}
+	id.Name = cleanName(req.DisplayName)
+	now := s.srv.now()
+	id.Exp = now.Add(tokenTTL).Unix()
+
+	log.Printf("Login: %s (%s)", id.Name, id.ID)
+	if err := s.srv.store.TouchProfile(id.ID, id.Name, now); err != nil {
+		log.Printf("No se pudo guardar el perfil de %s: %v", id.ID, err)
+	}
+	return &pb.LoginResponse{
+		Token:       s.srv.signer.sign(id),
+		PlayerId:    id.ID,
+		DisplayName: id.Name,
+		ExpiresAt:   id.Exp,
+	}, nil
+}
+
+// identityFromMetadata valida el token "Bearer" de los metadatos entrantes.
+func identityFromMetadata(ctx context.Context, signer *tokenSigner) (Identity, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	vals := md.Get(authMetadata)
+	if len(vals) == 0 || !strings.HasPrefix(vals[0], bearerPrefix) {
+		return Identity{}, status.Error(codes.Unauthenticated, "falta el token de jugador")
+	}
+	id, err := signer.verify(strings.TrimPrefix(vals[0], bearerPrefix))
+	if err != nil {
+		return Identity{}, status.Error(codes.Unauthenticated, err.Error())
+	}
+	return id, nil
+}
+
+// authedStream sustituye el contexto del stream por uno con la identidad.
+type authedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authedStream) Context() context.Context { return s.ctx }
+
+// streamAuthInterceptor exige un token "Bearer" válido en los metadatos
+// de Play.
+func streamAuthInterceptor(signer *tokenSigner) grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if info.FullMethod != pb.PingPong_Play_FullMethodName {
+			return handler(srv, ss)
+		}
+		id, err := identityFromMetadata(ss.Context(), signer)
+		if err != nil {
+			return err
+		}
+		ctx := context.WithValue(ss.Context(), identityKey{}, id)
+		return handler(srv, &authedStream{ServerStream: ss, ctx: ctx})
+	}
+}
@@ -0,0 +1,111 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"JuegoCeN/clock"
+	pb "JuegoCeN/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func testSigner(now time.Time) *tokenSigner {
+	return &tokenSigner{secret: []byte("secreto"), now: func() time.Time { return now }}
+}
+
+func TestTokenVerify(t *testing.T) {
+	ts := testSigner(t0)
+	valid := ts.sign(Identity{ID: "abc", Name: "Ana", Exp: t0.Add(time.Hour).Unix()})
+	payload, sig, _ := strings.Cut(valid, ".")
+	enc := base64.RawURLEncoding
+	other := (&tokenSigner{secret: []byte("otro")}).sign(Identity{ID: "abc", Name: "Ana", Exp: t0.Add(time.Hour).Unix()})
+	forged := enc.EncodeToString([]byte(`{"sub":"xyz","name":"Eva","exp":9999999999}`)) + "." + sig
+
+	tests := []struct {
+		name    string
+		token   string
+		parseOK bool // bien firmado, aunque haya caducado
+		valid   bool
+	}{
+		{"válido", valid, true, true},
+		{"caducado", ts.sign(Identity{ID: "abc", Exp: t0.Add(-time.Second).Unix()}), true, false},
+		{"justo al caducar", ts.sign(Identity{ID: "abc", Exp: t0.Unix()}), true, true},
+		{"firma de otro secreto", other, false, false},
+		{"payload cambiado", forged, false, false},
+		{"firma cambiada", payload + "." + enc.EncodeToString([]byte("firma")), false, false},
+		{"sin separador", payload + sig, false, false},
+		{"payload no base64", "%%%." + sig, false, false},
+		{"firma no base64", payload + ".%%%", false, false},
+		{"vacío", "", false, false},
+		{"sin id", ts.sign(Identity{Name: "Ana", Exp: t0.Add(time.Hour).Unix()}), false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ts.parse(tt.token); (err == nil) != tt.parseOK {
+				t.Errorf("parse: %v", err)
+			}
+			id, err := ts.verify(tt.token)
+			if (err == nil) != tt.valid {
+				t.Errorf("verify: %v", err)
+			}
+			if err == nil && id.ID != "abc" {
+				t.Errorf("id = %+v", id)
+			}
+		})
+	}
+}
+
+func TestCleanName(t *testing.T) {
+	tests := []struct{ in, want string }{
+		{"Ana", "Ana"},
+		{"  Bea  ", "Bea"},
+		{"", defaultName},
+		{"   ", defaultName},
+		{"ÑandúÑandúÑandúÑandú", "ÑandúÑandúÑandúÑ"},
+		{"  abcdefghijklmnopq", "abcdefghijklmnop"},
+		{"abcdefghijklmno pq", "abcdefghijklmno"},
+	}
+	for _, tt := range tests {
+		if got := cleanName(tt.in); got != tt.want {
+			t.Errorf("cleanName(%q) = %q, se esperaba %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestLoginKeepsIDOfExpiredToken(t *testing.T) {
+	clk := clock.NewManual(t0)
+	h := newHarness(t, Config{Clock: clk})
+	first := h.login("Ana")
+
+	// Pasada la caducidad el token ya no sirve para jugar...
+	clk.Advance(tokenTTL + time.Second)
+	if _, err := h.srv.signer.verify(first.Token); err == nil {
+		t.Fatal("el token caducado sigue valiendo")
+	}
+
+	// ...pero sí para renovarlo conservando el jugador
+	ctx, cancel := context.WithTimeout(context.Background(), waitFor)
+	defer cancel()
+	auth := pb.NewAuthClient(h.conn)
+	again, err := auth.Login(ctx, &pb.LoginRequest{Token: first.Token, DisplayName: "Ana2"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again.PlayerId != first.PlayerId || again.DisplayName != "Ana2" || again.ExpiresAt <= first.ExpiresAt {
+		t.Fatalf("renovado = %v, antes %v", again, first)
+	}
+	if _, err := h.srv.signer.verify(again.Token); err != nil {
+		t.Fatalf("token renovado: %v", err)
+	}
+
+	// Un token manipulado no se renueva
+	_, err = auth.Login(ctx, &pb.LoginRequest{Token: first.Token + "x", DisplayName: "Eva"})
+	if status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("err = %v, se esperaba Unauthenticated", err)
+	}
+}
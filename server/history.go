@@ -0,0 +1,157 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	pb "JuegoCeN/proto"
+	"JuegoCeN/store"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultHistoryLimit = 20
+	maxHistoryLimit     = 100
+)
+
+// historyServer consulta perfiles e historial en el Store del servidor.
+type historyServer struct {
+	pb.UnimplementedHistoryServer
+	srv *Server
+}
+
+// playerFor devuelve el player_id pedido o, si está vacío, el del token.
+func (s *historyServer) playerFor(ctx context.Context, playerID string) (string, error) {
+	if playerID != "" {
+		return playerID, nil
+	}
+	id, err := identityFromMetadata(ctx, s.srv.signer)
+	if err != nil {
+		return "", err
+	}
+	return id.ID, nil
+}
+
+// GetProfile devuelve el perfil y las estadísticas de un jugador.
+func (s *historyServer) GetProfile(ctx context.Context, req *pb.PlayerRequest) (*pb.PlayerProfile, error) {
+	id, err := s.playerFor(ctx, req.PlayerId)
+	if err != nil {
+		return nil, err
+	}
+	p, err := s.srv.store.Profile(id)
+	if errors.Is(err, store.ErrNotFound) {
+		return nil, status.Errorf(codes.NotFound, "jugador %q no encontrado", id)
+	} else if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &pb.PlayerProfile{
+		PlayerId:    p.ID,
+		DisplayName: p.Name,
+		FirstSeen:   p.FirstSeen.Unix(),
+		LastSeen:    p.LastSeen.Unix(),
+		Played:      int32(p.Played),
+		Wins:        int32(p.Wins),
+		Losses:      int32(p.Losses),
+		Rating:      float32(p.Rating),
+	}, nil
+}
+
+// GetMatchHistory devuelve las partidas de un jugador, las más recientes
+// primero, o NotFound si el jugador no existe.
+func (s *historyServer) GetMatchHistory(ctx context.Context, req *pb.MatchHistoryRequest) (*pb.MatchHistoryResponse, error) {
+	id, err := s.playerFor(ctx, req.PlayerId)
+	if err != nil {
+		return nil, err
+	}
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultHistoryLimit
+	} else if limit > maxHistoryLimit {
+		limit = maxHistoryLimit
+	}
+	if req.Offset < 0 {
+		return nil, status.Error(codes.InvalidArgument, "offset negativo")
+	}
+
+	ms, total, err := s.srv.store.PlayerMatches(id, limit, int(req.Offset))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if total == 0 {
+		// Sin partidas puede ser un jugador nuevo o uno que no existe
+		if _, err := s.srv.store.Profile(id); errors.Is(err, store.ErrNotFound) {
+			return nil, status.Errorf(codes.NotFound, "jugador %q no encontrado", id)
+		}
+	}
+	resp := &pb.MatchHistoryResponse{Total: int32(total)}
+	for _, m := range ms {
+		resp.Matches = append(resp.Matches, &pb.MatchRecord{
+			MatchId:     m.ID,
+			RoomCode:    m.RoomCode,
+			Player1Id:   m.Player1.ID,
+			Player1Name: m.Player1.Name,
+			Player2Id:   m.Player2.ID,
+			Player2Name: m.Player2.Name,
+			Score1:      m.Score1,
+			Score2:      m.Score2,
+			StartedAt:   m.StartedAt.Unix(),
+			DurationMs:  m.Duration.Milliseconds(),
+			EndReason:   m.EndReason,
+			WinnerId:    m.Winner(),
+		})
+	}
+	return resp, nil
+}
+
+// periodStart devuelve desde cuándo cuentan las partidas de un periodo.
+func periodStart(p pb.Period, now time.Time) (time.Time, error) {
+	switch p {
+	case pb.Period_PERIOD_ALL:
+		return time.Time{}, nil
+	case pb.Period_PERIOD_DAY:
+		return now.AddDate(0, 0, -1), nil
+	case pb.Period_PERIOD_WEEK:
+		return now.AddDate(0, 0, -7), nil
+	case pb.Period_PERIOD_MONTH:
+		return now.AddDate(0, -1, 0), nil
+	}
+	return time.Time{}, status.Errorf(codes.InvalidArgument, "periodo desconocido %v", p)
+}
+
+// GetLeaderboard devuelve la clasificación paginada del periodo pedido.
+func (s *historyServer) GetLeaderboard(ctx context.Context, req *pb.LeaderboardRequest) (*pb.LeaderboardResponse, error) {
+	since, err := periodStart(req.Period, s.srv.now())
+	if err != nil {
+		return nil, err
+	}
+	limit := int(req.Limit)
+	if limit <= 0 {
+		limit = defaultHistoryLimit
+	} else if limit > maxHistoryLimit {
+		limit = maxHistoryLimit
+	}
+	if req.Offset < 0 {
+		return nil, status.Error(codes.InvalidArgument, "offset negativo")
+	}
+
+	rows, total, err := s.srv.store.Leaderboard(since, limit, int(req.Offset))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	resp := &pb.LeaderboardResponse{Total: int32(total)}
+	for i, r := range rows {
+		resp.Entries = append(resp.Entries, &pb.LeaderboardEntry{
+			Rank:        req.Offset + int32(i) + 1,
+			PlayerId:    r.ID,
+			DisplayName: r.Name,
+			Rating:      float32(r.Rating),
+			Wins:        int32(r.Wins),
+			Losses:      int32(r.Losses),
+			Played:      int32(r.Played),
+		})
+	}
+	return resp, nil
+}
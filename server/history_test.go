@@ -0,0 +1,141 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"JuegoCeN/clock"
+	pb "JuegoCeN/proto"
+	"JuegoCeN/store"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// record apunta una partida ya jugada en el store.
+func record(t *testing.T, s store.Store, id, p1, p2 string, s1, s2 int32, at time.Time) {
+	t.Helper()
+	err := s.RecordMatch(store.Match{
+		ID:        id,
+		Player1:   store.MatchPlayer{ID: p1, Name: p1},
+		Player2:   store.MatchPlayer{ID: p2, Name: p2},
+		Score1:    s1,
+		Score2:    s2,
+		StartedAt: at,
+		Duration:  time.Minute,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGetMatchHistory(t *testing.T) {
+	mem := store.NewMemory()
+	// 105 partidas, una por minuto hacia atrás desde t0: m0 es la última
+	for i := 0; i < 105; i++ {
+		record(t, mem, fmt.Sprintf("m%d", i), "ana", "bea", 3, 1, t0.Add(-time.Duration(i)*time.Minute))
+	}
+	h := newHarness(t, Config{ManualTicks: true, Clock: clock.NewManual(t0), Store: mem})
+	hc := pb.NewHistoryClient(h.conn)
+	ctx := context.Background()
+
+	for _, tc := range []struct {
+		name          string
+		limit, offset int32
+		n             int
+		first         string
+	}{
+		{"límite por defecto", 0, 0, defaultHistoryLimit, "m0"},
+		{"límite pedido", 5, 0, 5, "m0"},
+		{"límite recortado", 1000, 0, maxHistoryLimit, "m0"},
+		{"segunda página", 10, 10, 10, "m10"},
+		{"última página", 10, 100, 5, "m100"},
+		{"más allá del final", 10, 200, 0, ""},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := hc.GetMatchHistory(ctx, &pb.MatchHistoryRequest{PlayerId: "bea", Limit: tc.limit, Offset: tc.offset})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if resp.Total != 105 || len(resp.Matches) != tc.n {
+				t.Fatalf("total %d, %d partidas", resp.Total, len(resp.Matches))
+			}
+			for i := 1; i < len(resp.Matches); i++ {
+				if resp.Matches[i].StartedAt > resp.Matches[i-1].StartedAt {
+					t.Fatalf("no van de la más reciente a la más antigua: %v", resp.Matches)
+				}
+			}
+			if tc.n > 0 {
+				if m := resp.Matches[0]; m.MatchId != tc.first || m.WinnerId != "ana" {
+					t.Fatalf("primera = %v", m)
+				}
+			}
+		})
+	}
+
+	if _, err := hc.GetMatchHistory(ctx, &pb.MatchHistoryRequest{PlayerId: "bea", Offset: -1}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("offset negativo: %v", err)
+	}
+	if _, err := hc.GetMatchHistory(ctx, &pb.MatchHistoryRequest{PlayerId: "nadie"}); status.Code(err) != codes.NotFound {
+		t.Fatalf("jugador inexistente: %v", err)
+	}
+	if _, err := hc.GetMatchHistory(ctx, &pb.MatchHistoryRequest{}); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("sin player_id ni token: %v", err)
+	}
+	// Sin player_id vale el del token; un jugador recién llegado existe
+	// aunque no tenga partidas
+	resp, err := hc.GetMatchHistory(authed(t, h.login("Carlos")), &pb.MatchHistoryRequest{})
+	if err != nil || resp.Total != 0 {
+		t.Fatalf("Carlos: %v, %v", resp, err)
+	}
+}
+
+func TestGetLeaderboard(t *testing.T) {
+	mem := store.NewMemory()
+	record(t, mem, "m1", "ana", "bea", 5, 3, t0.Add(-48*time.Hour))
+	record(t, mem, "m2", "ana", "carlos", 5, 1, t0.Add(-time.Hour))
+	record(t, mem, "m3", "bea", "carlos", 5, 2, t0.Add(-time.Hour))
+	h := newHarness(t, Config{ManualTicks: true, Clock: clock.NewManual(t0), Store: mem})
+	hc := pb.NewHistoryClient(h.conn)
+	ctx := context.Background()
+
+	resp, err := hc.GetLeaderboard(ctx, &pb.LeaderboardRequest{Limit: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Total != 3 || len(resp.Entries) != 2 {
+		t.Fatalf("total %d, %d filas", resp.Total, len(resp.Entries))
+	}
+	if e := resp.Entries[0]; e.PlayerId != "ana" || e.Rank != 1 || e.Wins != 2 || e.Played != 2 {
+		t.Fatalf("primero = %v", e)
+	}
+	if resp.Entries[1].Rating > resp.Entries[0].Rating {
+		t.Fatalf("clasificación desordenada: %v", resp.Entries)
+	}
+
+	// La segunda página sigue la numeración
+	resp, _ = hc.GetLeaderboard(ctx, &pb.LeaderboardRequest{Limit: 2, Offset: 2})
+	if len(resp.Entries) != 1 || resp.Entries[0].PlayerId != "carlos" || resp.Entries[0].Rank != 3 {
+		t.Fatalf("segunda página = %v", resp.Entries)
+	}
+
+	// En el último día no cuenta la victoria de ana sobre bea
+	resp, _ = hc.GetLeaderboard(ctx, &pb.LeaderboardRequest{Period: pb.Period_PERIOD_DAY})
+	for _, e := range resp.Entries {
+		if e.PlayerId == "bea" && (e.Played != 1 || e.Losses != 0) {
+			t.Fatalf("bea en el día = %v", e)
+		}
+	}
+	if resp.Total != 3 {
+		t.Fatalf("total del día = %d", resp.Total)
+	}
+
+	if _, err := hc.GetLeaderboard(ctx, &pb.LeaderboardRequest{Offset: -1}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("offset negativo: %v", err)
+	}
+	if _, err := hc.GetLeaderboard(ctx, &pb.LeaderboardRequest{Period: 99}); status.Code(err) != codes.InvalidArgument {
+		t.Fatalf("periodo desconocido: %v", err)
+	}
+}
@@ -1,128 +1,343 @@
 package main
 
 import (
+	"errors"
+	"flag"
 	"fmt"
 	"log"
 	"net"
+	"os"
+	"path/filepath"
+	"strconv"
 	"sync"
 	"time"
 
 	pb "JuegoCeN/proto"
+	"JuegoCeN/replay"
+	"JuegoCeN/sim"
+	"JuegoCeN/store"
+	"JuegoCeN/tlsutil"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 )
 
 type GameRoom struct {
+	srv      *Server
 	mu       sync.Mutex
 	players  []pb.PingPong_PlayServer
 	state    *pb.GameState
-	velX     float32
-	velY     float32
+	vel      sim.Velocity
+	rules    sim.Rules
 	roomCode string
+	mode     sim.Mode
+
+	// rec graba la partida si hay directorio de repeticiones (-replays).
+	rec *replay.Recorder
+
+	// seats conserva el orden original de los jugadores (índice = player_id-1)
+	// y kicks el canal con el que se expulsa a cada uno, con el motivo en
+	// kickReasons.
+	seats       []pb.PingPong_PlayServer
+	kicks       []chan struct{}
+	kickReasons []string
+
+	// violations cuenta las infracciones de cada asiento y cheated es el
+	// motivo de la última expulsión por trampas, con el que termina la sala
+	// (anticheat.go).
+	violations []int32
+	cheated    string
+
+	// ids guarda la identidad autenticada de cada asiento.
+	ids       []Identity
+	startedAt time.Time
+
+	// pausedAt es cuándo empezó la pausa en curso y resumeAt cuándo termina
+	// la cuenta atrás (cero mientras falte el visto bueno de alguien).
+	pausedAt time.Time
+	resumeAt time.Time
+
+	// done se cierra cuando la partida termina; endReason explica por qué.
+	done      chan struct{}
+	endOnce   sync.Once
+	endReason string
+
+	// rematch es el plazo de revancha que se abre al terminar si ambos
+	// siguen conectados (nil si no).
+	rematch *rematch
+
+	// tmatch es el partido de torneo que se juega en la sala (nil si no).
+	tmatch *tournamentMatch
+
+	// sendMu serializa los envíos a los streams, que tras terminar la
+	// partida hacen también las ofertas de revancha.
+	sendMu sync.Mutex
+
+	// modeSent indica que ya se envió la configuración de la partida
+	// (GameState.Mode), que solo va en el primer estado. Protegido por sendMu.
+	modeSent bool
 }
 
-var (
-	// Cola de emparejamiento
-	waitingQueue   []pb.PingPong_PlayServer
-	waitingQueueMu sync.Mutex
+// matchID identifica la partida en el historial y en las repeticiones.
+func (gr *GameRoom) matchID() string {
+	return fmt.Sprintf("%s-%d", gr.roomCode, gr.startedAt.UnixNano())
+}
+
+// finish marca la partida como terminada y la retira del registro de salas.
+// Es seguro llamarlo varias veces; solo cuenta el primer motivo.
+func (gr *GameRoom) finish(reason string) {
+	gr.endOnce.Do(func() {
+		gr.mu.Lock()
+		gr.endReason = reason
+		var final *pb.GameState
+		var pls []pb.PingPong_PlayServer
+		winner := -1
+		switch {
+		case gr.tmatch != nil:
+			// En un torneo siempre hay ganador, y quien siga conectado
+			// vuelve a la sala de espera del torneo
+			winner = gr.closeTournamentMatch(reason)
+		case len(gr.players) == 2 && gr.playedOut():
+			gr.openRematch(reason)
+		}
+		if gr.state.Finished {
+			final = proto.Clone(gr.state).(*pb.GameState)
+			pls = append(pls, gr.players...)
+		}
+		// El historial y la puntuación son solo de partidas de dos
+		duel := len(gr.seats) == 2
+		m := store.Match{
+			ID:        gr.matchID(),
+			RoomCode:  gr.roomCode,
+			Score1:    gr.state.Score1,
+			Score2:    gr.state.Score2,
+			StartedAt: gr.startedAt,
+			Duration:  gr.srv.now().Sub(gr.startedAt),
+			EndReason: reason,
+		}
+		if duel {
+			m.Player1 = store.MatchPlayer{ID: gr.ids[0].ID, Name: gr.ids[0].Name}
+			m.Player2 = store.MatchPlayer{ID: gr.ids[1].ID, Name: gr.ids[1].Name}
+			// Solo puntúa por el marcador la partida jugada hasta el final;
+			// si alguien se fue o lo expulsaron, pierde él
+			switch {
+			case gr.playedOut():
+			case len(gr.players) == 1:
+				m.Forfeit = 2 - gr.seatOf(gr.players[0])
+			default:
+				m.Unrated = true
+			}
+		}
+		var rep *pb.Replay
+		if gr.rec != nil {
+			rep = gr.rec.Finish(gr.state, reason)
+		}
+		gr.mu.Unlock()
+		close(gr.done)
+
+		if rep != nil {
+			path := filepath.Join(gr.srv.cfg.ReplayDir, m.ID+replay.Ext)
+			if err := replay.WriteFile(path, rep); err != nil {
+				log.Printf("No se pudo guardar la repetición %s: %v", m.ID, err)
+			}
+		}
+
+		if !duel {
+			log.Printf("Sala %s terminada: %s", gr.roomCode, reason)
+		} else if err := gr.srv.store.RecordMatch(m); err != nil {
+			log.Printf("No se pudo guardar la partida %s: %v", m.ID, err)
+		}
+
+		gr.srv.roomsMu.Lock()
+		delete(gr.srv.rooms, gr.roomCode)
+		gr.srv.roomsMu.Unlock()
+
+		// El último estado se envía ya con el historial guardado, para
+		// que una revancha parta de las puntuaciones nuevas
+		if final != nil {
+			gr.send(pls, final)
+		}
+		if gr.tmatch != nil {
+			gr.tmatch.lt.report(gr.srv, gr.tmatch.id, winner, m.Score1, m.Score2)
+		}
+	})
+}
 
-	// Mapa para recuperar la sala de un stream
-	streamToRoom   = make(map[pb.PingPong_PlayServer]*GameRoom)
-	streamToRoomMu sync.Mutex
+// adminKick es el motivo de las expulsiones pedidas por el administrador.
+const adminKick = "expulsado por el administrador"
+
+// kick expulsa al jugador del asiento indicado: de "1" a "2" en un duelo
+// y de "1" a "4" en una arena.
+func (gr *GameRoom) kick(playerID string) bool {
+	gr.mu.Lock()
+	defer gr.mu.Unlock()
+
+	for i := range gr.seats {
+		if fmt.Sprintf("%d", i+1) == playerID {
+			return gr.kickSeat(i, adminKick)
+		}
+	}
+	return false
+}
+
+// kickSeat saca de la sala al asiento i, si sigue en ella, y le avisa por
+// su canal de kicks con el motivo dado. Requiere gr.mu.
+func (gr *GameRoom) kickSeat(i int, reason string) bool {
+	for idx, p := range gr.players {
+		if p == gr.seats[i] {
+			gr.players = append(gr.players[:idx], gr.players[idx+1:]...)
+			gr.kickReasons[i] = reason
+			close(gr.kicks[i])
+			return true
+		}
+	}
+	return false
+}
+
+// Motivos con los que termina una sala en la que falta alguien: sin rival
+// en una partida de dos, o sin uno de los jugadores de una arena.
+const (
+	opponentLeft = "El oponente abandonó la partida"
+	playerLeft   = "Un jugador abandonó la partida"
 )
 
 // run envía el estado a ambos jugadores ~60 veces por segundo.
 func (gr *GameRoom) run() {
-	ticker := time.NewTicker(16 * time.Millisecond)
+	ticker := gr.srv.clock.NewTicker(time.Duration(gr.rules.TickMs) * time.Millisecond)
 	defer ticker.Stop()
 
-	// --- Resolución y tamaños en píxeles (constantes sin tipar) ---
-	const (
-		screenW    = 800.0
-		screenH    = 600.0
-		paddleW_px = 10.0
-		paddleH_px = 80.0
-		ballRad_px = 8.0
-	)
-
-	// --- Variables float32 para normalizaciones [0,1] ---
-	padHalfWidth := float32(paddleW_px / (2 * screenW))             // mitad de ancho de pala
-	padHalfHeight := float32((paddleH_px/2 + ballRad_px) / screenH) // radio incluido
-	ballRadX := float32(ballRad_px / screenW)                       // radio bola en X
-	ballRadY := float32(ballRad_px / screenH)                       // radio bola en Y
-	topLimit := float32(1) - ballRadY                               // límite superior
-
-	for range ticker.C {
-		gr.mu.Lock()
-		if len(gr.players) < 2 {
-			gr.mu.Unlock()
+	for {
+		select {
+		case <-ticker.C():
+		case <-gr.done:
 			return
 		}
+		if reason := gr.tick(); reason != "" {
+			gr.finish(reason)
+			return
+		}
+	}
+}
 
-		// 1) Mover la bola
-		gr.state.Ball.X += gr.velX
-		gr.state.Ball.Y += gr.velY
+// tick simula un tick y envía el estado a los jugadores. Devuelve el motivo
+// si la partida ha terminado: sin simular si falta algún jugador (con el
+// motivo de la expulsión si fue por trampas), o tras enviar el tick en que
+// alguien llega a Config.PointsToWin.
+func (gr *GameRoom) tick() (endReason string) {
+	gr.mu.Lock()
+	if len(gr.players) < len(gr.seats) {
+		cheated := gr.cheated
+		gr.mu.Unlock()
+		if cheated != "" {
+			return cheated
+		}
+		if len(gr.seats) > 2 {
+			return playerLeft
+		}
+		return opponentLeft
+	}
 
-		// 2) Rebote en techo/suelo
-		if gr.state.Ball.Y <= ballRadY || gr.state.Ball.Y >= topLimit {
-			gr.velY = -gr.velY
+	// 1) Simular un tick: bola, rebotes, palas y puntuación. En pausa solo
+	// se envía el estado, y el tick no cuenta para la repetición
+	if !gr.updatePause(gr.srv.now()) {
+		sim.Step(gr.state, &gr.vel, gr.rules)
+		if gr.rec != nil {
+			gr.rec.EndTick(gr.state, gr.vel)
 		}
+	}
+
+	// 2) Clonar estado y lista de jugadores
+	st := proto.Clone(gr.state).(*pb.GameState)
+	pls := append([]pb.PingPong_PlayServer(nil), gr.players...)
+	gr.mu.Unlock()
 
-		// 3) Colisión pala izquierda (Paddle1.X es el centro)
-		if gr.velX < 0 {
-			leftEdge := gr.state.Paddle1.X + padHalfWidth
-			dy := gr.state.Ball.Y - gr.state.Paddle1.Y
-			if gr.state.Ball.X-ballRadX <= leftEdge && (dy < padHalfHeight && -dy < padHalfHeight) {
-				// reposiciona justo fuera de la pala
-				gr.state.Ball.X = leftEdge + ballRadX
-				gr.velX = -gr.velX
+	// 3) Enviar a cada jugador
+	gr.send(pls, st)
+
+	// La práctica no termina por puntos
+	if p := gr.srv.cfg.PointsToWin; p > 0 && !gr.rules.Wall {
+		// En los equipos cuenta el marcador de cada lado, como en el clásico
+		for _, pad := range st.Paddles {
+			if pad.Score >= p && !sim.Teams(st) {
+				return fmt.Sprintf("Gana %s con %d puntos", pad.Name, pad.Score)
 			}
 		}
+		switch {
+		case st.Score1 >= p:
+			return fmt.Sprintf("Gana %s %d-%d", st.Name1, st.Score1, st.Score2)
+		case st.Score2 >= p:
+			return fmt.Sprintf("Gana %s %d-%d", st.Name2, st.Score2, st.Score1)
+		}
+	}
+	return ""
+}
 
-		// 4) Colisión pala derecha
-		if gr.velX > 0 {
-			rightEdge := gr.state.Paddle2.X - padHalfWidth
-			dy := gr.state.Ball.Y - gr.state.Paddle2.Y
-			if gr.state.Ball.X+ballRadX >= rightEdge && (dy < padHalfHeight && -dy < padHalfHeight) {
-				gr.state.Ball.X = rightEdge - ballRadX
-				gr.velX = -gr.velX
-			}
+// send envía st a los jugadores indicados, cada uno con su player_id.
+func (gr *GameRoom) send(pls []pb.PingPong_PlayServer, st *pb.GameState) {
+	gr.sendMu.Lock()
+	defer gr.sendMu.Unlock()
+	for _, p := range pls {
+		msg := proto.Clone(st).(*pb.GameState)
+		if gr.modeSent {
+			msg.Mode = nil
+		}
+		msg.PlayerId = fmt.Sprintf("%d", gr.seatOf(p)+1)
+		if err := p.Send(msg); err != nil {
+			log.Printf("Error enviando estado al jugador %s: %v", msg.PlayerId, err)
 		}
+	}
+	gr.modeSent = true
+}
 
-		// 5) Puntuación y reinicio
-		if gr.state.Ball.X < 0 {
-			gr.state.Score2++
-			gr.state.Ball.X, gr.state.Ball.Y = 0.5, 0.5
-		} else if gr.state.Ball.X > 1 {
-			gr.state.Score1++
-			gr.state.Ball.X, gr.state.Ball.Y = 0.5, 0.5
+// seatOf devuelve el asiento (player_id-1) del stream, o -1. seats no
+// cambia tras crear la sala, así que no requiere gr.mu.
+func (gr *GameRoom) seatOf(stream pb.PingPong_PlayServer) int {
+	for i, p := range gr.seats {
+		if p == stream {
+			return i
 		}
+	}
+	return -1
+}
 
-		// 6) Clonar estado y lista de jugadores
-		st := gr.state
-		pls := append([]pb.PingPong_PlayServer(nil), gr.players...)
-		gr.mu.Unlock()
+// leave quita el stream de los jugadores de la sala.
+func (gr *GameRoom) leave(stream pb.PingPong_PlayServer) {
+	gr.mu.Lock()
+	defer gr.mu.Unlock()
+	for idx, p := range gr.players {
+		if p == stream {
+			gr.players = append(gr.players[:idx], gr.players[idx+1:]...)
+			return
+		}
+	}
+}
 
-		// 7) Enviar a cada jugador
-		for i, p := range pls {
-			msg := &pb.GameState{
-				RoomCode: st.RoomCode,
-				Ball:     &pb.Vector{X: st.Ball.X, Y: st.Ball.Y},
-				Paddle1:  &pb.Vector{X: st.Paddle1.X, Y: st.Paddle1.Y},
-				Paddle2:  &pb.Vector{X: st.Paddle2.X, Y: st.Paddle2.Y},
-				Score1:   st.Score1,
-				Score2:   st.Score2,
-				PlayerId: fmt.Sprintf("%d", i+1),
-			}
-			if err := p.Send(msg); err != nil {
-				log.Printf("Error enviando estado al jugador %d: %v", i+1, err)
-			}
+// Step simula n ticks de una sala creada con Config.ManualTicks y devuelve
+// el estado resultante. Si la partida termina (sin rival o por puntos) la
+// cierra y devuelve Aborted con el motivo.
+func (s *Server) Step(roomCode string, n int) (*pb.GameState, error) {
+	if !s.cfg.ManualTicks {
+		return nil, status.Error(codes.FailedPrecondition, "las salas avanzan solas")
+	}
+	room, err := s.lookupRoom(roomCode)
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < n; i++ {
+		if reason := room.tick(); reason != "" {
+			room.finish(reason)
+			return nil, status.Error(codes.Aborted, reason)
 		}
 	}
+	room.mu.Lock()
+	defer room.mu.Unlock()
+	return proto.Clone(room.state).(*pb.GameState), nil
 }
 
-// handleAction mueve las paletas según la acción recibida.
+// handleAction aplica la acción recibida: pausas y dirección de la pala.
 func (gr *GameRoom) handleAction(a *pb.GameAction) {
 	gr.mu.Lock()
 	defer gr.mu.Unlock()
@@ -130,138 +345,126 @@ func (gr *GameRoom) handleAction(a *pb.GameAction) {
 	if gr.state == nil {
 		return
 	}
-	const delta = 0.02
 
-	switch a.PlayerId {
-	case "1":
-		switch a.Move {
-		case "UP":
-			gr.state.Paddle1.Y -= delta
-		case "DOWN":
-			gr.state.Paddle1.Y += delta
-			// case "NONE": no hacemos nada
+	n, err := strconv.Atoi(a.PlayerId)
+	if err != nil || n < 1 || n > len(gr.seats) {
+		return
+	}
+	player := int32(n)
+	// Las pausas, por jugador, solo existen en las partidas de dos
+	switch a.Move {
+	case movePause:
+		if len(gr.seats) == 2 {
+			gr.pause(player, gr.srv.now())
 		}
-	case "2":
-		switch a.Move {
-		case "UP":
-			gr.state.Paddle2.Y -= delta
-		case "DOWN":
-			gr.state.Paddle2.Y += delta
-			// case "NONE": no hacemos nada
+		return
+	case moveResume:
+		if len(gr.seats) == 2 {
+			gr.resume(player, gr.srv.now())
 		}
+		return
 	}
-
-	// Limitar dentro de [0,1]
-	if gr.state.Paddle1.Y < 0 {
-		gr.state.Paddle1.Y = 0
-	} else if gr.state.Paddle1.Y > 1 {
-		gr.state.Paddle1.Y = 1
-	}
-	if gr.state.Paddle2.Y < 0 {
-		gr.state.Paddle2.Y = 0
-	} else if gr.state.Paddle2.Y > 1 {
-		gr.state.Paddle2.Y = 1
+	// La acción solo cambia la dirección que mantiene el jugador; la pala
+	// se mueve en cada tick, también si se anota durante una pausa
+	dir := sim.Move(a.Move)
+	if sim.SetInput(gr.state, player, dir) && gr.rec != nil {
+		gr.rec.Input(player, dir)
 	}
 }
 
-type server struct{ pb.UnimplementedPingPongServer }
-
-// Play implementa emparejamiento automático por parejas.
-func (s *server) Play(stream pb.PingPong_PlayServer) error {
+// Play implementa emparejamiento automático: reúne a los jugadores que pide
+// el modo elegido (Config.Modes), por parejas en los duelos.
+func (s *Server) Play(stream pb.PingPong_PlayServer) error {
 	// 1) Primer recv para disparar emparejamiento
-	if _, err := stream.Recv(); err != nil {
+	first, err := stream.Recv()
+	if err != nil {
 		return err
 	}
+	if first.TournamentId != "" {
+		return s.playTournament(stream, first.TournamentId)
+	}
+	mode := first.Mode
+	if mode == "" {
+		mode = modeDuel
+	}
+	m, ok := s.cfg.Modes[mode]
+	if !ok {
+		return status.Errorf(codes.InvalidArgument, "modo de juego desconocido: %q", first.Mode)
+	}
+	need := m.Players
+	if first.MapName != "" {
+		if m.Layout != sim.LayoutDuel {
+			return status.Error(codes.InvalidArgument, "los mapas son solo para duelos")
+		}
+		if s.cfg.Maps[first.MapName] == nil {
+			return status.Errorf(codes.InvalidArgument, "mapa desconocido: %q", first.MapName)
+		}
+	}
 
 	var room *GameRoom
 
-	// 2) Emparejamiento en parejas
-	waitingQueueMu.Lock()
-	if len(waitingQueue) == 0 {
-		// Primer jugador se queda en cola
-		waitingQueue = append(waitingQueue, stream)
-		waitingQueueMu.Unlock()
-		// Espera hasta que sea emparejado
-		for {
-			time.Sleep(50 * time.Millisecond)
-			waitingQueueMu.Lock()
-			found := false
-			for _, p := range waitingQueue {
-				if p == stream {
-					found = true
-					break
-				}
-			}
-			waitingQueueMu.Unlock()
-			if !found {
-				break
+	// 2) Emparejamiento por nivel: se busca rival dentro de una ventana de
+	// puntuación que se ensancha con la espera
+	id, _ := identityFrom(stream.Context())
+	me := &queueEntry{
+		stream:   stream,
+		mode:     mode,
+		mapName:  first.MapName,
+		rating:   s.playerRating(id),
+		joinedAt: s.now(),
+		dequeued: make(chan struct{}),
+	}
+
+	s.waitingQueueMu.Lock()
+	s.waitingQueue = append(s.waitingQueue, me)
+	for {
+		if s.queueIndex(me) < 0 {
+			// Otro jugador nos emparejó (me.room), o vaciaron la cola
+			room = me.room
+			s.waitingQueueMu.Unlock()
+			break
+		}
+		if found := s.findOpponents(me, s.now(), need); found != nil {
+			entries := []*queueEntry{me}
+			for _, i := range found {
+				entries = append(entries, s.waitingQueue[i])
 			}
+			room = s.createRoom(entries...)
+			s.waitingQueueMu.Unlock()
+			s.startRoom(room)
+			break
 		}
-	} else {
-		// Segundo jugador empareja con el primero
-		peer := waitingQueue[0]
-		waitingQueue = waitingQueue[1:]
-		waitingQueueMu.Unlock()
-
-		// Crear sala nueva
-		room = &GameRoom{
-			velX:     0.008,
-			velY:     0.012,
-			roomCode: fmt.Sprintf("%04X", time.Now().UnixNano()%0x10000),
-		}
-		// Inicializar estado
-		room.state = &pb.GameState{
-			RoomCode: room.roomCode,
-			Ball:     &pb.Vector{X: 0.5, Y: 0.5},
-			Paddle1:  &pb.Vector{X: 0.1, Y: 0.5},
-			Paddle2:  &pb.Vector{X: 0.9, Y: 0.5},
-			Score1:   0,
-			Score2:   0,
-		}
-		// Añadir ambos
-		room.players = []pb.PingPong_PlayServer{peer, stream}
-
-		// Mapear streams a sala
-		streamToRoomMu.Lock()
-		streamToRoom[peer] = room
-		streamToRoom[stream] = room
-		streamToRoomMu.Unlock()
-
-		// Enviar estado inicial sincronizado
-		for i, p := range room.players {
-			msg := &pb.GameState{
-				RoomCode: room.roomCode,
-				Ball:     &pb.Vector{X: 0.5, Y: 0.5},
-				Paddle1:  &pb.Vector{X: 0.1, Y: 0.5},
-				Paddle2:  &pb.Vector{X: 0.9, Y: 0.5},
-				Score1:   0,
-				Score2:   0,
-				PlayerId: fmt.Sprintf("%d", i+1),
+		s.waitingQueueMu.Unlock()
+
+		select {
+		case <-s.clock.After(s.cfg.MatchPoll):
+		case <-me.dequeued:
+		case <-stream.Context().Done():
+			s.waitingQueueMu.Lock()
+			if i := s.queueIndex(me); i >= 0 {
+				s.removeFromQueue(i)
+				s.waitingQueueMu.Unlock()
+				return stream.Context().Err()
 			}
-			p.Send(msg)
+			s.waitingQueueMu.Unlock()
+			// Ya estaba emparejado: el bucle de acciones lo sacará de la sala
 		}
-
-		// Arrancar físicas
-		go room.run()
+		s.waitingQueueMu.Lock()
 	}
 
-	// 3) Primer jugador recupera su sala
-	streamToRoomMu.Lock()
-	room = streamToRoom[stream]
-	streamToRoomMu.Unlock()
-
-	// 4) Determinar índice fijo
-	room.mu.Lock()
-	myIndex := -1
-	for i, p := range room.players {
-		if p == stream {
-			myIndex = i
-			break
-		}
+	// 3) Sin sala es que vaciaron la cola. La sala puede haber terminado
+	// ya si el rival se fue enseguida; el bucle de acciones lo verá en done
+	if room == nil {
+		return status.Error(codes.Unavailable, "la cola de emparejamiento fue vaciada")
 	}
-	room.mu.Unlock()
 
-	// 5) Canal para acciones entrantes
+	// 4) Canal para acciones entrantes y bucle de la partida
+	return s.playRoom(room, stream, recvActions(stream))
+}
+
+// recvActions lee las acciones del stream en un canal que se cierra cuando
+// el jugador se desconecta.
+func recvActions(stream pb.PingPong_PlayServer) <-chan *pb.GameAction {
 	actions := make(chan *pb.GameAction)
 	go func() {
 		defer close(actions)
@@ -270,39 +473,183 @@ func (s *server) Play(stream pb.PingPong_PlayServer) error {
 			if err != nil {
 				return
 			}
-			actions <- a
+			select {
+			case actions <- a:
+			case <-stream.Context().Done():
+				return
+			}
 		}
 	}()
+	return actions
+}
 
-	// 6) Loop principal: procesar acciones
+// errMatchOver es lo que devuelve playRoom al terminar una partida de
+// torneo con el jugador aún conectado.
+var errMatchOver = errors.New("partida terminada")
+
+// playRoom procesa las acciones del jugador en la sala (y en las de las
+// revanchas o juegos siguientes de la serie) hasta que termina. Devuelve el
+// error con el que termina Play, o errMatchOver en una partida de torneo.
+func (s *Server) playRoom(room *GameRoom, stream pb.PingPong_PlayServer, actions <-chan *pb.GameAction) error {
+	// Índice fijo por el asiento, que no cambia aunque el jugador ya haya
+	// salido de players
+	myIndex := room.seatOf(stream)
+	guard := newInputGuard(s.cfg.MaxActionRate, s.now())
 	for {
-		action, ok := <-actions
-		if !ok {
-			// Si se desconecta, quitamos del room
-			room.mu.Lock()
-			// eliminar stream de room.players
-			for idx, p := range room.players {
-				if p == stream {
-					room.players = append(room.players[:idx], room.players[idx+1:]...)
-					break
+		select {
+		case action, ok := <-actions:
+			if !ok {
+				// Si se desconecta, quitamos del room
+				room.leave(stream)
+				return nil
+			}
+			if ok, err := s.screen(room, myIndex, guard, action); !ok {
+				if err != nil {
+					return err
 				}
+				continue
 			}
+			action.PlayerId = fmt.Sprintf("%d", myIndex+1)
+			room.handleAction(action)
+
+		case <-room.kicks[myIndex]:
+			room.mu.Lock()
+			reason := room.kickReasons[myIndex]
 			room.mu.Unlock()
-			return nil
+			return status.Error(codes.Aborted, reason)
+
+		case <-room.done:
+			if room.tmatch != nil {
+				return errMatchOver
+			}
+			// Terminada la partida se espera a la revancha; si la hay, el
+			// bucle sigue en la sala nueva con el asiento que toque
+			next, err := s.awaitRematch(room, stream, myIndex, actions, guard)
+			if next == nil {
+				return err
+			}
+			room, myIndex = next, next.seatOf(stream)
 		}
-		action.PlayerId = fmt.Sprintf("%d", myIndex+1)
-		room.handleAction(action)
 	}
 }
 
 func main() {
-	lis, err := net.Listen("tcp", ":50051")
+	addr := flag.String("addr", ":50051", "dirección del servicio de juego")
+	adminAddr := flag.String("admin-addr", "127.0.0.1:50052", "dirección propia para el servicio Admin (vacío = sin Admin)")
+	authSecret := flag.String("auth-secret", os.Getenv("PINGPONG_AUTH_SECRET"), "secreto para firmar los tokens de jugador")
+	adminSecret := flag.String("admin-secret", os.Getenv("PINGPONG_ADMIN_SECRET"), "clave que exige el servicio Admin")
+	var tlsCfg tlsutil.Config
+	flag.StringVar(&tlsCfg.CertFile, "tls-cert", "", "certificado PEM del servidor (activa TLS)")
+	flag.StringVar(&tlsCfg.KeyFile, "tls-key", "", "clave PEM del servidor")
+	flag.StringVar(&tlsCfg.CAFile, "tls-client-ca", "", "CA de clientes; si se indica se exige TLS mutuo")
+	storePath := flag.String("store", "juegocen.jsonl", "fichero de perfiles e historial (vacío = solo en memoria)")
+	replayDir := flag.String("replays", "", "directorio donde grabar las partidas (vacío = no grabar)")
+	pointsToWin := flag.Int("points", 11, "puntos para ganar una partida (0 = sin límite)")
+	series := flag.Int("series", 1, "jugar series al mejor de N juegos (N impar)")
+	powerUps := flag.Bool("powerups", false, "activar los potenciadores en los duelos")
+	balls := flag.Int("balls", 3, "bolas en las partidas del modo MULTI")
+	mapsDir := flag.String("maps", "", "directorio con los mapas *.json que se pueden elegir (vacío = ninguno)")
+	walkover := flag.Duration("walkover", 2*time.Minute, "espera en un torneo al rival que no se presenta")
+	maxRate := flag.Int("max-action-rate", 120, "acciones por segundo que se aceptan de cada jugador")
+	maxViolations := flag.Int("max-violations", 20, "infracciones con las que se expulsa a un jugador")
+	flag.Parse()
+
+	if *series < 1 || *series%2 == 0 {
+		log.Fatalf("-series debe ser un número impar positivo (%d)", *series)
+	}
+	if *series > 1 && *pointsToWin <= 0 {
+		log.Fatal("-series necesita -points")
+	}
+	if *balls < 2 || *balls > sim.MaxBalls {
+		log.Fatalf("-balls debe estar entre 2 y %d (%d)", sim.MaxBalls, *balls)
+	}
+	modes := sim.DefaultModes()
+	multi := modes[modeMulti]
+	multi.Balls = *balls
+	modes[modeMulti] = multi
+	cfg := Config{
+		AuthSecret:   *authSecret,
+		AdminSecret:  *adminSecret,
+		ReplayDir:    *replayDir,
+		PointsToWin:  int32(*pointsToWin),
+		SeriesLength: int32(*series),
+		PowerUps:     *powerUps,
+		Modes:        modes,
+
+		WalkoverTimeout: *walkover,
+		MaxActionRate:   *maxRate,
+		MaxViolations:   int32(*maxViolations),
+	}
+
+	if cfg.ReplayDir != "" {
+		if err := os.MkdirAll(cfg.ReplayDir, 0o755); err != nil {
+			log.Fatalf("replays: %v", err)
+		}
+		log.Printf("Grabando repeticiones en %s", cfg.ReplayDir)
+	}
+
+	if *mapsDir != "" {
+		maps, err := sim.LoadMaps(*mapsDir)
+		if err != nil {
+			log.Fatalf("mapas: %v", err)
+		}
+		cfg.Maps = maps
+		log.Printf("%d mapas cargados de %s", len(maps), *mapsDir)
+	}
+
+	if *storePath != "" {
+		fs, err := store.OpenFile(*storePath)
+		if err != nil {
+			log.Fatalf("store: %v", err)
+		}
+		defer fs.Close()
+		cfg.Store = fs
+		log.Printf("Historial de partidas en %s", *storePath)
+	}
+
+	srv := NewServer(cfg)
+
+	// Opciones comunes a los servidores de juego y de administración
+	var opts []grpc.ServerOption
+	if tlsCfg.ServerEnabled() {
+		creds, err := tlsCfg.ServerCredentials()
+		if err != nil {
+			log.Fatalf("TLS: %v", err)
+		}
+		opts = append(opts, grpc.Creds(creds))
+		if tlsCfg.CAFile != "" {
+			log.Println("TLS mutuo activado")
+		} else {
+			log.Println("TLS activado")
+		}
+	}
+
+	lis, err := net.Listen("tcp", *addr)
 	if err != nil {
 		log.Fatalf("listen failed: %v", err)
 	}
-	grpcServer := grpc.NewServer()
-	pb.RegisterPingPongServer(grpcServer, &server{})
+	grpcServer := grpc.NewServer(append(opts, grpc.StreamInterceptor(srv.StreamInterceptor()))...)
+	srv.Register(grpcServer)
 	reflection.Register(grpcServer)
-	log.Println("Servidor gRPC corriendo en :50051")
+
+	// Admin va siempre en su propio puerto (por defecto solo en loopback) y
+	// exige clave o certificado de cliente
+	switch {
+	case *adminAddr == "":
+	case *adminSecret == "" && tlsCfg.CAFile == "":
+		log.Println("Aviso: sin -admin-secret ni -tls-client-ca el servicio Admin queda desactivado")
+	default:
+		adminLis, err := net.Listen("tcp", *adminAddr)
+		if err != nil {
+			log.Fatalf("admin listen failed: %v", err)
+		}
+		adminGrpc := grpc.NewServer(append(opts, grpc.UnaryInterceptor(srv.AdminInterceptor()))...)
+		srv.RegisterAdmin(adminGrpc)
+		reflection.Register(adminGrpc)
+		log.Printf("Servicio Admin corriendo en %s", *adminAddr)
+		go adminGrpc.Serve(adminLis)
+	}
+
+	log.Printf("Servidor gRPC corriendo en %s", *addr)
 	grpcServer.Serve(lis)
 }
@@ -0,0 +1,261 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	pb "JuegoCeN/proto"
+	"JuegoCeN/rating"
+	"JuegoCeN/replay"
+	"JuegoCeN/sim"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// Modos de juego de GameAction.mode a los que se refiere el servidor; el
+// resto solo están en el registro (Config.Modes).
+const (
+	modeDuel     = "DUEL"
+	modeFour     = "FOUR"
+	modeTeams    = "TEAMS"
+	modeMulti    = "MULTI"
+	modePractice = "PRACTICE"
+)
+
+// queueEntry es un jugador esperando rival en Server.waitingQueue.
+type queueEntry struct {
+	stream   pb.PingPong_PlayServer
+	mode     string
+	mapName  string
+	rating   float64
+	joinedAt time.Time
+	room     *GameRoom     // sala asignada al emparejarlo; requiere waitingQueueMu
+	dequeued chan struct{} // se cierra al sacarlo de la cola para despertarlo
+}
+
+// playerRating devuelve la puntuación guardada del jugador o la inicial.
+func (s *Server) playerRating(id Identity) float64 {
+	if p, err := s.store.Profile(id.ID); err == nil {
+		return p.Rating
+	}
+	return rating.Initial
+}
+
+// queueIndex devuelve la posición de e en la cola o -1; requiere waitingQueueMu.
+func (s *Server) queueIndex(e *queueEntry) int {
+	for i, q := range s.waitingQueue {
+		if q == e {
+			return i
+		}
+	}
+	return -1
+}
+
+// removeFromQueue requiere waitingQueueMu.
+func (s *Server) removeFromQueue(i int) {
+	s.waitingQueue = append(s.waitingQueue[:i], s.waitingQueue[i+1:]...)
+}
+
+// findOpponents devuelve los índices de los n-1 rivales en cola del mismo
+// modo y mapa con la puntuación más cercana a la de me que entren en la
+// ventana, o nil si no hay bastantes. La ventana depende de quién de cada
+// pareja lleve más tiempo esperando. Requiere waitingQueueMu.
+func (s *Server) findOpponents(me *queueEntry, now time.Time, n int) []int {
+	if n == 1 {
+		return []int{} // quien juega solo no espera a nadie
+	}
+	var found []int
+	diffs := map[int]float64{}
+	for i, q := range s.waitingQueue {
+		if q == me || q.mode != me.mode || q.mapName != me.mapName {
+			continue
+		}
+		oldest := q.joinedAt
+		if me.joinedAt.Before(oldest) {
+			oldest = me.joinedAt
+		}
+		diff := math.Abs(q.rating - me.rating)
+		if diff <= rating.Window(now.Sub(oldest)) {
+			found = append(found, i)
+			diffs[i] = diff
+		}
+	}
+	if len(found) < n-1 {
+		return nil
+	}
+	sort.SliceStable(found, func(a, b int) bool { return diffs[found[a]] < diffs[found[b]] })
+	return found[:n-1]
+}
+
+// createRoom saca de la cola a los jugadores indicados y les asigna una
+// sala nueva, sentados por orden de llegada: el que más tiempo llevaba
+// esperando ocupa el asiento 1. En el modo por equipos se sientan de forma
+// que los equipos queden igualados (balanceTeams). Requiere
+// waitingQueueMu, con el que se asigna room a todas las entradas para que
+// quien espera encuentre ya su sala al ver que ha salido de la cola. La
+// sala queda registrada para la administración.
+func (s *Server) createRoom(entries ...*queueEntry) *GameRoom {
+	// A igual hora de llegada decide el puesto en la cola
+	sort.SliceStable(entries, func(a, b int) bool {
+		ea, eb := entries[a], entries[b]
+		if !ea.joinedAt.Equal(eb.joinedAt) {
+			return ea.joinedAt.Before(eb.joinedAt)
+		}
+		return s.queueIndex(ea) < s.queueIndex(eb)
+	})
+	mode := s.cfg.Modes[entries[0].mode]
+	if mode.Layout == sim.LayoutTeams {
+		entries = balanceTeams(entries)
+	}
+	pls := make([]pb.PingPong_PlayServer, len(entries))
+	ratings := make([]float64, len(entries))
+	for i, e := range entries {
+		if j := s.queueIndex(e); j >= 0 {
+			s.removeFromQueue(j)
+		}
+		pls[i], ratings[i] = e.stream, e.rating
+	}
+
+	room := s.newRoom(mode, entries[0].mapName, pls, ratings)
+
+	// Mapear streams a sala
+	for _, e := range entries {
+		e.room = room
+		close(e.dequeued)
+	}
+	return room
+}
+
+// balanceTeams sienta a los cuatro jugadores de una sala por equipos: el
+// mejor y el peor juntos en los asientos 1 y 2 (izquierda) contra los otros
+// dos en el 3 y el 4. Dentro de cada equipo se respeta el orden de llegada.
+func balanceTeams(entries []*queueEntry) []*queueEntry {
+	byRating := append([]*queueEntry(nil), entries...)
+	sort.SliceStable(byRating, func(a, b int) bool { return byRating[a].rating > byRating[b].rating })
+	left := map[*queueEntry]bool{byRating[0]: true, byRating[3]: true}
+	var out, right []*queueEntry
+	for _, e := range entries {
+		if left[e] {
+			out = append(out, e)
+		} else {
+			right = append(right, e)
+		}
+	}
+	return append(out, right...)
+}
+
+// newRoom crea una sala del modo dado con un asiento por stream, en orden,
+// con las puntuaciones dadas, y la registra para la administración. Los
+// modos con disposición de duelo son el juego clásico, o la práctica si
+// solo hay un jugador; los demás son arenas. mapName es el mapa de
+// Config.Maps en que se juega un duelo ("" = campo vacío). Requiere
+// waitingQueueMu, que es el que reparte los códigos de sala.
+func (s *Server) newRoom(mode sim.Mode, mapName string, pls []pb.PingPong_PlayServer, ratings []float64) *GameRoom {
+	now := s.now()
+	room := &GameRoom{
+		srv:       s,
+		vel:       mode.Rules.InitialVelocity(),
+		rules:     mode.Rules,
+		done:      make(chan struct{}),
+		startedAt: now,
+		mode:      mode,
+	}
+	room.roomCode = s.freeRoomCode(now)
+
+	// Identidades puestas por el interceptor de autenticación
+	for _, p := range pls {
+		id, _ := identityFrom(p.Context())
+		room.ids = append(room.ids, id)
+		room.kicks = append(room.kicks, make(chan struct{}))
+		room.kickReasons = append(room.kickReasons, "")
+		room.violations = append(room.violations, 0)
+	}
+
+	// Inicializar estado
+	room.state = mode.NewState(room.roomCode)
+	if m := s.cfg.Maps[mapName]; m != nil && mode.Layout == sim.LayoutDuel {
+		room.state.Map = proto.Clone(m).(*pb.FieldMap)
+	}
+	switch {
+	case mode.Layout == sim.LayoutTeams:
+		room.state.Name1 = room.ids[0].Name + " y " + room.ids[1].Name
+		room.state.Name2 = room.ids[2].Name + " y " + room.ids[3].Name
+		team1, team2 := (ratings[0]+ratings[1])/2, (ratings[2]+ratings[3])/2
+		room.state.Rating1, room.state.Rating2 = float32(team1), float32(team2)
+		room.state.WinProb1 = float32(rating.Expected(team1, team2))
+	case mode.Layout == sim.LayoutDuel && len(pls) == 2:
+		room.initDuel(ratings[0], ratings[1])
+	case mode.Layout == sim.LayoutDuel:
+		room.state.Name1, room.state.Name2 = room.ids[0].Name, practiceWall
+	}
+	for i, p := range room.state.Paddles {
+		p.Name, p.Rating = room.ids[i].Name, float32(ratings[i])
+	}
+
+	// Añadir a todos
+	room.players = append([]pb.PingPong_PlayServer(nil), pls...)
+	room.seats = append([]pb.PingPong_PlayServer(nil), pls...)
+
+	// Registrar la sala para la administración
+	s.roomsMu.Lock()
+	s.rooms[room.roomCode] = room
+	s.roomsMu.Unlock()
+	return room
+}
+
+// practiceWall es el nombre del rival en la práctica.
+const practiceWall = "Pared"
+
+// initDuel completa el estado de saque de una partida de dos, que es la
+// única que se graba y admite pausas, revancha y series.
+func (gr *GameRoom) initDuel(rating1, rating2 float64) {
+	cfg := gr.srv.cfg
+	id1, id2 := gr.ids[0], gr.ids[1]
+	gr.state.Name1 = id1.Name
+	gr.state.Name2 = id2.Name
+	gr.state.Rating1 = float32(rating1)
+	gr.state.Rating2 = float32(rating2)
+	gr.state.WinProb1 = float32(rating.Expected(rating1, rating2))
+	gr.state.PausesLeft1 = cfg.PauseBudget
+	gr.state.PausesLeft2 = cfg.PauseBudget
+	gr.state.SeriesLength = cfg.SeriesLength
+	gr.state.SeriesGame = 1
+	if cfg.PowerUps && gr.mode.Balls == 1 {
+		sim.EnablePowerUps(gr.state, uint64(gr.startedAt.UnixNano()))
+	}
+
+	if cfg.ReplayDir != "" {
+		gr.rec = replay.NewRecorder(gr.matchID(), gr.roomCode, id1.Name, id2.Name,
+			gr.startedAt, gr.rules, gr.state, gr.vel)
+	}
+}
+
+// freeRoomCode deriva un código de sala de now que no esté en uso. Como
+// las salas se crean con waitingQueueMu, dos salas no pueden elegir a la
+// vez el mismo código.
+func (s *Server) freeRoomCode(now time.Time) string {
+	s.roomsMu.Lock()
+	defer s.roomsMu.Unlock()
+	code := now.UnixNano() % 0x10000
+	for s.rooms[fmt.Sprintf("%04X", code)] != nil {
+		code = (code + 1) % 0x10000
+	}
+	return fmt.Sprintf("%04X", code)
+}
+
+// startRoom envía el estado inicial y arranca las físicas.
+func (s *Server) startRoom(room *GameRoom) {
+	// Enviar estado inicial sincronizado
+	room.mu.Lock()
+	st := proto.Clone(room.state).(*pb.GameState)
+	pls := append([]pb.PingPong_PlayServer(nil), room.players...)
+	room.mu.Unlock()
+	room.send(pls, st)
+
+	// Arrancar físicas, salvo que los ticks se den a mano con Step
+	if !s.cfg.ManualTicks {
+		go room.run()
+	}
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"log"
+	"time"
+)
+
+// Acciones de GameAction.move que no mueven la pala.
+const (
+	movePause  = "PAUSE"
+	moveResume = "RESUME"
+)
+
+// La pausa vive en los campos Paused* y Resume* de gr.state, de modo que se
+// difunde con el estado. Todas estas funciones requieren gr.mu.
+
+// pause detiene la partida si el jugador aún tiene pausas disponibles.
+func (gr *GameRoom) pause(player int32, now time.Time) {
+	st := gr.state
+	if st.Paused {
+		return
+	}
+	left := &st.PausesLeft1
+	if player == 2 {
+		left = &st.PausesLeft2
+	}
+	if *left <= 0 {
+		log.Printf("Sala %s: el jugador %d no tiene pausas disponibles", gr.roomCode, player)
+		return
+	}
+	*left--
+	st.Paused, st.PausedBy = true, player
+	st.ResumeReady1, st.ResumeReady2, st.ResumeInMs = false, false, 0
+	gr.pausedAt, gr.resumeAt = now, time.Time{}
+}
+
+// resume anota que el jugador quiere seguir; cuando lo quieren ambos
+// empieza la cuenta atrás.
+func (gr *GameRoom) resume(player int32, now time.Time) {
+	st := gr.state
+	if !st.Paused || !gr.resumeAt.IsZero() {
+		return
+	}
+	switch player {
+	case 1:
+		st.ResumeReady1 = true
+	case 2:
+		st.ResumeReady2 = true
+	}
+	if st.ResumeReady1 && st.ResumeReady2 {
+		gr.resumeAt = now.Add(gr.srv.cfg.ResumeCountdown)
+	}
+}
+
+// updatePause avanza la cuenta atrás y levanta la pausa al terminar.
+// Devuelve true si la partida sigue en pausa en este tick.
+func (gr *GameRoom) updatePause(now time.Time) bool {
+	st := gr.state
+	if !st.Paused {
+		return false
+	}
+	// Nadie puede retener la partida indefinidamente
+	if gr.resumeAt.IsZero() && now.Sub(gr.pausedAt) >= gr.srv.cfg.MaxPause {
+		st.ResumeReady1, st.ResumeReady2 = true, true
+		gr.resumeAt = now.Add(gr.srv.cfg.ResumeCountdown)
+	}
+	if gr.resumeAt.IsZero() {
+		return true
+	}
+	if left := gr.resumeAt.Sub(now); left > 0 {
+		st.ResumeInMs = int32((left + time.Millisecond - 1) / time.Millisecond)
+		return true
+	}
+	st.Paused, st.PausedBy = false, 0
+	st.ResumeReady1, st.ResumeReady2, st.ResumeInMs = false, false, 0
+	gr.pausedAt, gr.resumeAt = time.Time{}, time.Time{}
+	return false
+}
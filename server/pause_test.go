@@ -0,0 +1,134 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"JuegoCeN/clock"
+	pb "JuegoCeN/proto"
+	"JuegoCeN/sim"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// waitRoom espera a que el estado de la sala cumpla cond; sirve para saber
+// que el servidor ya procesó una acción.
+func waitRoom(t *testing.T, s *Server, code string, cond func(*pb.GameState) bool) *pb.GameState {
+	t.Helper()
+	room, err := s.lookupRoom(code)
+	if err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(waitFor)
+	for {
+		room.mu.Lock()
+		st := proto.Clone(room.state).(*pb.GameState)
+		room.mu.Unlock()
+		if cond(st) {
+			return st
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("la sala no llegó al estado esperado: %v", st)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// holding es la condición de waitRoom de que el jugador mantenga dir.
+func holding(player, dir int32) func(*pb.GameState) bool {
+	return func(st *pb.GameState) bool { return sim.Input(st, player) == dir }
+}
+
+func TestPauseAndResume(t *testing.T) {
+	clk := clock.NewManual(t0)
+	h := newHarness(t, Config{ManualTicks: true, Clock: clk})
+	a, b, first := h.pair()
+	code := first.RoomCode
+
+	before, _ := h.srv.Step(code, 10)
+	a.send(movePause)
+	waitRoom(t, h.srv, code, func(st *pb.GameState) bool { return st.Paused })
+
+	// En pausa no se mueven ni la bola ni las palas
+	a.send("UP")
+	a.send(moveResume)
+	waitRoom(t, h.srv, code, func(st *pb.GameState) bool { return st.ResumeReady1 })
+	st, _ := h.srv.Step(code, 30)
+	if st.Ball.X != before.Ball.X || st.Paddle1.Y != before.Paddle1.Y {
+		t.Fatalf("la partida avanzó en pausa: %v -> %v", before, st)
+	}
+	if !st.Paused || st.PausedBy != 1 || st.PausesLeft1 != 2 || st.PausesLeft2 != 3 {
+		t.Fatalf("estado de pausa = %v", st)
+	}
+	if st.ResumeInMs != 0 {
+		t.Fatal("la cuenta atrás empezó sin el visto bueno del rival")
+	}
+
+	// Con los dos de acuerdo empieza la cuenta atrás
+	b.send(moveResume)
+	waitRoom(t, h.srv, code, func(st *pb.GameState) bool { return st.ResumeReady2 })
+	st, _ = h.srv.Step(code, 1)
+	if !st.Paused || st.ResumeInMs != 3000 {
+		t.Fatalf("cuenta atrás = %d ms (pausa %v)", st.ResumeInMs, st.Paused)
+	}
+	clk.Advance(2500 * time.Millisecond)
+	if st, _ = h.srv.Step(code, 1); st.ResumeInMs != 500 {
+		t.Fatalf("cuenta atrás = %d ms", st.ResumeInMs)
+	}
+	clk.Advance(500 * time.Millisecond)
+	st, _ = h.srv.Step(code, 1)
+	if st.Paused || st.ResumeReady1 || st.ResumeInMs != 0 {
+		t.Fatalf("la pausa no terminó: %v", st)
+	}
+	if st.Ball.X == before.Ball.X {
+		t.Fatal("la bola no se movió al reanudar")
+	}
+
+	// Los clientes reciben la pausa en la difusión
+	a.waitState(func(st *pb.GameState) bool { return st.Paused && st.PausedBy == 1 })
+}
+
+func TestPauseBudget(t *testing.T) {
+	clk := clock.NewManual(t0)
+	h := newHarness(t, Config{ManualTicks: true, Clock: clk, PauseBudget: 1})
+	a, b, first := h.pair()
+	code := first.RoomCode
+
+	a.send(movePause)
+	a.send(moveResume)
+	waitRoom(t, h.srv, code, func(st *pb.GameState) bool { return st.ResumeReady1 })
+	b.send(moveResume)
+	waitRoom(t, h.srv, code, func(st *pb.GameState) bool { return st.ResumeReady1 && st.ResumeReady2 })
+	clk.Advance(3 * time.Second)
+	h.srv.Step(code, 1)
+
+	// Sin pausas restantes la petición se ignora (el movimiento que la sigue
+	// sí se aplica); el rival aún tiene la suya
+	a.send(movePause)
+	a.send("UP")
+	waitRoom(t, h.srv, code, holding(1, -1))
+	if st, _ := h.srv.Step(code, 1); st.Paused || st.Paddle1.Y >= first.Paddle1.Y ||
+		st.PausesLeft1 != 0 || st.PausesLeft2 != 1 {
+		t.Fatalf("estado = %v", st)
+	}
+}
+
+func TestPauseTimesOut(t *testing.T) {
+	clk := clock.NewManual(t0)
+	h := newHarness(t, Config{ManualTicks: true, Clock: clk, MaxPause: 10 * time.Second})
+	a, _, first := h.pair()
+	code := first.RoomCode
+
+	a.send(movePause)
+	waitRoom(t, h.srv, code, func(st *pb.GameState) bool { return st.Paused })
+
+	// Nadie acepta, pero pasado MaxPause la cuenta atrás empieza sola
+	clk.Advance(10 * time.Second)
+	if st, _ := h.srv.Step(code, 1); st.ResumeInMs != 3000 {
+		t.Fatalf("cuenta atrás = %d ms", st.ResumeInMs)
+	}
+	clk.Advance(3 * time.Second)
+	if st, _ := h.srv.Step(code, 1); st.Paused {
+		t.Fatal("la pausa no terminó")
+	}
+}
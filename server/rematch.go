@@ -0,0 +1,233 @@
+package main
+
+import (
+	"log"
+	"time"
+
+	pb "JuegoCeN/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+// Acciones de GameAction.move tras terminar la partida.
+const (
+	moveRematch = "REMATCH"
+	moveLeave   = "LEAVE"
+)
+
+// Motivos con los que se cierra el plazo de revancha sin sala nueva.
+const (
+	rematchDeclined = "El oponente no quiso la revancha"
+	rematchExpired  = "Se agotó el tiempo para la revancha"
+	seriesAbandoned = "El oponente abandonó la serie"
+)
+
+// rematch es el plazo de revancha de una sala terminada, o la espera hasta
+// el siguiente juego de una serie (auto). Lo protege el mutex de la sala;
+// las ofertas se difunden en los campos Rematch* del estado.
+type rematch struct {
+	deadline time.Time
+	auto     bool          // juego de una serie: empieza solo en deadline
+	accepted bool          // se está creando la sala nueva
+	done     chan struct{} // se cierra al resolverse
+	next     *GameRoom     // sala de la revancha, o nil
+	reason   string        // por qué no hubo revancha
+}
+
+// playedOut indica si alguien llegó a Config.PointsToWin: solo las
+// partidas jugadas hasta el final (no las abandonadas ni las que corta el
+// administrador) ofrecen revancha. Requiere gr.mu.
+func (gr *GameRoom) playedOut() bool {
+	p := gr.srv.cfg.PointsToWin
+	return p > 0 && (gr.state.Score1 >= p || gr.state.Score2 >= p)
+}
+
+// openRematch marca el estado como terminado, apunta el juego al ganador y
+// abre el plazo de revancha, o la espera al siguiente juego si la serie
+// sigue. Requiere gr.mu.
+func (gr *GameRoom) openRematch(reason string) {
+	st := gr.state
+	st.Finished, st.EndReason = true, reason
+	st.Winner = 1
+	st.SeriesWins1++
+	if st.Score2 > st.Score1 {
+		st.Winner = 2
+		st.SeriesWins1--
+		st.SeriesWins2++
+	}
+	rm := &rematch{done: make(chan struct{})}
+	need := st.SeriesLength/2 + 1
+	if st.SeriesWins1 < need && st.SeriesWins2 < need {
+		rm.auto = true
+		rm.deadline = gr.srv.now().Add(gr.srv.cfg.SeriesBreak)
+		st.NextGameInMs = int32(gr.srv.cfg.SeriesBreak / time.Millisecond)
+	} else {
+		rm.deadline = gr.srv.now().Add(gr.srv.cfg.RematchTimeout)
+		st.RematchInMs = int32(gr.srv.cfg.RematchTimeout / time.Millisecond)
+	}
+	gr.rematch = rm
+}
+
+// offerRematch anota la oferta del asiento indicado. Cuando ambos la han
+// hecho crea la sala nueva con los lados cambiados y la arranca.
+func (gr *GameRoom) offerRematch(seat int) {
+	gr.mu.Lock()
+	rm := gr.rematch
+	if rm == nil || rm.auto || rm.accepted || isClosed(rm.done) {
+		gr.mu.Unlock()
+		return
+	}
+	st := gr.state
+	if seat == 0 {
+		st.RematchOffer1 = true
+	} else {
+		st.RematchOffer2 = true
+	}
+	both := st.RematchOffer1 && st.RematchOffer2
+	rm.accepted = both
+	left := rm.deadline.Sub(gr.srv.now())
+	st.RematchInMs = int32((left + time.Millisecond - 1) / time.Millisecond)
+	msg := proto.Clone(st).(*pb.GameState)
+	pls := append([]pb.PingPong_PlayServer(nil), gr.players...)
+	gr.mu.Unlock()
+
+	if !both {
+		gr.send(pls, msg)
+		return
+	}
+	gr.startNext(rm)
+}
+
+// continueSeries arranca el siguiente juego de la serie si nadie lo ha
+// hecho ya.
+func (gr *GameRoom) continueSeries() {
+	gr.mu.Lock()
+	rm := gr.rematch
+	if rm == nil || !rm.auto || rm.accepted || isClosed(rm.done) {
+		gr.mu.Unlock()
+		return
+	}
+	rm.accepted = true
+	gr.mu.Unlock()
+	gr.startNext(rm)
+}
+
+// startNext crea la sala siguiente con los lados cambiados, la arranca y
+// cierra rm. Solo lo llama quien ha puesto rm.accepted. La revancha empieza
+// una serie nueva; el siguiente juego de una serie arrastra el marcador.
+func (gr *GameRoom) startNext(rm *rematch) {
+	// Con sendMu tomado la sala vieja ya no envía nada más, y la nueva
+	// empieza a enviar cuando la soltamos
+	gr.sendMu.Lock()
+	defer gr.sendMu.Unlock()
+	s := gr.srv
+	s.waitingQueueMu.Lock()
+	next := s.newRoom(gr.mode, gr.state.Map.GetName(), []pb.PingPong_PlayServer{gr.seats[1], gr.seats[0]},
+		[]float64{s.playerRating(gr.ids[1]), s.playerRating(gr.ids[0])})
+	s.waitingQueueMu.Unlock()
+
+	gr.mu.Lock()
+	if rm.auto {
+		next.mu.Lock()
+		next.state.SeriesGame = gr.state.SeriesGame + 1
+		next.state.SeriesWins1, next.state.SeriesWins2 = gr.state.SeriesWins2, gr.state.SeriesWins1
+		next.mu.Unlock()
+		log.Printf("Sala %s: juego %d de la serie en la sala %s",
+			gr.roomCode, gr.state.SeriesGame+1, next.roomCode)
+	} else {
+		log.Printf("Sala %s: revancha en la sala %s", gr.roomCode, next.roomCode)
+	}
+	rm.next = next
+	close(rm.done)
+	gr.mu.Unlock()
+	s.startRoom(next)
+}
+
+// cancelRematch cierra el plazo sin revancha, salvo que ya se esté creando
+// la sala nueva.
+func (gr *GameRoom) cancelRematch(reason string) {
+	gr.mu.Lock()
+	defer gr.mu.Unlock()
+	rm := gr.rematch
+	if rm == nil || rm.accepted || isClosed(rm.done) {
+		return
+	}
+	rm.reason = reason
+	close(rm.done)
+}
+
+func isClosed(c chan struct{}) bool {
+	select {
+	case <-c:
+		return true
+	default:
+		return false
+	}
+}
+
+// awaitRematch atiende el plazo de revancha de una sala terminada, o la
+// espera al siguiente juego de la serie. Devuelve la sala nueva si la hay;
+// si no, nil y el error con el que termina Play (nil si fue este jugador
+// quien se marchó). Las acciones pasan los mismos controles que en juego.
+func (s *Server) awaitRematch(room *GameRoom, stream pb.PingPong_PlayServer,
+	seat int, actions <-chan *pb.GameAction, guard *inputGuard) (*GameRoom, error) {
+	room.mu.Lock()
+	rm := room.rematch
+	reason := room.endReason
+	room.mu.Unlock()
+	if rm == nil {
+		return nil, status.Error(codes.Aborted, reason)
+	}
+
+	declined := rematchDeclined
+	if rm.auto {
+		declined = seriesAbandoned
+	}
+	// quit cierra el plazo con el motivo dado; si la revancha ya estaba
+	// aceptada, hay que salir de ella
+	quit := func(reason string) {
+		room.cancelRematch(reason)
+		<-rm.done
+		if rm.next != nil {
+			rm.next.leave(stream)
+		}
+	}
+	expired := s.clock.After(rm.deadline.Sub(s.now()))
+	for {
+		select {
+		case a, ok := <-actions:
+			if !ok {
+				quit(declined)
+				return nil, nil
+			}
+			if pass, err := s.screen(room, seat, guard, a); err != nil {
+				quit(status.Convert(err).Message())
+				return nil, err
+			} else if !pass {
+				continue
+			}
+			switch a.Move {
+			case moveLeave:
+				quit(declined)
+				return nil, nil
+			case moveRematch:
+				room.offerRematch(seat)
+			}
+
+		case <-expired:
+			if rm.auto {
+				room.continueSeries()
+			} else {
+				room.cancelRematch(rematchExpired)
+			}
+
+		case <-rm.done:
+			if rm.next != nil {
+				return rm.next, nil
+			}
+			return nil, status.Error(codes.Aborted, rm.reason)
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"JuegoCeN/clock"
+	pb "JuegoCeN/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// playOut avanza la sala hasta que alguien gana y devuelve el motivo.
+func playOut(t *testing.T, s *Server, code string) string {
+	t.Helper()
+	for i := 0; i < 10000; i++ {
+		if _, err := s.Step(code, 1); err != nil {
+			if status.Code(err) != codes.Aborted {
+				t.Fatalf("Step: %v", err)
+			}
+			return status.Convert(err).Message()
+		}
+	}
+	t.Fatal("nadie ganó la partida")
+	return ""
+}
+
+func newRematchHarness(t *testing.T) (*harness, *clock.Manual) {
+	clk := clock.NewManual(t0)
+	return newHarness(t, Config{ManualTicks: true, Clock: clk, PointsToWin: 1}), clk
+}
+
+func TestRematchSwapsSides(t *testing.T) {
+	h, clk := newRematchHarness(t)
+	a, b, first := h.pair()
+
+	if reason := playOut(t, h.srv, first.RoomCode); !strings.HasPrefix(reason, "Gana ") {
+		t.Fatalf("motivo = %q", reason)
+	}
+	finished := func(st *pb.GameState) bool { return st.Finished }
+	st := a.waitState(finished)
+	if st.Winner == 0 || st.RematchInMs != 15000 || st.EndReason == "" {
+		t.Fatalf("estado final = %v", st)
+	}
+	b.waitState(finished)
+
+	// La oferta de Ana le llega a Bea. El reloj avanza para que la sala
+	// nueva no coja el mismo código
+	clk.Advance(time.Second)
+	a.send(moveRematch)
+	b.waitState(func(st *pb.GameState) bool { return st.Finished && st.RematchOffer1 })
+	b.send(moveRematch)
+
+	// Ambos siguen en el mismo stream, en una sala nueva con los lados cambiados
+	fresh := func(st *pb.GameState) bool { return !st.Finished }
+	sa, sb := a.waitState(fresh), b.waitState(fresh)
+	if sa.RoomCode == first.RoomCode || sa.RoomCode != sb.RoomCode {
+		t.Fatalf("salas %s y %s tras la revancha de %s", sa.RoomCode, sb.RoomCode, first.RoomCode)
+	}
+	if sa.Name1 != "Bea" || sa.Name2 != "Ana" || sa.PlayerId != "2" || sb.PlayerId != "1" {
+		t.Fatalf("revancha: %s-%s, Ana %s, Bea %s", sa.Name1, sa.Name2, sa.PlayerId, sb.PlayerId)
+	}
+	if sa.Score1 != 0 || sa.Score2 != 0 || sa.PausesLeft1 != 3 {
+		t.Fatalf("la revancha no empieza de cero: %v", sa)
+	}
+
+	// Los movimientos de Ana mueven ahora la pala 2
+	a.send("UP")
+	waitRoom(t, h.srv, sa.RoomCode, holding(2, -1))
+	if st, _ := h.srv.Step(sa.RoomCode, 1); st.Paddle2.Y >= sa.Paddle2.Y {
+		t.Fatalf("la pala 2 no se movió: %v", st.Paddle2)
+	}
+}
+
+func TestRematchDeclined(t *testing.T) {
+	h, _ := newRematchHarness(t)
+	a, b, first := h.pair()
+	playOut(t, h.srv, first.RoomCode)
+
+	a.send(moveRematch)
+	b.send(moveLeave)
+	if err := a.waitEnd(); status.Code(err) != codes.Aborted || status.Convert(err).Message() != rematchDeclined {
+		t.Fatalf("Ana: %v", err)
+	}
+	if err := b.waitEnd(); err != io.EOF {
+		t.Fatalf("Bea: %v, se esperaba fin limpio", err)
+	}
+}
+
+func TestRematchExpires(t *testing.T) {
+	h, clk := newRematchHarness(t)
+	a, b, first := h.pair()
+	playOut(t, h.srv, first.RoomCode)
+
+	a.send(moveRematch)
+	b.waitState(func(st *pb.GameState) bool { return st.RematchOffer1 })
+	clk.Advance(15 * time.Second)
+	for _, p := range []*player{a, b} {
+		if err := p.waitEnd(); status.Convert(err).Message() != rematchExpired {
+			t.Fatalf("err = %v", err)
+		}
+	}
+}
+
+func TestNoRematchWhenOpponentLeaves(t *testing.T) {
+	h, _ := newRematchHarness(t)
+	a, b, first := h.pair()
+
+	b.cancel()
+	waitSeats(t, h.srv, first.RoomCode, 1)
+	h.srv.Step(first.RoomCode, 1)
+	if err := a.waitEnd(); status.Convert(err).Message() != opponentLeft {
+		t.Fatalf("err = %v", err)
+	}
+}
+
+func TestSeriesSwitchesSides(t *testing.T) {
+	clk := clock.NewManual(t0)
+	h := newHarness(t, Config{ManualTicks: true, Clock: clk, PointsToWin: 1, SeriesLength: 3})
+	a, b, first := h.pair()
+	if first.SeriesLength != 3 || first.SeriesGame != 1 {
+		t.Fatalf("serie = %v", first)
+	}
+
+	code, names := first.RoomCode, first.Name1+"-"+first.Name2
+	finished := func(st *pb.GameState) bool { return st.Finished }
+	fresh := func(st *pb.GameState) bool { return !st.Finished }
+	for game := int32(1); ; game++ {
+		playOut(t, h.srv, code)
+		end := a.waitState(finished)
+		b.waitState(finished)
+		if end.SeriesWins1+end.SeriesWins2 != game {
+			t.Fatalf("juego %d: serie %d-%d", game, end.SeriesWins1, end.SeriesWins2)
+		}
+		if end.SeriesWins1 == 2 || end.SeriesWins2 == 2 {
+			// Decidida la serie se ofrece la revancha
+			if end.RematchInMs != 15000 || end.NextGameInMs != 0 {
+				t.Fatalf("fin de serie = %v", end)
+			}
+			// Sin cambiar de lado ganaría siempre el mismo asiento, así
+			// que con el cambio la serie llega al tercer juego
+			if game != 3 {
+				t.Fatalf("la serie terminó en el juego %d", game)
+			}
+			return
+		}
+		if end.NextGameInMs != 3000 || end.RematchInMs != 0 {
+			t.Fatalf("entre juegos = %v", end)
+		}
+
+		// El siguiente juego empieza solo, con los lados y el marcador cambiados
+		clk.Advance(3 * time.Second)
+		next := a.waitState(fresh)
+		b.waitState(fresh)
+		if next.SeriesGame != game+1 || next.SeriesWins1 != end.SeriesWins2 || next.SeriesWins2 != end.SeriesWins1 {
+			t.Fatalf("juego %d: %v tras %v", game+1, next, end)
+		}
+		swapped := next.Name2 + "-" + next.Name1
+		if swapped != names {
+			t.Fatalf("juego %d: %s-%s, antes %s", game+1, next.Name1, next.Name2, names)
+		}
+		code, names = next.RoomCode, next.Name1+"-"+next.Name2
+	}
+}
+
+func TestLeavingSeriesEndsIt(t *testing.T) {
+	h := newHarness(t, Config{ManualTicks: true, Clock: clock.NewManual(t0), PointsToWin: 1, SeriesLength: 3})
+	a, b, first := h.pair()
+	playOut(t, h.srv, first.RoomCode)
+
+	b.send(moveLeave)
+	if err := a.waitEnd(); status.Convert(err).Message() != seriesAbandoned {
+		t.Fatalf("err = %v", err)
+	}
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	pb "JuegoCeN/proto"
+	"JuegoCeN/replay"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const replayChunkSize = 32 * 1024
+
+// replaysServer sirve los ficheros grabados en Config.ReplayDir.
+type replaysServer struct {
+	pb.UnimplementedReplaysServer
+	srv *Server
+}
+
+// findReplay devuelve la ruta de la repetición pedida: la de match_id o, si
+// falta, la más reciente con ese código de sala.
+func findReplay(replayDir string, req *pb.ReplayRequest) (string, error) {
+	if replayDir == "" {
+		return "", status.Error(codes.FailedPrecondition, "el servidor no graba repeticiones")
+	}
+
+	name := req.MatchId
+	if name == "" {
+		name = req.RoomCode
+	}
+	// Solo un nombre de fichero dentro de replayDir: sin rutas, sin ".." y
+	// sin comodines de Glob
+	if name == "" || filepath.Base(name) != name || strings.Contains(name, "..") || strings.ContainsAny(name, "*?[") {
+		return "", status.Error(codes.InvalidArgument, "código de sala o partida no válido")
+	}
+
+	if req.MatchId != "" {
+		path := filepath.Join(replayDir, req.MatchId+replay.Ext)
+		if _, err := os.Stat(path); err != nil {
+			return "", status.Errorf(codes.NotFound, "no hay repetición de %s", req.MatchId)
+		}
+		return path, nil
+	}
+
+	// Los ficheros se llaman <sala>-<inicio en ns>.replay
+	matches, _ := filepath.Glob(filepath.Join(replayDir, req.RoomCode+"-*"+replay.Ext))
+	best, bestAt := "", int64(-1)
+	for _, m := range matches {
+		suffix := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(m), req.RoomCode+"-"), replay.Ext)
+		if at, err := strconv.ParseInt(suffix, 10, 64); err == nil && at > bestAt {
+			best, bestAt = m, at
+		}
+	}
+	if best == "" {
+		return "", status.Errorf(codes.NotFound, "no hay repeticiones de la sala %s", req.RoomCode)
+	}
+	return best, nil
+}
+
+// DownloadReplay envía el fichero de repetición en trozos.
+func (s *replaysServer) DownloadReplay(req *pb.ReplayRequest, stream pb.Replays_DownloadReplayServer) error {
+	path, err := findReplay(s.srv.cfg.ReplayDir, req)
+	if err != nil {
+		return err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	r := bytes.NewReader(data)
+	buf := make([]byte, replayChunkSize)
+	for {
+		n, _ := r.Read(buf)
+		if n == 0 {
+			return nil
+		}
+		if err := stream.Send(&pb.ReplayChunk{Data: buf[:n]}); err != nil {
+			return err
+		}
+	}
+}
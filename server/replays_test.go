@@ -0,0 +1,88 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"JuegoCeN/clock"
+	pb "JuegoCeN/proto"
+	"JuegoCeN/replay"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// download baja una repetición entera por el servicio Replays.
+func download(h *harness, req *pb.ReplayRequest) ([]byte, error) {
+	stream, err := pb.NewReplaysClient(h.conn).DownloadReplay(context.Background(), req)
+	if err != nil {
+		return nil, err
+	}
+	var data []byte
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			return data, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, chunk.Data...)
+	}
+}
+
+func TestDownloadReplay(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string][]byte{
+		"ABCD-100": []byte("vieja"),
+		"ABCD-200": bytes.Repeat([]byte("nueva"), replayChunkSize), // varios trozos
+		"ABCD-x":   []byte("sin fecha"),
+		"m1":       []byte("por id"),
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name+replay.Ext), data, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	os.WriteFile(filepath.Join(filepath.Dir(dir), "fuera"+replay.Ext), []byte("secreto"), 0o644)
+	h := newHarness(t, Config{ManualTicks: true, Clock: clock.NewManual(t0), ReplayDir: dir})
+
+	for _, tc := range []struct {
+		name string
+		req  *pb.ReplayRequest
+		want []byte
+		code codes.Code
+	}{
+		{"por id", &pb.ReplayRequest{MatchId: "m1"}, files["m1"], codes.OK},
+		{"la más reciente de la sala", &pb.ReplayRequest{RoomCode: "ABCD"}, files["ABCD-200"], codes.OK},
+		{"el id manda sobre la sala", &pb.ReplayRequest{MatchId: "m1", RoomCode: "ABCD"}, files["m1"], codes.OK},
+		{"id inexistente", &pb.ReplayRequest{MatchId: "m2"}, nil, codes.NotFound},
+		{"sala sin repeticiones", &pb.ReplayRequest{RoomCode: "ZZZZ"}, nil, codes.NotFound},
+		{"sin id ni sala", &pb.ReplayRequest{}, nil, codes.InvalidArgument},
+		{"id con ..", &pb.ReplayRequest{MatchId: "../fuera"}, nil, codes.InvalidArgument},
+		{"id con /", &pb.ReplayRequest{MatchId: "a/m1"}, nil, codes.InvalidArgument},
+		{"sala con ..", &pb.ReplayRequest{RoomCode: ".."}, nil, codes.InvalidArgument},
+		{"sala con comodines", &pb.ReplayRequest{RoomCode: "AB*"}, nil, codes.InvalidArgument},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			data, err := download(h, tc.req)
+			if status.Code(err) != tc.code {
+				t.Fatalf("err = %v, se esperaba %v", err, tc.code)
+			}
+			if !bytes.Equal(data, tc.want) {
+				t.Fatalf("%d bytes, se esperaban %d", len(data), len(tc.want))
+			}
+		})
+	}
+}
+
+func TestDownloadReplayWithoutDir(t *testing.T) {
+	h := newHarness(t, Config{ManualTicks: true, Clock: clock.NewManual(t0)})
+	if _, err := download(h, &pb.ReplayRequest{RoomCode: "ABCD"}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("err = %v", err)
+	}
+}
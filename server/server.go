@@ -0,0 +1,176 @@
+package main
+
+import (
+	"sync"
+	"time"
+
+	"JuegoCeN/clock"
+	pb "JuegoCeN/proto"
+	"JuegoCeN/sim"
+	"JuegoCeN/store"
+
+	"google.golang.org/grpc"
+)
+
+// Config son los parámetros del servidor. Los campos vacíos toman el valor
+// por defecto indicado.
+type Config struct {
+	// Modes es el registro de modos de juego por nombre, cada uno con sus
+	// reglas (sim.DefaultModes).
+	Modes map[string]sim.Mode
+	// Store guarda perfiles e historial (en memoria).
+	Store store.Store
+	// ReplayDir es el directorio de repeticiones (vacío = no se graban).
+	ReplayDir string
+	// AuthSecret firma los tokens (aleatorio: no sobreviven a un reinicio).
+	AuthSecret string
+	// AdminSecret es la clave que exige el servicio Admin (vacío = ninguna:
+	// solo se admite si el TLS mutuo ya exige certificado de cliente).
+	AdminSecret string
+	// MatchPoll es cada cuánto reintenta emparejar quien espera (50ms).
+	MatchPoll time.Duration
+	// PauseBudget es cuántas pausas puede pedir cada jugador por partida (3).
+	PauseBudget int32
+	// ResumeCountdown es la cuenta atrás tras aceptar ambos reanudar (3s).
+	ResumeCountdown time.Duration
+	// MaxPause es cuánto dura una pausa como mucho antes de que empiece la
+	// cuenta atrás sin esperar a nadie (1 min).
+	MaxPause time.Duration
+	// PointsToWin son los puntos con los que se gana una partida (0 = sin
+	// límite: termina al irse alguien o por el administrador).
+	PointsToWin int32
+	// RematchTimeout es cuánto se espera a que ambos acepten la revancha (15s).
+	RematchTimeout time.Duration
+	// SeriesLength hace que se juegue al mejor de N juegos (1: partida
+	// suelta). Requiere PointsToWin.
+	SeriesLength int32
+	// PowerUps activa los potenciadores en los duelos (sim.EnablePowerUps).
+	PowerUps bool
+	// Maps son los mapas que se pueden pedir para un duelo, por nombre
+	// (sim.LoadMaps).
+	Maps map[string]*pb.FieldMap
+	// MaxActionRate son las acciones por segundo que se aceptan de cada
+	// jugador (120), con ráfagas de hasta un segundo. Las demás se
+	// descartan y cuentan como infracción.
+	MaxActionRate int
+	// MaxViolations son las infracciones (acciones por encima del límite,
+	// desconocidas o implausibles) con las que se expulsa a un jugador (20).
+	MaxViolations int32
+	// WalkoverTimeout es cuánto se espera en un torneo al rival que no se
+	// presenta antes de dar el partido por ganado a quien sí está (2 min).
+	WalkoverTimeout time.Duration
+	// SeriesBreak es la espera entre juegos de una serie (3s).
+	SeriesBreak time.Duration
+	// Clock da la hora y los temporizadores (clock.Real).
+	Clock clock.Clock
+	// ManualTicks hace que las salas no avancen solas: solo simulan al
+	// llamar a Server.Step. Es para tests deterministas.
+	ManualTicks bool
+}
+
+// Server es el servidor de juego: cola de emparejamiento, salas activas y
+// los servicios gRPC que las exponen. Se crea con NewServer.
+type Server struct {
+	pb.UnimplementedPingPongServer
+
+	cfg    Config
+	store  store.Store
+	signer *tokenSigner
+	clock  clock.Clock
+
+	// Cola de emparejamiento
+	waitingQueue   []*queueEntry
+	waitingQueueMu sync.Mutex
+
+	// Salas activas indexadas por código
+	rooms   map[string]*GameRoom
+	roomsMu sync.Mutex
+
+	// Torneos por id
+	tournaments   map[string]*liveTournament
+	tournamentSeq int
+	tournamentsMu sync.Mutex
+}
+
+// NewServer crea un servidor con la configuración dada.
+func NewServer(cfg Config) *Server {
+	if cfg.Modes == nil {
+		cfg.Modes = sim.DefaultModes()
+	}
+	if cfg.Store == nil {
+		cfg.Store = store.NewMemory()
+	}
+	if cfg.MatchPoll <= 0 {
+		cfg.MatchPoll = 50 * time.Millisecond
+	}
+	if cfg.PauseBudget <= 0 {
+		cfg.PauseBudget = 3
+	}
+	if cfg.ResumeCountdown <= 0 {
+		cfg.ResumeCountdown = 3 * time.Second
+	}
+	if cfg.MaxPause <= 0 {
+		cfg.MaxPause = time.Minute
+	}
+	if cfg.RematchTimeout <= 0 {
+		cfg.RematchTimeout = 15 * time.Second
+	}
+	if cfg.SeriesLength <= 0 {
+		cfg.SeriesLength = 1
+	}
+	if cfg.MaxActionRate <= 0 {
+		cfg.MaxActionRate = 120
+	}
+	if cfg.MaxViolations <= 0 {
+		cfg.MaxViolations = 20
+	}
+	if cfg.WalkoverTimeout <= 0 {
+		cfg.WalkoverTimeout = 2 * time.Minute
+	}
+	if cfg.SeriesBreak <= 0 {
+		cfg.SeriesBreak = 3 * time.Second
+	}
+	if cfg.Clock == nil {
+		cfg.Clock = clock.Real{}
+	}
+	s := &Server{
+		cfg:    cfg,
+		store:  cfg.Store,
+		signer: newTokenSigner(cfg.AuthSecret),
+		clock:  cfg.Clock,
+		rooms:  make(map[string]*GameRoom),
+
+		tournaments: make(map[string]*liveTournament),
+	}
+	s.signer.now = cfg.Clock.Now
+	return s
+}
+
+func (s *Server) now() time.Time { return s.clock.Now() }
+
+// StreamInterceptor exige token de jugador en Play; hay que pasarlo a
+// grpc.NewServer del servidor donde se llame a Register.
+func (s *Server) StreamInterceptor() grpc.StreamServerInterceptor {
+	return streamAuthInterceptor(s.signer)
+}
+
+// Register registra los servicios de juego: PingPong, Auth, History,
+// Replays y Tournament.
+func (s *Server) Register(gs *grpc.Server) {
+	pb.RegisterPingPongServer(gs, s)
+	pb.RegisterAuthServer(gs, &authServer{srv: s})
+	pb.RegisterHistoryServer(gs, &historyServer{srv: s})
+	pb.RegisterReplaysServer(gs, &replaysServer{srv: s})
+	pb.RegisterTournamentServer(gs, &tournamentServer{srv: s})
+}
+
+// AdminInterceptor exige Config.AdminSecret en las llamadas a Admin; hay
+// que pasarlo a grpc.NewServer del servidor donde se llame a RegisterAdmin.
+func (s *Server) AdminInterceptor() grpc.UnaryServerInterceptor {
+	return adminAuthInterceptor(s.cfg.AdminSecret)
+}
+
+// RegisterAdmin registra el servicio Admin, que va en su propio puerto.
+func (s *Server) RegisterAdmin(gs *grpc.Server) {
+	pb.RegisterAdminServer(gs, &adminServer{srv: s})
+}
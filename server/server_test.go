@@ -0,0 +1,605 @@
+package main
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"JuegoCeN/clock"
+	pb "JuegoCeN/proto"
+	"JuegoCeN/sim"
+	"JuegoCeN/store"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const waitFor = 5 * time.Second
+
+const testAdminSecret = "clave-de-admin"
+
+// adminCtx es un contexto con la clave de administración de los tests.
+func adminCtx(ctx context.Context) context.Context {
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+testAdminSecret)
+}
+
+// harness es un servidor completo escuchando en memoria con bufconn.
+type harness struct {
+	t    *testing.T
+	srv  *Server
+	conn *grpc.ClientConn
+}
+
+func newHarness(t *testing.T, cfg Config) *harness {
+	t.Helper()
+	if cfg.AuthSecret == "" {
+		cfg.AuthSecret = "secreto-de-prueba"
+	}
+	if cfg.AdminSecret == "" {
+		cfg.AdminSecret = testAdminSecret
+	}
+	srv := NewServer(cfg)
+
+	// Admin va en el mismo servidor solo por comodidad: el interceptor
+	// exige la clave igual que en su puerto propio
+	lis := bufconn.Listen(1 << 20)
+	gs := grpc.NewServer(grpc.StreamInterceptor(srv.StreamInterceptor()),
+		grpc.UnaryInterceptor(srv.AdminInterceptor()))
+	srv.Register(gs)
+	srv.RegisterAdmin(gs)
+	go gs.Serve(lis)
+	t.Cleanup(gs.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufconn",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return &harness{t: t, srv: srv, conn: conn}
+}
+
+// player es un cliente falso con su stream de Play.
+type player struct {
+	t      *testing.T
+	id     string // player_id de la identidad
+	seat   string // asiento dentro de la sala, de "1" a "4"
+	stream pb.PingPong_PlayClient
+	cancel context.CancelFunc
+	states chan *pb.GameState
+	err    chan error
+}
+
+// login devuelve un token para name.
+func (h *harness) login(name string) *pb.LoginResponse {
+	h.t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), waitFor)
+	defer cancel()
+	resp, err := pb.NewAuthClient(h.conn).Login(ctx, &pb.LoginRequest{DisplayName: name})
+	if err != nil {
+		h.t.Fatalf("Login(%s): %v", name, err)
+	}
+	return resp
+}
+
+// join inicia sesión, abre Play y pide partida.
+func (h *harness) join(name string) *player {
+	h.t.Helper()
+	return h.play(h.login(name))
+}
+
+// play abre Play con la sesión dada y pide partida. Los estados recibidos
+// se encolan en p.states.
+func (h *harness) play(login *pb.LoginResponse) *player {
+	h.t.Helper()
+	return h.playWith(login, &pb.GameAction{})
+}
+
+// playWith es play con una primera acción dada.
+func (h *harness) playWith(login *pb.LoginResponse, first *pb.GameAction) *player {
+	h.t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	h.t.Cleanup(cancel)
+	ctx = metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+login.Token)
+	stream, err := pb.NewPingPongClient(h.conn).Play(ctx)
+	if err != nil {
+		h.t.Fatalf("Play(%s): %v", login.DisplayName, err)
+	}
+	if err := stream.Send(first); err != nil {
+		h.t.Fatalf("Send(%s): %v", login.DisplayName, err)
+	}
+	p := &player{
+		t:      h.t,
+		id:     login.PlayerId,
+		stream: stream,
+		cancel: cancel,
+		states: make(chan *pb.GameState, 1024),
+		err:    make(chan error, 1),
+	}
+	go func() {
+		for {
+			st, err := stream.Recv()
+			if err != nil {
+				p.err <- err
+				return
+			}
+			p.states <- st
+		}
+	}()
+	return p
+}
+
+// next espera el siguiente estado.
+func (p *player) next() *pb.GameState {
+	p.t.Helper()
+	select {
+	case st := <-p.states:
+		if p.seat == "" {
+			p.seat = st.PlayerId
+		}
+		return st
+	case err := <-p.err:
+		p.t.Fatalf("el stream terminó esperando estado: %v", err)
+	case <-time.After(waitFor):
+		p.t.Fatal("no llegó ningún estado")
+	}
+	return nil
+}
+
+// waitState descarta estados hasta que uno cumple cond.
+func (p *player) waitState(cond func(*pb.GameState) bool) *pb.GameState {
+	p.t.Helper()
+	deadline := time.After(waitFor)
+	for {
+		select {
+		case st := <-p.states:
+			if cond(st) {
+				return st
+			}
+		case err := <-p.err:
+			p.t.Fatalf("el stream terminó: %v", err)
+		case <-deadline:
+			p.t.Fatal("ningún estado cumplió la condición")
+		}
+	}
+}
+
+// waitEnd espera a que el servidor cierre el stream y devuelve el error.
+func (p *player) waitEnd() error {
+	p.t.Helper()
+	for {
+		select {
+		case <-p.states:
+		case err := <-p.err:
+			return err
+		case <-time.After(waitFor):
+			p.t.Fatal("el servidor no cerró el stream")
+			return nil
+		}
+	}
+}
+
+func (p *player) send(move string) {
+	p.t.Helper()
+	if err := p.stream.Send(&pb.GameAction{Move: move}); err != nil {
+		p.t.Fatalf("Send(%s): %v", move, err)
+	}
+}
+
+// pair conecta a dos jugadores y espera el estado inicial de ambos.
+func (h *harness) pair() (a, b *player, first *pb.GameState) {
+	h.t.Helper()
+	a = h.join("Ana")
+	waitQueueLen(h.t, h.srv, 1)
+	b = h.join("Bea")
+	first = a.next()
+	if st := b.next(); st.RoomCode != first.RoomCode {
+		h.t.Fatalf("salas distintas: %s y %s", first.RoomCode, st.RoomCode)
+	}
+	return a, b, first
+}
+
+func TestPairsTwoPlayers(t *testing.T) {
+	h := newHarness(t, Config{})
+	a, b, first := h.pair()
+
+	if a.seat != "1" || b.seat != "2" {
+		t.Fatalf("asientos %q y %q, se esperaba 1 y 2 por orden de llegada", a.seat, b.seat)
+	}
+	if first.Name1 != "Ana" || first.Name2 != "Bea" {
+		t.Fatalf("nombres %q y %q", first.Name1, first.Name2)
+	}
+	if first.Score1 != 0 || first.Score2 != 0 || first.Ball.X != 0.5 || first.Ball.Y != 0.5 {
+		t.Fatalf("estado inicial inesperado: %v", first)
+	}
+	if _, err := h.srv.lookupRoom(first.RoomCode); err != nil {
+		t.Fatalf("la sala %s no está registrada", first.RoomCode)
+	}
+
+	// Ambos reciben las difusiones de la misma sala, cada uno con su asiento
+	for _, p := range []*player{a, b} {
+		st := p.next()
+		if st.RoomCode != first.RoomCode || st.PlayerId != p.seat {
+			t.Fatalf("difusión para %s: sala %s jugador %s", p.seat, st.RoomCode, st.PlayerId)
+		}
+	}
+}
+
+func TestInputsMovePaddles(t *testing.T) {
+	h := newHarness(t, Config{ManualTicks: true, Clock: clock.NewManual(t0)})
+	a, b, first := h.pair()
+
+	// Por muchas acciones que lleguen, la pala avanza lo mismo en cada tick
+	for i := 0; i < 20; i++ {
+		a.send("UP")
+		b.send("DOWN")
+	}
+	waitRoom(t, h.srv, first.RoomCode, holding(2, 1))
+	waitRoom(t, h.srv, first.RoomCode, holding(1, -1))
+	h.srv.Step(first.RoomCode, 5)
+	want1 := first.Paddle1.Y - 5*h.srv.cfg.Modes[modeDuel].Rules.PaddleDelta
+	want2 := first.Paddle2.Y + 5*h.srv.cfg.Modes[modeDuel].Rules.PaddleDelta
+	moved := func(st *pb.GameState) bool {
+		return near(st.Paddle1.Y, want1) && near(st.Paddle2.Y, want2)
+	}
+	// Los dos ven ambas palas en su sitio
+	st := a.waitState(moved)
+	b.waitState(moved)
+
+	// La bola avanza con la velocidad de saque
+	if st.Ball.X <= first.Ball.X || st.Ball.Y <= first.Ball.Y {
+		t.Fatalf("la bola no avanza: %v -> %v", first.Ball, st.Ball)
+	}
+
+	// Al soltar la tecla la pala se para
+	a.send("NONE")
+	waitRoom(t, h.srv, first.RoomCode, holding(1, 0))
+	if st, _ := h.srv.Step(first.RoomCode, 5); !near(st.Paddle1.Y, want1) {
+		t.Fatalf("la pala sigue moviéndose: %v", st.Paddle1.Y)
+	}
+}
+
+func TestDisconnectEndsMatch(t *testing.T) {
+	mem := store.NewMemory()
+	h := newHarness(t, Config{Store: mem})
+	a, b, first := h.pair()
+
+	b.cancel()
+	err := a.waitEnd()
+	if status.Code(err) != codes.Aborted {
+		t.Fatalf("err = %v, se esperaba Aborted", err)
+	}
+	if _, err := h.srv.lookupRoom(first.RoomCode); err == nil {
+		t.Fatal("la sala sigue registrada tras terminar")
+	}
+
+	ms, total, _ := mem.PlayerMatches(a.id, 10, 0)
+	if total != 1 || ms[0].RoomCode != first.RoomCode || ms[0].Player2.ID != b.id {
+		t.Fatalf("historial de Ana = %+v", ms)
+	}
+	// Bea se fue: pierde ella, aunque fueran 0-0
+	if ms[0].Forfeit != 2 || ms[0].Winner() != a.id {
+		t.Fatalf("abandono = %+v", ms[0])
+	}
+	if p, _ := mem.Profile(a.id); p.Wins != 1 || p.Rating <= 1500 {
+		t.Fatalf("perfil de Ana = %+v", p)
+	}
+}
+
+func TestAdminEndIsUnrated(t *testing.T) {
+	mem := store.NewMemory()
+	h := newHarness(t, Config{ManualTicks: true, Clock: clock.NewManual(t0), Store: mem})
+	a, _, first := h.pair()
+
+	ctx, cancel := context.WithTimeout(context.Background(), waitFor)
+	defer cancel()
+	if _, err := pb.NewAdminClient(h.conn).EndMatch(adminCtx(ctx), &pb.RoomRequest{RoomCode: first.RoomCode}); err != nil {
+		t.Fatal(err)
+	}
+	a.waitEnd()
+	ms, total, _ := mem.PlayerMatches(a.id, 10, 0)
+	if total != 1 || !ms[0].Unrated {
+		t.Fatalf("historial de Ana = %+v", ms)
+	}
+	if p, _ := mem.Profile(a.id); p.Played != 1 || p.Rating != 1500 {
+		t.Fatalf("perfil de Ana = %+v", p)
+	}
+}
+
+func TestWaitingPlayerLeavesQueue(t *testing.T) {
+	h := newHarness(t, Config{})
+	a := h.join("Ana")
+	waitQueueLen(t, h.srv, 1)
+
+	a.cancel()
+	waitQueueLen(t, h.srv, 0)
+
+	// Quien llega después no se empareja con el que se fue
+	b := h.join("Bea")
+	waitQueueLen(t, h.srv, 1)
+	c := h.join("Carlos")
+	if st := b.next(); st.Name1 != "Bea" || st.Name2 != "Carlos" {
+		t.Fatalf("pareja %s-%s", st.Name1, st.Name2)
+	}
+	c.next()
+}
+
+func TestPlayRequiresToken(t *testing.T) {
+	h := newHarness(t, Config{})
+	ctx, cancel := context.WithTimeout(context.Background(), waitFor)
+	defer cancel()
+	stream, err := pb.NewPingPongClient(h.conn).Play(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stream.Send(&pb.GameAction{})
+	if _, err := stream.Recv(); status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("err = %v, se esperaba Unauthenticated", err)
+	}
+}
+
+func TestAdminDrainQueue(t *testing.T) {
+	h := newHarness(t, Config{})
+	a := h.join("Ana")
+	waitQueueLen(t, h.srv, 1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), waitFor)
+	defer cancel()
+	admin := pb.NewAdminClient(h.conn)
+	// Sin la clave, o con otra, no se puede
+	for _, c := range []context.Context{ctx, metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer otra")} {
+		if _, err := admin.DrainQueue(c, &pb.DrainQueueRequest{}); status.Code(err) != codes.Unauthenticated {
+			t.Fatalf("err = %v, se esperaba Unauthenticated", err)
+		}
+	}
+	waitQueueLen(t, h.srv, 1)
+
+	resp, err := admin.DrainQueue(adminCtx(ctx), &pb.DrainQueueRequest{})
+	if err != nil || resp.Drained != 1 {
+		t.Fatalf("DrainQueue = %v, %v", resp, err)
+	}
+	if err := a.waitEnd(); status.Code(err) != codes.Unavailable {
+		t.Fatalf("err = %v, se esperaba Unavailable", err)
+	}
+}
+
+func waitQueueLen(t *testing.T, s *Server, n int) {
+	t.Helper()
+	deadline := time.Now().Add(waitFor)
+	for {
+		s.waitingQueueMu.Lock()
+		got := len(s.waitingQueue)
+		s.waitingQueueMu.Unlock()
+		if got == n {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("la cola tiene %d jugadores, se esperaban %d", got, n)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func near(a, b float32) bool {
+	d := a - b
+	return d < 1e-5 && d > -1e-5
+}
+
+var t0 = time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+
+func TestManualTicksExactPhysics(t *testing.T) {
+	h := newHarness(t, Config{ManualTicks: true, Clock: clock.NewManual(t0)})
+	a, b, first := h.pair()
+
+	// Misma simulación en local: sin acciones, el resultado es exacto
+	rules := h.srv.cfg.Modes[modeDuel].Rules
+	want := sim.NewState(first.RoomCode)
+	vel := rules.InitialVelocity()
+	firstPoint := 0
+	for k := 1; firstPoint == 0; k++ {
+		sim.Step(want, &vel, rules)
+		if want.Score1+want.Score2 > 0 {
+			firstPoint = k
+		}
+		if k > 10000 {
+			t.Fatal("nadie marca sin mover las palas")
+		}
+	}
+
+	const k = 30
+	st, err := h.srv.Step(first.RoomCode, k)
+	if err != nil {
+		t.Fatal(err)
+	}
+	local := sim.NewState(first.RoomCode)
+	vel = rules.InitialVelocity()
+	for i := 0; i < k; i++ {
+		sim.Step(local, &vel, rules)
+	}
+	if st.Ball.X != local.Ball.X || st.Ball.Y != local.Ball.Y {
+		t.Fatalf("bola tras %d ticks en %v, se esperaba %v", k, st.Ball, local.Ball)
+	}
+
+	// Cada jugador recibe exactamente un estado por tick
+	for _, p := range []*player{a, b} {
+		var last *pb.GameState
+		for i := 0; i < k; i++ {
+			last = p.next()
+		}
+		if last.Ball.X != st.Ball.X || last.Ball.Y != st.Ball.Y {
+			t.Fatalf("el jugador %s recibió %v, el servidor está en %v", p.seat, last.Ball, st.Ball)
+		}
+	}
+
+	// El marcador cambia justo en el tick calculado
+	st, _ = h.srv.Step(first.RoomCode, firstPoint-k-1)
+	if st.Score1+st.Score2 != 0 {
+		t.Fatalf("punto antes del tick %d: %d-%d", firstPoint, st.Score1, st.Score2)
+	}
+	st, _ = h.srv.Step(first.RoomCode, 1)
+	if st.Score1 != want.Score1 || st.Score2 != want.Score2 {
+		t.Fatalf("marcador en el tick %d = %d-%d, se esperaba %d-%d",
+			firstPoint, st.Score1, st.Score2, want.Score1, want.Score2)
+	}
+}
+
+func TestPowerUpsFollowSeed(t *testing.T) {
+	h := newHarness(t, Config{ManualTicks: true, Clock: clock.NewManual(t0), PowerUps: true})
+	_, _, first := h.pair()
+	if !first.PowerUps || first.Seed == 0 {
+		t.Fatalf("estado inicial sin potenciadores: %v", first)
+	}
+
+	// Con la semilla del estado inicial los objetos salen igual en local
+	rules := h.srv.cfg.Modes[modeDuel].Rules
+	local := sim.NewState(first.RoomCode)
+	sim.EnablePowerUps(local, first.Seed)
+	vel := rules.InitialVelocity()
+	for i := 0; i < sim.ItemEvery; i++ {
+		sim.Step(local, &vel, rules)
+	}
+	st, err := h.srv.Step(first.RoomCode, sim.ItemEvery)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(st.Items) == 0 || len(st.Items) != len(local.Items) ||
+		st.Items[0].Kind != local.Items[0].Kind || st.Items[0].Pos.Y != local.Items[0].Pos.Y {
+		t.Fatalf("objetos %v, se esperaba %v", st.Items, local.Items)
+	}
+}
+
+func TestMapChosenAtRoomCreation(t *testing.T) {
+	pilares, err := sim.ParseMap([]byte(`{"name": "pilares", "goal_width": 0.5,
+		"obstacles": [{"pos": {"X": 0.5, "Y": 0.2}, "w": 0.03, "h": 0.16}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	h := newHarness(t, Config{Maps: map[string]*pb.FieldMap{"pilares": pilares}})
+
+	// Solo se empareja a quien pide el mismo mapa
+	h.join("Eva")
+	waitQueueLen(t, h.srv, 1)
+	a := h.playWith(h.login("Ana"), &pb.GameAction{MapName: "pilares"})
+	waitQueueLen(t, h.srv, 2)
+	b := h.playWith(h.login("Bea"), &pb.GameAction{MapName: "pilares"})
+	st := a.next()
+	if st.Map.GetName() != "pilares" || st.Map.GoalWidth != 0.5 || len(st.Map.Obstacles) != 1 {
+		t.Fatalf("mapa = %v", st.Map)
+	}
+	if b.next().RoomCode != st.RoomCode {
+		t.Fatal("Ana y Bea en salas distintas")
+	}
+	waitQueueLen(t, h.srv, 1)
+
+	for _, first := range []*pb.GameAction{{MapName: "laberinto"}, {MapName: "pilares", Mode: modeFour}} {
+		p := h.playWith(h.login("Carlos"), first)
+		if err := p.waitEnd(); status.Code(err) != codes.InvalidArgument {
+			t.Fatalf("%v: err = %v", first, err)
+		}
+	}
+}
+
+func TestManualTicksEndWhenPlayerLeaves(t *testing.T) {
+	h := newHarness(t, Config{ManualTicks: true, Clock: clock.NewManual(t0)})
+	a, b, first := h.pair()
+
+	b.cancel()
+	waitSeats(t, h.srv, first.RoomCode, 1)
+	if _, err := h.srv.Step(first.RoomCode, 1); status.Code(err) != codes.Aborted {
+		t.Fatalf("Step con un solo jugador: %v", err)
+	}
+	if err := a.waitEnd(); status.Code(err) != codes.Aborted {
+		t.Fatalf("err = %v, se esperaba Aborted", err)
+	}
+}
+
+// ratedStore fija la puntuación de algunos jugadores.
+type ratedStore struct {
+	store.Store
+	ratings map[string]float64
+}
+
+func (s ratedStore) Profile(id string) (store.Profile, error) {
+	p, err := s.Store.Profile(id)
+	if r, ok := s.ratings[id]; ok {
+		p.Rating = r
+	}
+	return p, err
+}
+
+func TestManualClockWidensRatingWindow(t *testing.T) {
+	clk := clock.NewManual(t0)
+	rs := ratedStore{Store: store.NewMemory(), ratings: map[string]float64{}}
+	h := newHarness(t, Config{ManualTicks: true, Clock: clk, Store: rs})
+
+	ana, bea := h.login("Ana"), h.login("Bea")
+	rs.ratings[ana.PlayerId] = 1500
+	rs.ratings[bea.PlayerId] = 1800
+	a := h.play(ana)
+	b := h.play(bea)
+
+	// La ventana es 100 + 50/s: con 300 de diferencia hacen falta 4s
+	clk.BlockUntil(2)
+	clk.Advance(3900 * time.Millisecond)
+	clk.BlockUntil(2)
+	waitQueueLen(t, h.srv, 2)
+
+	clk.Advance(100 * time.Millisecond)
+	st := a.next()
+	if names := st.Name1 + "-" + st.Name2; names != "Ana-Bea" && names != "Bea-Ana" {
+		t.Fatalf("pareja %s", names)
+	}
+	b.next()
+}
+
+func TestLargeRatingGapEventuallyMatches(t *testing.T) {
+	clk := clock.NewManual(t0)
+	rs := ratedStore{Store: store.NewMemory(), ratings: map[string]float64{}}
+	h := newHarness(t, Config{ManualTicks: true, Clock: clk, Store: rs})
+
+	ana, bea := h.login("Ana"), h.login("Bea")
+	rs.ratings[ana.PlayerId] = 1000
+	rs.ratings[bea.PlayerId] = 2500
+	a := h.play(ana)
+	b := h.play(bea)
+
+	// Solos en la cola, 1500 puntos se cubren a los 28s
+	clk.BlockUntil(2)
+	clk.Advance(27900 * time.Millisecond)
+	clk.BlockUntil(2)
+	waitQueueLen(t, h.srv, 2)
+
+	clk.Advance(100 * time.Millisecond)
+	a.next()
+	b.next()
+}
+
+// waitSeats espera a que la sala tenga n jugadores conectados.
+func waitSeats(t *testing.T, s *Server, code string, n int) {
+	t.Helper()
+	room, err := s.lookupRoom(code)
+	if err != nil {
+		t.Fatal(err)
+	}
+	deadline := time.Now().Add(waitFor)
+	for {
+		room.mu.Lock()
+		got := len(room.players)
+		room.mu.Unlock()
+		if got == n {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("la sala tiene %d jugadores, se esperaban %d", got, n)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
@@ -0,0 +1,478 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+
+	pb "JuegoCeN/proto"
+	"JuegoCeN/tournament"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// liveTournament es un torneo del servidor: inscripciones, cuadro y la sala
+// de espera de los jugadores conectados. Los torneos viven solo en memoria.
+type liveTournament struct {
+	id, name string
+	format   tournament.Format
+	creator  Identity
+
+	mu       sync.Mutex
+	entrants []tournament.Player    // inscritos, hasta que empieza
+	t        *tournament.Tournament // cuadro; nil mientras se inscriben
+	lobby    map[string]*lobbyEntry // jugadores esperando partido por player_id
+
+	// noShow marca los partidos listos en los que uno de los dos ya espera
+	// y corre el plazo de Config.WalkoverTimeout para el otro.
+	noShow map[int]bool
+}
+
+// lobbyEntry es un jugador en la sala de espera; por assigned recibe la
+// sala de su partido o el error con el que termina su torneo.
+type lobbyEntry struct {
+	stream   pb.PingPong_PlayServer
+	assigned chan lobbyResult
+}
+
+type lobbyResult struct {
+	room *GameRoom
+	err  error
+}
+
+// tournamentMatch une una sala con su partido del cuadro.
+type tournamentMatch struct {
+	lt *liveTournament
+	id int
+}
+
+// index devuelve la posición del jugador en el torneo (su cabeza de serie
+// menos uno una vez empezado) o -1. Requiere lt.mu.
+func (lt *liveTournament) index(playerID string) int {
+	players := lt.entrants
+	if lt.t != nil {
+		players = lt.t.Players
+	}
+	for i, p := range players {
+		if p.ID == playerID {
+			return i
+		}
+	}
+	return -1
+}
+
+// dispatch crea las salas de los partidos listos cuyos jugadores estén los
+// dos esperando, y despide a los que ya no tienen partidos. Devuelve las
+// salas para arrancarlas sin lt.mu. Requiere lt.mu.
+func (lt *liveTournament) dispatch(s *Server) []*GameRoom {
+	if lt.t == nil {
+		return nil
+	}
+	for id, e := range lt.lobby {
+		if p := lt.index(id); !lt.t.Alive(p) {
+			e.assigned <- lobbyResult{err: lt.outcome(p)}
+			delete(lt.lobby, id)
+		}
+	}
+
+	var rooms []*GameRoom
+	for _, m := range lt.t.Ready() {
+		p1, p2 := lt.t.Players[m.Players[0]], lt.t.Players[m.Players[1]]
+		a, b := lt.lobby[p1.ID], lt.lobby[p2.ID]
+		if a == nil || b == nil {
+			if (a != nil || b != nil) && !lt.noShow[m.ID] {
+				lt.noShow[m.ID] = true
+				go lt.awaitNoShow(s, m.ID)
+			}
+			continue
+		}
+		s.waitingQueueMu.Lock()
+		room := s.newRoom(s.cfg.Modes[modeDuel], "", []pb.PingPong_PlayServer{a.stream, b.stream},
+			[]float64{s.playerRating(Identity{ID: p1.ID}), s.playerRating(Identity{ID: p2.ID})})
+		s.waitingQueueMu.Unlock()
+		// Los partidos de torneo son a un solo juego: el cuadro no sabe de
+		// series
+		room.state.SeriesLength = 1
+		room.tmatch = &tournamentMatch{lt: lt, id: m.ID}
+		lt.t.Start(m.ID, room.roomCode)
+		log.Printf("Torneo %s: %s contra %s en la sala %s", lt.id, p1.Name, p2.Name, room.roomCode)
+
+		delete(lt.lobby, p1.ID)
+		delete(lt.lobby, p2.ID)
+		a.assigned <- lobbyResult{room: room}
+		b.assigned <- lobbyResult{room: room}
+		rooms = append(rooms, room)
+	}
+	return rooms
+}
+
+// awaitNoShow espera Config.WalkoverTimeout y, si para entonces solo uno
+// de los dos jugadores del partido está en la sala de espera, le da el
+// partido por ganado para que el cuadro no se quede parado.
+func (lt *liveTournament) awaitNoShow(s *Server, matchID int) {
+	<-s.clock.After(s.cfg.WalkoverTimeout)
+	lt.mu.Lock()
+	delete(lt.noShow, matchID)
+	m := lt.t.Matches[matchID]
+	var rooms []*GameRoom
+	if m.State == tournament.Ready {
+		present := [2]bool{}
+		for i, p := range m.Players {
+			present[i] = lt.lobby[lt.t.Players[p].ID] != nil
+		}
+		if present[0] != present[1] {
+			winner := 0
+			if present[1] {
+				winner = 1
+			}
+			if err := lt.t.Walkover(matchID, winner); err != nil {
+				log.Printf("Torneo %s: no se pudo dar el partido %d: %v", lt.id, matchID, err)
+			}
+			log.Printf("Torneo %s: %s no se presentó; gana %s", lt.id,
+				lt.t.Players[m.Players[1-winner]].Name, lt.t.Players[m.Players[winner]].Name)
+			if lt.t.Done() {
+				log.Printf("Torneo %s terminado: gana %s", lt.id, lt.t.Players[lt.t.Champion()].Name)
+			}
+		}
+		// Si sigue faltando alguien en otro partido, dispatch vuelve a
+		// contar el plazo
+		rooms = lt.dispatch(s)
+	}
+	lt.mu.Unlock()
+	for _, r := range rooms {
+		s.startRoom(r)
+	}
+}
+
+// outcome es el error con el que se despide a un jugador sin partidos.
+// Requiere lt.mu.
+func (lt *liveTournament) outcome(p int) error {
+	switch c := lt.t.Champion(); {
+	case c == p:
+		return status.Error(codes.Aborted, "Has ganado el torneo")
+	case c != tournament.NoPlayer:
+		return status.Errorf(codes.Aborted, "Torneo terminado: gana %s", lt.t.Players[c].Name)
+	}
+	return status.Error(codes.Aborted, "Has quedado eliminado del torneo")
+}
+
+// report apunta el resultado de una sala y arranca los partidos que queden
+// listos.
+func (lt *liveTournament) report(s *Server, matchID, winner int, score1, score2 int32) {
+	lt.mu.Lock()
+	if err := lt.t.Report(matchID, winner, score1, score2); err != nil {
+		log.Printf("Torneo %s: no se pudo apuntar el partido %d: %v", lt.id, matchID, err)
+	}
+	if lt.t.Done() {
+		log.Printf("Torneo %s terminado: gana %s", lt.id, lt.t.Players[lt.t.Champion()].Name)
+	}
+	rooms := lt.dispatch(s)
+	lt.mu.Unlock()
+	for _, r := range rooms {
+		s.startRoom(r)
+	}
+}
+
+// closeTournamentMatch marca el estado como terminado y decide el ganador:
+// quien siga en la sala si el otro se fue y, si no, quien vaya por delante
+// (con empate, el asiento 1, que es el mejor cabeza de serie). Devuelve el
+// asiento ganador. Requiere gr.mu.
+func (gr *GameRoom) closeTournamentMatch(reason string) int {
+	st := gr.state
+	winner := 0
+	switch {
+	case len(gr.players) == 1:
+		winner = gr.seatOf(gr.players[0])
+	case st.Score2 > st.Score1:
+		winner = 1
+	}
+	st.Finished, st.EndReason, st.Winner = true, reason, int32(winner+1)
+	return winner
+}
+
+// playTournament lleva a un jugador de partido en partido de un torneo
+// hasta que queda eliminado, se desconecta o termina el torneo.
+func (s *Server) playTournament(stream pb.PingPong_PlayServer, tournamentID string) error {
+	lt, err := s.lookupTournament(tournamentID)
+	if err != nil {
+		return err
+	}
+	id, _ := identityFrom(stream.Context())
+	actions := recvActions(stream)
+	for {
+		room, err := s.awaitTournamentMatch(lt, id, stream, actions)
+		if room == nil {
+			return err
+		}
+		if err := s.playRoom(room, stream, actions); err != errMatchOver {
+			return err
+		}
+	}
+}
+
+// awaitTournamentMatch deja al jugador en la sala de espera hasta que su
+// siguiente partido tiene sala. Devuelve nil si se desconecta o si ya no
+// tiene partidos (con el error que lo explica).
+func (s *Server) awaitTournamentMatch(lt *liveTournament, id Identity,
+	stream pb.PingPong_PlayServer, actions <-chan *pb.GameAction) (*GameRoom, error) {
+	e := &lobbyEntry{stream: stream, assigned: make(chan lobbyResult, 1)}
+	lt.mu.Lock()
+	if lt.index(id.ID) < 0 {
+		lt.mu.Unlock()
+		return nil, status.Error(codes.FailedPrecondition, "no estás inscrito en el torneo")
+	}
+	if lt.lobby[id.ID] != nil {
+		lt.mu.Unlock()
+		return nil, status.Error(codes.AlreadyExists, "ya estás esperando en este torneo")
+	}
+	lt.lobby[id.ID] = e
+	rooms := lt.dispatch(s)
+	lt.mu.Unlock()
+	for _, r := range rooms {
+		s.startRoom(r)
+	}
+
+	for {
+		select {
+		case r := <-e.assigned:
+			return r.room, r.err
+		case _, ok := <-actions:
+			if ok {
+				continue // esperando no hay nada que mover
+			}
+			lt.mu.Lock()
+			if lt.lobby[id.ID] == e {
+				delete(lt.lobby, id.ID)
+			}
+			lt.mu.Unlock()
+			// Si justo le tocó sala, la abandona y gana el rival
+			select {
+			case r := <-e.assigned:
+				if r.room != nil {
+					r.room.leave(stream)
+				}
+			default:
+			}
+			return nil, nil
+		}
+	}
+}
+
+func (s *Server) lookupTournament(id string) (*liveTournament, error) {
+	s.tournamentsMu.Lock()
+	defer s.tournamentsMu.Unlock()
+	lt, ok := s.tournaments[id]
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "torneo %q no encontrado", id)
+	}
+	return lt, nil
+}
+
+// tournamentServer implementa el servicio Tournament.
+type tournamentServer struct {
+	pb.UnimplementedTournamentServer
+	srv *Server
+}
+
+// CreateTournament abre las inscripciones de un torneo nuevo.
+func (s *tournamentServer) CreateTournament(ctx context.Context, req *pb.CreateTournamentRequest) (*pb.TournamentBracket, error) {
+	id, err := identityFromMetadata(ctx, s.srv.signer)
+	if err != nil {
+		return nil, err
+	}
+	var format tournament.Format
+	switch req.Format {
+	case pb.TournamentFormat_SINGLE_ELIMINATION:
+		format = tournament.SingleElimination
+	case pb.TournamentFormat_DOUBLE_ELIMINATION:
+		format = tournament.DoubleElimination
+	case pb.TournamentFormat_ROUND_ROBIN:
+		format = tournament.RoundRobin
+	default:
+		return nil, status.Errorf(codes.InvalidArgument, "formato desconocido: %v", req.Format)
+	}
+
+	s.srv.tournamentsMu.Lock()
+	s.srv.tournamentSeq++
+	lt := &liveTournament{
+		id:      fmt.Sprintf("T%d", s.srv.tournamentSeq),
+		name:    strings.TrimSpace(req.Name),
+		format:  format,
+		creator: id,
+		lobby:   make(map[string]*lobbyEntry),
+		noShow:  make(map[int]bool),
+	}
+	if lt.name == "" {
+		lt.name = "Torneo " + lt.id
+	}
+	s.srv.tournaments[lt.id] = lt
+	s.srv.tournamentsMu.Unlock()
+
+	log.Printf("Torneo %s (%s, %s) creado por %s", lt.id, lt.name, format, id.Name)
+	return lt.bracket(), nil
+}
+
+// JoinTournament inscribe al jugador del token.
+func (s *tournamentServer) JoinTournament(ctx context.Context, req *pb.TournamentRequest) (*pb.TournamentBracket, error) {
+	id, err := identityFromMetadata(ctx, s.srv.signer)
+	if err != nil {
+		return nil, err
+	}
+	lt, err := s.srv.lookupTournament(req.TournamentId)
+	if err != nil {
+		return nil, err
+	}
+	rating := s.srv.playerRating(id)
+
+	lt.mu.Lock()
+	switch {
+	case lt.t != nil:
+		lt.mu.Unlock()
+		return nil, status.Error(codes.FailedPrecondition, "el torneo ya ha empezado")
+	case lt.index(id.ID) < 0:
+		lt.entrants = append(lt.entrants, tournament.Player{ID: id.ID, Name: id.Name, Rating: rating})
+		log.Printf("Torneo %s: inscrito %s", lt.id, id.Name)
+	}
+	lt.mu.Unlock()
+	return lt.bracket(), nil
+}
+
+// StartTournament cierra las inscripciones y genera el cuadro; los
+// partidos empiezan en cuanto ambos jugadores están en la sala de espera.
+func (s *tournamentServer) StartTournament(ctx context.Context, req *pb.TournamentRequest) (*pb.TournamentBracket, error) {
+	id, err := identityFromMetadata(ctx, s.srv.signer)
+	if err != nil {
+		return nil, err
+	}
+	lt, err := s.srv.lookupTournament(req.TournamentId)
+	if err != nil {
+		return nil, err
+	}
+	if id.ID != lt.creator.ID {
+		return nil, status.Error(codes.PermissionDenied, "solo quien creó el torneo puede empezarlo")
+	}
+
+	lt.mu.Lock()
+	if lt.t != nil {
+		lt.mu.Unlock()
+		return nil, status.Error(codes.FailedPrecondition, "el torneo ya ha empezado")
+	}
+	t, err := tournament.New(lt.format, lt.entrants)
+	if err != nil {
+		lt.mu.Unlock()
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	lt.t = t
+	rooms := lt.dispatch(s.srv)
+	lt.mu.Unlock()
+	for _, r := range rooms {
+		s.srv.startRoom(r)
+	}
+	log.Printf("Torneo %s empezado con %d jugadores", lt.id, len(t.Players))
+	return lt.bracket(), nil
+}
+
+// GetBracket devuelve el estado del cuadro.
+func (s *tournamentServer) GetBracket(ctx context.Context, req *pb.TournamentRequest) (*pb.TournamentBracket, error) {
+	lt, err := s.srv.lookupTournament(req.TournamentId)
+	if err != nil {
+		return nil, err
+	}
+	return lt.bracket(), nil
+}
+
+// ListTournaments devuelve los torneos sin los partidos.
+func (s *tournamentServer) ListTournaments(ctx context.Context, _ *pb.ListTournamentsRequest) (*pb.ListTournamentsResponse, error) {
+	s.srv.tournamentsMu.Lock()
+	var lts []*liveTournament
+	for _, lt := range s.srv.tournaments {
+		lts = append(lts, lt)
+	}
+	s.srv.tournamentsMu.Unlock()
+
+	resp := &pb.ListTournamentsResponse{}
+	for _, lt := range lts {
+		b := lt.bracket()
+		b.Matches, b.Standings = nil, nil
+		resp.Tournaments = append(resp.Tournaments, b)
+	}
+	return resp, nil
+}
+
+// bracket convierte el torneo a su mensaje.
+func (lt *liveTournament) bracket() *pb.TournamentBracket {
+	lt.mu.Lock()
+	defer lt.mu.Unlock()
+
+	b := &pb.TournamentBracket{
+		TournamentId: lt.id,
+		Name:         lt.name,
+		Format:       pb.TournamentFormat(lt.format),
+		Creator:      lt.creator.Name,
+	}
+	if lt.t == nil {
+		for i, p := range lt.entrants {
+			b.Players = append(b.Players, &pb.TournamentPlayer{
+				PlayerId: p.ID, DisplayName: p.Name, Seed: int32(i + 1), Alive: true,
+			})
+		}
+		return b
+	}
+
+	t := lt.t
+	b.Status = pb.TournamentStatus_TOURNAMENT_RUNNING
+	if c := t.Champion(); c != tournament.NoPlayer {
+		b.Status = pb.TournamentStatus_TOURNAMENT_FINISHED
+		b.Champion = t.Players[c].Name
+	}
+	for i, p := range t.Players {
+		b.Players = append(b.Players, &pb.TournamentPlayer{
+			PlayerId: p.ID, DisplayName: p.Name, Seed: int32(i + 1), Alive: t.Alive(i),
+		})
+	}
+	name := func(p int) string {
+		if p == tournament.NoPlayer {
+			return ""
+		}
+		return t.Players[p].Name
+	}
+	for _, m := range t.Matches {
+		bm := &pb.BracketMatch{
+			MatchId:  int32(m.ID),
+			Side:     pb.BracketSide(m.Bracket),
+			Round:    int32(m.Round),
+			Player1:  name(m.Players[0]),
+			Player2:  name(m.Players[1]),
+			Status:   pb.BracketMatchStatus(m.State),
+			Score1:   m.Score[0],
+			Score2:   m.Score[1],
+			RoomCode: m.RoomCode,
+			Bye:      m.Bye,
+			Walkover: m.Walkover,
+		}
+		switch {
+		case m.Winner == tournament.NoPlayer:
+		case m.Winner == m.Players[0]:
+			bm.Winner = 1
+		default:
+			bm.Winner = 2
+		}
+		b.Matches = append(b.Matches, bm)
+	}
+	if lt.format == tournament.RoundRobin {
+		for _, st := range t.Standings() {
+			b.Standings = append(b.Standings, &pb.TournamentStanding{
+				DisplayName:   t.Players[st.Player].Name,
+				Played:        int32(st.Played),
+				Wins:          int32(st.Wins),
+				Losses:        int32(st.Losses),
+				PointsFor:     st.PointsFor,
+				PointsAgainst: st.Against,
+			})
+		}
+	}
+	return b
+}
@@ -0,0 +1,162 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"JuegoCeN/clock"
+	pb "JuegoCeN/proto"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// authed devuelve un contexto con el token de login.
+func authed(t *testing.T, login *pb.LoginResponse) context.Context {
+	ctx, cancel := context.WithTimeout(context.Background(), waitFor)
+	t.Cleanup(cancel)
+	return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+login.Token)
+}
+
+func TestTournamentSingleElimination(t *testing.T) {
+	h := newHarness(t, Config{ManualTicks: true, Clock: clock.NewManual(t0), PointsToWin: 1})
+	tc := pb.NewTournamentClient(h.conn)
+	ana, bea, carlos := h.login("Ana"), h.login("Bea"), h.login("Carlos")
+
+	b, err := tc.CreateTournament(authed(t, ana), &pb.CreateTournamentRequest{Name: "Comida"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := b.TournamentId
+	for _, l := range []*pb.LoginResponse{ana, bea, carlos} {
+		if _, err := tc.JoinTournament(authed(t, l), &pb.TournamentRequest{TournamentId: id}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := tc.StartTournament(authed(t, bea), &pb.TournamentRequest{TournamentId: id}); status.Code(err) != codes.PermissionDenied {
+		t.Fatalf("Start de quien no lo creó: %v", err)
+	}
+	if b, err = tc.StartTournament(authed(t, ana), &pb.TournamentRequest{TournamentId: id}); err != nil {
+		t.Fatal(err)
+	}
+	// Con la misma puntuación cuenta el orden de inscripción: Ana pasa
+	// la primera ronda sin jugar y Bea y Carlos se enfrentan
+	if m := b.Matches[0]; !m.Bye || m.Winner != 1 || m.Player1 != "Ana" {
+		t.Fatalf("primer partido = %v", m)
+	}
+	if _, err := tc.JoinTournament(authed(t, carlos), &pb.TournamentRequest{TournamentId: id}); status.Code(err) != codes.FailedPrecondition {
+		t.Fatalf("inscripción tras empezar: %v", err)
+	}
+
+	join := &pb.GameAction{TournamentId: id}
+	pa, pb_, pc := h.playWith(ana, join), h.playWith(bea, join), h.playWith(carlos, join)
+	semi := pb_.next()
+	if st := pc.next(); st.RoomCode != semi.RoomCode || semi.Name1 != "Bea" || semi.Name2 != "Carlos" {
+		t.Fatalf("semifinal: %s-%s en %s y %s", semi.Name1, semi.Name2, semi.RoomCode, st.RoomCode)
+	}
+
+	playOut(t, h.srv, semi.RoomCode)
+	finished := func(st *pb.GameState) bool { return st.Finished }
+	end := pb_.waitState(finished)
+	winner, loser := pb_, pc
+	if end.Winner == 2 {
+		winner, loser = pc, pb_
+	}
+	if err := loser.waitEnd(); status.Convert(err).Message() != "Has quedado eliminado del torneo" {
+		t.Fatalf("perdedor: %v", err)
+	}
+
+	// El ganador sigue en el mismo stream y juega la final contra Ana
+	fresh := func(st *pb.GameState) bool { return !st.Finished }
+	final := pa.next()
+	if st := winner.waitState(fresh); st.RoomCode != final.RoomCode || final.Name1 != "Ana" {
+		t.Fatalf("final: %s-%s en %s y %s", final.Name1, final.Name2, final.RoomCode, st.RoomCode)
+	}
+	b, _ = tc.GetBracket(context.Background(), &pb.TournamentRequest{TournamentId: id})
+	if m := b.Matches[2]; m.Status != pb.BracketMatchStatus_MATCH_PLAYING || m.RoomCode != final.RoomCode {
+		t.Fatalf("final en el cuadro = %v", m)
+	}
+
+	playOut(t, h.srv, final.RoomCode)
+	b, _ = tc.GetBracket(context.Background(), &pb.TournamentRequest{TournamentId: id})
+	if b.Status != pb.TournamentStatus_TOURNAMENT_FINISHED || b.Champion == "" {
+		t.Fatalf("cuadro final = %v", b)
+	}
+	for _, p := range []*player{pa, winner} {
+		err := status.Convert(p.waitEnd()).Message()
+		if err != "Has ganado el torneo" && err != "Torneo terminado: gana "+b.Champion {
+			t.Fatalf("fin del torneo: %q", err)
+		}
+	}
+}
+
+func TestTournamentWalkover(t *testing.T) {
+	h := newHarness(t, Config{ManualTicks: true, Clock: clock.NewManual(t0), PointsToWin: 1, SeriesLength: 3})
+	tc := pb.NewTournamentClient(h.conn)
+	ana, bea := h.login("Ana"), h.login("Bea")
+	b, _ := tc.CreateTournament(authed(t, ana), &pb.CreateTournamentRequest{Format: pb.TournamentFormat_ROUND_ROBIN})
+	id := &pb.TournamentRequest{TournamentId: b.TournamentId}
+	tc.JoinTournament(authed(t, ana), id)
+	tc.JoinTournament(authed(t, bea), id)
+	if _, err := tc.StartTournament(authed(t, ana), id); err != nil {
+		t.Fatal(err)
+	}
+
+	join := &pb.GameAction{TournamentId: b.TournamentId}
+	pa, pb_ := h.playWith(ana, join), h.playWith(bea, join)
+	first := pa.next()
+	pb_.next()
+	// El cuadro no sabe de series: el partido es a un solo juego
+	if first.SeriesLength != 1 {
+		t.Fatalf("SeriesLength = %d", first.SeriesLength)
+	}
+
+	// Si Bea se va, Ana gana el partido y con él la liga
+	pb_.cancel()
+	waitSeats(t, h.srv, first.RoomCode, 1)
+	h.srv.Step(first.RoomCode, 1)
+	if st := pa.waitState(func(st *pb.GameState) bool { return st.Finished }); st.Winner != 1 {
+		t.Fatalf("ganador = %d", st.Winner)
+	}
+	if err := pa.waitEnd(); status.Convert(err).Message() != "Has ganado el torneo" {
+		t.Fatalf("err = %v", err)
+	}
+	b, _ = tc.GetBracket(context.Background(), id)
+	if b.Champion != "Ana" || len(b.Standings) != 2 || b.Standings[0].Wins != 1 {
+		t.Fatalf("cuadro = %v", b)
+	}
+}
+
+func TestTournamentNoShow(t *testing.T) {
+	clk := clock.NewManual(t0)
+	h := newHarness(t, Config{ManualTicks: true, Clock: clk, PointsToWin: 1, WalkoverTimeout: time.Minute})
+	tc := pb.NewTournamentClient(h.conn)
+	ana, bea := h.login("Ana"), h.login("Bea")
+	b, _ := tc.CreateTournament(authed(t, ana), &pb.CreateTournamentRequest{})
+	id := &pb.TournamentRequest{TournamentId: b.TournamentId}
+	tc.JoinTournament(authed(t, ana), id)
+	tc.JoinTournament(authed(t, bea), id)
+	if _, err := tc.StartTournament(authed(t, ana), id); err != nil {
+		t.Fatal(err)
+	}
+
+	// Bea se inscribe pero no entra: pasado el plazo, Ana gana sin jugar
+	waiters := clk.Waiters()
+	pa := h.playWith(ana, &pb.GameAction{TournamentId: b.TournamentId})
+	clk.BlockUntil(waiters + 1)
+	clk.Advance(time.Minute - time.Second)
+	b, _ = tc.GetBracket(context.Background(), id)
+	if b.Status == pb.TournamentStatus_TOURNAMENT_FINISHED {
+		t.Fatal("walkover antes de tiempo")
+	}
+	clk.Advance(time.Second)
+	if err := pa.waitEnd(); status.Convert(err).Message() != "Has ganado el torneo" {
+		t.Fatalf("err = %v", err)
+	}
+	b, _ = tc.GetBracket(context.Background(), id)
+	if m := b.Matches[0]; !m.Walkover || m.Winner != 1 || b.Champion != "Ana" {
+		t.Fatalf("cuadro = %v", b)
+	}
+}
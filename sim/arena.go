@@ -0,0 +1,212 @@
+package sim
+
+import pb "JuegoCeN/proto"
+
+// Una arena es una sala de hasta cuatro jugadores con una pala por lado
+// (GameState.Paddles). Los lados sin pala son paredes. Cada gol lo marca
+// quien tocó la bola por última vez; si nadie la tocó desde el saque o es
+// un gol en propia puerta, el punto es para todos los demás, como en el
+// juego clásico, donde el rival puntúa siempre.
+//
+// En la arena por equipos (NewTeamArena) hay dos palas por lado, una
+// delante de otra, e izquierda juega contra derecha: el gol suma al equipo
+// contrario en Score1 o Score2, como en el clásico, y además al marcador
+// propio de quien tocó la bola por última vez si es de ese equipo.
+
+// arenaSides es el orden en que se ocupan los lados según los jugadores.
+var arenaSides = []pb.Side{pb.Side_LEFT, pb.Side_RIGHT, pb.Side_TOP, pb.Side_BOTTOM}
+
+// MaxArenaPlayers es el número máximo de palas de una arena.
+const MaxArenaPlayers = 4
+
+// NewArena devuelve el estado de saque de una arena de n jugadores (2 a 4):
+// bola en el centro y una pala centrada en cada uno de los n primeros lados
+// de izquierda, derecha, arriba y abajo.
+func NewArena(roomCode string, n int) *pb.GameState {
+	if n > MaxArenaPlayers {
+		n = MaxArenaPlayers
+	}
+	st := &pb.GameState{
+		RoomCode: roomCode,
+		Ball:     &pb.Vector{X: 0.5, Y: 0.5},
+	}
+	for _, side := range arenaSides[:n] {
+		pos := &pb.Vector{X: 0.5, Y: 0.5}
+		switch side {
+		case pb.Side_LEFT:
+			pos.X = 0.1
+		case pb.Side_RIGHT:
+			pos.X = 0.9
+		case pb.Side_TOP:
+			pos.Y = 0.1
+		case pb.Side_BOTTOM:
+			pos.Y = 0.9
+		}
+		st.Paddles = append(st.Paddles, &pb.Paddle{Side: side, Pos: pos})
+	}
+	return st
+}
+
+// NewTeamArena devuelve el estado de saque de una arena de dos contra dos:
+// los asientos 1 y 2 defienden la izquierda (en x = 0.1 y 0.3) y los 3 y 4
+// la derecha (en x = 0.9 y 0.7).
+func NewTeamArena(roomCode string) *pb.GameState {
+	st := &pb.GameState{
+		RoomCode: roomCode,
+		Ball:     &pb.Vector{X: 0.5, Y: 0.5},
+	}
+	for _, x := range []float32{0.1, 0.3, 0.9, 0.7} {
+		side := pb.Side_LEFT
+		if x > 0.5 {
+			side = pb.Side_RIGHT
+		}
+		st.Paddles = append(st.Paddles, &pb.Paddle{Side: side, Pos: &pb.Vector{X: x, Y: 0.5}})
+	}
+	return st
+}
+
+// Teams indica si el estado es de una arena por equipos: con palas que
+// comparten lado.
+func Teams(st *pb.GameState) bool {
+	var seen [MaxArenaPlayers]bool
+	for _, p := range st.Paddles {
+		if seen[p.Side] {
+			return true
+		}
+		seen[p.Side] = true
+	}
+	return false
+}
+
+// Horizontal indica si la pala se mueve en X (arriba y abajo).
+func Horizontal(side pb.Side) bool {
+	return side == pb.Side_TOP || side == pb.Side_BOTTOM
+}
+
+func applyArenaMove(st *pb.GameState, player, dir int32, r Rules) {
+	if player < 1 || int(player) > len(st.Paddles) {
+		return
+	}
+	p := st.Paddles[player-1]
+	axis := &p.Pos.Y
+	if Horizontal(p.Side) {
+		axis = &p.Pos.X
+	}
+	*axis += float32(dir) * r.PaddleDelta
+	if *axis < 0 {
+		*axis = 0
+	} else if *axis > 1 {
+		*axis = 1
+	}
+}
+
+func stepArena(st *pb.GameState, vel *Velocity, r Rules) {
+	// Las palas de arriba y abajo son las de los lados giradas: su largo
+	// va en X y su grosor en Y
+	halfThickX := r.PaddleW / (2 * r.ScreenW)
+	halfThickY := r.PaddleW / (2 * r.ScreenH)
+	halfLenX := (r.PaddleH/2 + r.BallRadius) / r.ScreenW
+	halfLenY := (r.PaddleH/2 + r.BallRadius) / r.ScreenH
+	ballRadX := r.BallRadius / r.ScreenW
+	ballRadY := r.BallRadius / r.ScreenH
+
+	st.Ball.X += vel.X
+	st.Ball.Y += vel.Y
+
+	// 1) Paredes en los lados sin pala, solo si la bola va hacia ellas
+	var guarded [MaxArenaPlayers]bool
+	for _, p := range st.Paddles {
+		guarded[p.Side] = true
+	}
+	if (!guarded[pb.Side_LEFT] && st.Ball.X <= ballRadX && vel.X < 0) ||
+		(!guarded[pb.Side_RIGHT] && st.Ball.X >= 1-ballRadX && vel.X > 0) {
+		vel.X = -vel.X
+	}
+	if (!guarded[pb.Side_TOP] && st.Ball.Y <= ballRadY && vel.Y < 0) ||
+		(!guarded[pb.Side_BOTTOM] && st.Ball.Y >= 1-ballRadY && vel.Y > 0) {
+		vel.Y = -vel.Y
+	}
+
+	// 2) Palas: la bola rebota si va hacia la pala y en este tick ha
+	// llegado a su cara sin haber pasado antes de la cara de atrás (así la
+	// pala delantera de un equipo no recoge bolas que ya la han
+	// rebasado), dentro de su largo
+	for i, p := range st.Paddles {
+		hit := false
+		switch p.Side {
+		case pb.Side_LEFT:
+			edge, d := p.Pos.X+halfThickX, st.Ball.Y-p.Pos.Y
+			before := st.Ball.X - vel.X - ballRadX
+			if hit = vel.X < 0 && st.Ball.X-ballRadX <= edge && before >= p.Pos.X-halfThickX &&
+				d < halfLenY && -d < halfLenY; hit {
+				st.Ball.X, vel.X = edge+ballRadX, -vel.X
+			}
+		case pb.Side_RIGHT:
+			edge, d := p.Pos.X-halfThickX, st.Ball.Y-p.Pos.Y
+			before := st.Ball.X - vel.X + ballRadX
+			if hit = vel.X > 0 && st.Ball.X+ballRadX >= edge && before <= p.Pos.X+halfThickX &&
+				d < halfLenY && -d < halfLenY; hit {
+				st.Ball.X, vel.X = edge-ballRadX, -vel.X
+			}
+		case pb.Side_TOP:
+			edge, d := p.Pos.Y+halfThickY, st.Ball.X-p.Pos.X
+			before := st.Ball.Y - vel.Y - ballRadY
+			if hit = vel.Y < 0 && st.Ball.Y-ballRadY <= edge && before >= p.Pos.Y-halfThickY &&
+				d < halfLenX && -d < halfLenX; hit {
+				st.Ball.Y, vel.Y = edge+ballRadY, -vel.Y
+			}
+		case pb.Side_BOTTOM:
+			edge, d := p.Pos.Y-halfThickY, st.Ball.X-p.Pos.X
+			before := st.Ball.Y - vel.Y + ballRadY
+			if hit = vel.Y > 0 && st.Ball.Y+ballRadY >= edge && before <= p.Pos.Y+halfThickY &&
+				d < halfLenX && -d < halfLenX; hit {
+				st.Ball.Y, vel.Y = edge-ballRadY, -vel.Y
+			}
+		}
+		if hit {
+			st.LastHit = int32(i + 1)
+		}
+	}
+
+	// 3) Gol: la bola ha salido por un lado
+	var conceded pb.Side
+	switch {
+	case st.Ball.X < 0:
+		conceded = pb.Side_LEFT
+	case st.Ball.X > 1:
+		conceded = pb.Side_RIGHT
+	case st.Ball.Y < 0:
+		conceded = pb.Side_TOP
+	case st.Ball.Y > 1:
+		conceded = pb.Side_BOTTOM
+	default:
+		return
+	}
+	if !guarded[conceded] {
+		// Una bola muy rápida puede pasar la pared: la rebota el tick
+		// siguiente
+		return
+	}
+	h := st.LastHit
+	switch {
+	case Teams(st):
+		if conceded == pb.Side_LEFT {
+			st.Score2++
+		} else {
+			st.Score1++
+		}
+		if h > 0 && st.Paddles[h-1].Side != conceded {
+			st.Paddles[h-1].Score++
+		}
+	case h > 0 && st.Paddles[h-1].Side != conceded:
+		st.Paddles[h-1].Score++
+	default:
+		for _, p := range st.Paddles {
+			if p.Side != conceded {
+				p.Score++
+			}
+		}
+	}
+	st.Ball.X, st.Ball.Y = 0.5, 0.5
+	st.LastHit = 0
+}
@@ -0,0 +1,142 @@
+package sim
+
+import (
+	"math/rand"
+	"testing"
+
+	pb "JuegoCeN/proto"
+)
+
+func scores(st *pb.GameState) []int32 {
+	out := make([]int32, len(st.Paddles))
+	for i, p := range st.Paddles {
+		out[i] = p.Score
+	}
+	return out
+}
+
+// shoot lanza la bola desde el centro con la velocidad dada hasta que hay
+// gol, y devuelve los marcadores.
+func shoot(t *testing.T, st *pb.GameState, vel Velocity) []int32 {
+	t.Helper()
+	before := scores(st)
+	for i := 0; i < 1000; i++ {
+		Step(st, &vel, Classic)
+		for j, s := range scores(st) {
+			if s != before[j] {
+				return scores(st)
+			}
+		}
+	}
+	t.Fatal("no hubo gol")
+	return nil
+}
+
+func TestArenaScoring(t *testing.T) {
+	// Sin tocarla, el gol da un punto a cada rival del lado que lo encaja
+	st := NewArena("A", 4)
+	st.Paddles[1].Pos.Y = 0 // la pala derecha se aparta
+	if got := shoot(t, st, Velocity{X: 0.01}); got[0] != 1 || got[1] != 0 || got[2] != 1 || got[3] != 1 {
+		t.Fatalf("sin toque: %v", got)
+	}
+
+	// La bola la devuelve la pala de abajo y entra por arriba: punto solo
+	// para abajo
+	st = NewArena("A", 4)
+	st.Paddles[2].Pos.X = 0
+	if got := shoot(t, st, Velocity{Y: 0.01}); got[3] != 1 || got[0]+got[1]+got[2] != 0 {
+		t.Fatalf("tras tocar abajo: %v", got)
+	}
+	if st.LastHit != 0 || st.Ball.X != 0.5 || st.Ball.Y != 0.5 {
+		t.Fatalf("tras el gol: toque %d, bola %v", st.LastHit, st.Ball)
+	}
+
+	// En propia puerta puntúan los demás
+	st = NewArena("A", 4)
+	st.LastHit = 1
+	st.Paddles[0].Pos.Y = 0
+	if got := shoot(t, st, Velocity{X: -0.01}); got[0] != 0 || got[1] != 1 || got[2] != 1 || got[3] != 1 {
+		t.Fatalf("en propia puerta: %v", got)
+	}
+}
+
+func TestArenaWalls(t *testing.T) {
+	// Con tres jugadores el lado de abajo es pared
+	st := NewArena("A", 3)
+	vel := Velocity{Y: 0.01}
+	for i := 0; i < 60; i++ {
+		Step(st, &vel, Classic)
+	}
+	if vel.Y >= 0 || st.Ball.Y > 1 {
+		t.Fatalf("la bola no rebotó abajo: y = %v, vel %v", st.Ball.Y, vel)
+	}
+
+	// Con dos es el juego clásico: palas a los lados y paredes arriba y abajo
+	if st := NewArena("A", 2); len(st.Paddles) != 2 || st.Paddles[1].Side != pb.Side_RIGHT {
+		t.Fatalf("arena de dos: %v", st.Paddles)
+	}
+}
+
+// TestArenaProperties comprueba con acciones aleatorias que las palas no
+// salen de [0,1], que cada gol suma como mucho un punto por jugador y que
+// la bola no se escapa del campo.
+func TestArenaProperties(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		n := 2 + i%3
+		st := NewArena("PROP", n)
+		vel := Velocity{X: rng.Float32()*0.04 - 0.02, Y: rng.Float32()*0.04 - 0.02}
+		for tick := 0; tick < ticksPerCase; tick++ {
+			ApplyMove(st, int32(rng.Intn(n+1)), int32(rng.Intn(3)-1), Classic)
+			before := scores(st)
+			Step(st, &vel, Classic)
+			for j, p := range st.Paddles {
+				if !(p.Pos.X >= 0 && p.Pos.X <= 1 && p.Pos.Y >= 0 && p.Pos.Y <= 1) {
+					t.Fatalf("tick %d: pala %d en %v", tick, j+1, p.Pos)
+				}
+				if d := p.Score - before[j]; d < 0 || d > 1 {
+					t.Fatalf("tick %d: marcador %v -> %v", tick, before, scores(st))
+				}
+			}
+			if st.Ball.X < -0.05 || st.Ball.X > 1.05 || st.Ball.Y < -0.05 || st.Ball.Y > 1.05 {
+				t.Fatalf("tick %d: bola fuera: %v", tick, st.Ball)
+			}
+		}
+	}
+}
+
+func TestTeamArena(t *testing.T) {
+	st := NewTeamArena("E")
+	if !Teams(st) || Teams(NewArena("A", 4)) {
+		t.Fatal("Teams no distingue la arena por equipos")
+	}
+
+	// La bola que ya ha rebasado al delantero (asiento 2, en x = 0.3) la
+	// devuelve el defensa (asiento 1), no el delantero desde atrás
+	st.Ball.X = 0.25
+	vel := Velocity{X: -0.01}
+	for i := 0; i < 30 && st.LastHit == 0; i++ {
+		Step(st, &vel, Classic)
+	}
+	if st.LastHit != 1 {
+		t.Fatalf("la tocó el asiento %d", st.LastHit)
+	}
+
+	// Sale por la derecha: punto para la izquierda y para el defensa; el
+	// delantero de la derecha se aparta para dejarla pasar
+	st.Paddles[2].Pos.Y, st.Paddles[3].Pos.Y = 0, 0
+	if got := shoot(t, st, vel); got[0] != 1 || got[1]+got[2]+got[3] != 0 || st.Score1 != 1 || st.Score2 != 0 {
+		t.Fatalf("marcador %d-%d, jugadores %v", st.Score1, st.Score2, got)
+	}
+
+	// Sin tocarla por la izquierda: punto solo para el equipo de la derecha
+	st.Paddles[0].Pos.Y, st.Paddles[1].Pos.Y = 0, 0
+	before := scores(st)
+	vel = Velocity{X: -0.01}
+	for st.Score2 == 0 {
+		Step(st, &vel, Classic)
+	}
+	if got := scores(st); st.Score1 != 1 || got[2] != before[2] || got[3] != before[3] {
+		t.Fatalf("marcador %d-%d, jugadores %v", st.Score1, st.Score2, got)
+	}
+}
@@ -0,0 +1,228 @@
+package sim
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	pb "JuegoCeN/proto"
+)
+
+// Mapas de los duelos (GameState.Map): paredes y obstáculos además de las
+// bandas, y porterías que pueden no ocupar todo el lado. Se definen en
+// ficheros JSON con la forma de pb.FieldMap, por ejemplo:
+//
+//	{
+//	  "name": "pilares",
+//	  "goal_width": 0.6,
+//	  "obstacles": [{"pos": {"X": 0.5, "Y": 0.25}, "w": 0.03, "h": 0.15}]
+//	}
+
+// ParseMap lee la definición de un mapa y comprueba que se pueda jugar: la
+// bola tiene que poder sacar desde el centro. Los obstáculos móviles
+// empiezan en from.
+func ParseMap(data []byte) (*pb.FieldMap, error) {
+	m := &pb.FieldMap{}
+	if err := protojson.Unmarshal(data, m); err != nil {
+		return nil, err
+	}
+	if m.Name == "" {
+		return nil, errors.New("el mapa no tiene nombre")
+	}
+	if m.GoalWidth < 0 || m.GoalWidth > 1 {
+		return nil, fmt.Errorf("mapa %s: goal_width %v fuera de [0,1]", m.Name, m.GoalWidth)
+	}
+	for i, w := range m.Walls {
+		if w.A == nil || w.B == nil || (w.A.X == w.B.X && w.A.Y == w.B.Y) {
+			return nil, fmt.Errorf("mapa %s: la pared %d no tiene dos extremos distintos", m.Name, i+1)
+		}
+	}
+	serve := &pb.Vector{X: 0.5, Y: 0.5}
+	for i, o := range m.Obstacles {
+		if o.W <= 0 || o.H <= 0 {
+			return nil, fmt.Errorf("mapa %s: el obstáculo %d no tiene tamaño", m.Name, i+1)
+		}
+		places := []*pb.Vector{o.Pos}
+		switch {
+		case o.Period < 0 || o.Period == 1:
+			return nil, fmt.Errorf("mapa %s: el obstáculo %d tiene un periodo de %d ticks", m.Name, i+1, o.Period)
+		case o.Period > 0 && (o.From == nil || o.To == nil):
+			return nil, fmt.Errorf("mapa %s: el obstáculo móvil %d necesita from y to", m.Name, i+1)
+		case o.Period > 0:
+			o.Pos = &pb.Vector{X: o.From.X, Y: o.From.Y}
+			places = []*pb.Vector{o.From, o.To}
+		case o.Pos == nil:
+			return nil, fmt.Errorf("mapa %s: el obstáculo %d no tiene posición", m.Name, i+1)
+		}
+		if covers(o, places, serve, Classic) {
+			return nil, fmt.Errorf("mapa %s: el obstáculo %d tapa el saque", m.Name, i+1)
+		}
+	}
+	return m, nil
+}
+
+// covers indica si el obstáculo, en su recorrido entre places, llega a
+// tocar una bola en p.
+func covers(o *pb.Obstacle, places []*pb.Vector, p *pb.Vector, r Rules) bool {
+	minX, maxX := places[0].X, places[0].X
+	minY, maxY := places[0].Y, places[0].Y
+	for _, q := range places[1:] {
+		minX, maxX = min(minX, q.X), max(maxX, q.X)
+		minY, maxY = min(minY, q.Y), max(maxY, q.Y)
+	}
+	hx := o.W/2 + r.BallRadius/r.ScreenW
+	hy := o.H/2 + r.BallRadius/r.ScreenH
+	return p.X > minX-hx && p.X < maxX+hx && p.Y > minY-hy && p.Y < maxY+hy
+}
+
+// LoadMaps lee los mapas *.json de dir, indexados por nombre.
+func LoadMaps(dir string) (map[string]*pb.FieldMap, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	maps := map[string]*pb.FieldMap{}
+	for _, f := range files {
+		data, err := os.ReadFile(f)
+		if err != nil {
+			return nil, err
+		}
+		m, err := ParseMap(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", f, err)
+		}
+		if maps[m.Name] != nil {
+			return nil, fmt.Errorf("%s: ya hay otro mapa %s", f, m.Name)
+		}
+		maps[m.Name] = m
+	}
+	return maps, nil
+}
+
+// stepMap avanza un tick los obstáculos móviles: van de from a to en
+// medio periodo y vuelven en el otro medio.
+func stepMap(m *pb.FieldMap) {
+	m.Tick++
+	for _, o := range m.Obstacles {
+		if o.Period == 0 {
+			continue
+		}
+		half := float32(o.Period) / 2
+		f := float32(m.Tick%o.Period) / half
+		if f > 1 {
+			f = 2 - f
+		}
+		o.Pos.X = o.From.X + (o.To.X-o.From.X)*f
+		o.Pos.Y = o.From.Y + (o.To.Y-o.From.Y)*f
+	}
+}
+
+// collideMap resuelve el choque de la bola con las paredes y obstáculos
+// del mapa. Como la bola puede avanzar en un tick más que su radio, se
+// comprueba en varios puntos del camino desde (fromX, fromY); en el primer
+// choque la bola se queda allí, fuera del obstáculo, con la velocidad
+// reflejada.
+func collideMap(m *pb.FieldMap, ball *pb.Vector, fromX, fromY float32, vel *Velocity, r Rules) {
+	if len(m.Walls) == 0 && len(m.Obstacles) == 0 {
+		return
+	}
+	toX, toY := ball.X, ball.Y
+	dist := math.Hypot(float64((toX-fromX)*r.ScreenW), float64((toY-fromY)*r.ScreenH))
+	n := int(math.Ceil(dist / float64(r.BallRadius/2)))
+	for k := 1; k <= n; k++ {
+		f := float32(k) / float32(n)
+		ball.X = fromX + (toX-fromX)*f
+		ball.Y = fromY + (toY-fromY)*f
+		for _, w := range m.Walls {
+			if bounceWall(w, ball, vel, r) {
+				return
+			}
+		}
+		for _, o := range m.Obstacles {
+			if bounceObstacle(o, ball, vel, r) {
+				return
+			}
+		}
+	}
+	ball.X, ball.Y = toX, toY
+}
+
+// bounceWall hace rebotar la bola en la pared si la toca yendo hacia ella.
+// Las cuentas van en píxeles para que el rebote respete los ángulos.
+func bounceWall(w *pb.Segment, ball *pb.Vector, vel *Velocity, r Rules) bool {
+	W, H := float64(r.ScreenW), float64(r.ScreenH)
+	px, py := float64(ball.X)*W, float64(ball.Y)*H
+	ax, ay := float64(w.A.X)*W, float64(w.A.Y)*H
+	dx, dy := float64(w.B.X)*W-ax, float64(w.B.Y)*H-ay
+
+	// Punto de la pared más cercano al centro de la bola
+	t := ((px-ax)*dx + (py-ay)*dy) / (dx*dx + dy*dy)
+	t = math.Max(0, math.Min(1, t))
+	cx, cy := ax+t*dx, ay+t*dy
+	nx, ny := px-cx, py-cy
+	d := math.Hypot(nx, ny)
+	rad := float64(r.BallRadius)
+	if d >= rad || d == 0 {
+		return false
+	}
+	nx, ny = nx/d, ny/d
+	vx, vy := float64(vel.X)*W, float64(vel.Y)*H
+	dot := vx*nx + vy*ny
+	if dot >= 0 {
+		return false // ya se aleja
+	}
+	vel.X = float32((vx - 2*dot*nx) / W)
+	vel.Y = float32((vy - 2*dot*ny) / H)
+	ball.X = float32((cx + nx*rad) / W)
+	ball.Y = float32((cy + ny*rad) / H)
+	return true
+}
+
+// bounceObstacle saca la bola del obstáculo por el lado en que menos ha
+// entrado y, si iba hacia él, le invierte la velocidad en ese eje.
+func bounceObstacle(o *pb.Obstacle, ball *pb.Vector, vel *Velocity, r Rules) bool {
+	hx := o.W/2 + r.BallRadius/r.ScreenW
+	hy := o.H/2 + r.BallRadius/r.ScreenH
+	dx, dy := ball.X-o.Pos.X, ball.Y-o.Pos.Y
+	if dx <= -hx || dx >= hx || dy <= -hy || dy >= hy {
+		return false
+	}
+	sx, sy := float32(1), float32(1)
+	if dx < 0 {
+		sx = -1
+	}
+	if dy < 0 {
+		sy = -1
+	}
+	if (hx-sx*dx)*r.ScreenW < (hy-sy*dy)*r.ScreenH {
+		ball.X = o.Pos.X + sx*hx
+		if vel.X*sx < 0 {
+			vel.X = -vel.X
+		}
+	} else {
+		ball.Y = o.Pos.Y + sy*hy
+		if vel.Y*sy < 0 {
+			vel.Y = -vel.Y
+		}
+	}
+	return true
+}
+
+// bounceGoalLine hace de pared la parte de cada lado que queda fuera de
+// la portería.
+func bounceGoalLine(m *pb.FieldMap, ball *pb.Vector, vel *Velocity, r Rules) {
+	if m.GoalWidth == 0 || (ball.Y-0.5 < m.GoalWidth/2 && 0.5-ball.Y < m.GoalWidth/2) {
+		return
+	}
+	radX := r.BallRadius / r.ScreenW
+	switch {
+	case ball.X-radX < 0 && vel.X < 0:
+		ball.X, vel.X = radX, -vel.X
+	case ball.X+radX > 1 && vel.X > 0:
+		ball.X, vel.X = 1-radX, -vel.X
+	}
+}
@@ -0,0 +1,137 @@
+package sim
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	pb "JuegoCeN/proto"
+)
+
+func TestParseMapErrors(t *testing.T) {
+	for _, tc := range []struct{ json, err string }{
+		{`{"goal_width": 0.5}`, "no tiene nombre"},
+		{`{"name": "a", "goal_width": 1.5}`, "goal_width"},
+		{`{"name": "a", "walls": [{"a": {"X": 0.1}, "b": {"X": 0.1}}]}`, "pared 1"},
+		{`{"name": "a", "obstacles": [{"pos": {"X": 0.2}}]}`, "no tiene tamaño"},
+		{`{"name": "a", "obstacles": [{"w": 0.1, "h": 0.1}]}`, "no tiene posición"},
+		{`{"name": "a", "obstacles": [{"w": 0.1, "h": 0.1, "period": 60, "to": {"X": 0.2}}]}`, "from y to"},
+		{`{"name": "a", "obstacles": [{"pos": {"X": 0.5, "Y": 0.5}, "w": 0.1, "h": 0.1}]}`, "tapa el saque"},
+		// Un móvil que pasa por el centro también lo tapa
+		{`{"name": "a", "obstacles": [{"w": 0.05, "h": 0.05, "period": 60,
+			"from": {"X": 0.5, "Y": 0.1}, "to": {"X": 0.5, "Y": 0.9}}]}`, "tapa el saque"},
+		{`{"name": "a", "portería": 3}`, "unknown field"},
+	} {
+		if _, err := ParseMap([]byte(tc.json)); err == nil || !strings.Contains(err.Error(), tc.err) {
+			t.Errorf("%s: err = %v, se esperaba %q", tc.json, err, tc.err)
+		}
+	}
+}
+
+func TestShippedMaps(t *testing.T) {
+	maps, err := LoadMaps("../maps")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range []string{"pilares", "esquinas", "ascensores"} {
+		if maps[name] == nil {
+			t.Fatalf("falta el mapa %s", name)
+		}
+	}
+
+	// Con las palas moviéndose al azar la bola nunca queda dentro de un
+	// obstáculo ni fuera del campo, y solo marca por la portería
+	for name, m := range maps {
+		st := NewState("A")
+		st.Map = m
+		vel := Classic.InitialVelocity()
+		rng := rand.New(rand.NewSource(1))
+		goals := 0
+		for i := 0; i < 20000; i++ {
+			ApplyMove(st, int32(rng.Intn(2)+1), int32(rng.Intn(3)-1), Classic)
+			before := st.Score1 + st.Score2
+			y := st.Ball.Y + vel.Y
+			Step(st, &vel, Classic)
+			if st.Score1+st.Score2 != before {
+				goals++
+				if g := m.GoalWidth; g > 0 && (y < 0.5-g/2-0.02 || y > 0.5+g/2+0.02) {
+					t.Fatalf("%s, tick %d: gol con la bola en y=%v", name, i, y)
+				}
+				continue
+			}
+			for j, o := range m.Obstacles {
+				if dx, dy := st.Ball.X-o.Pos.X, st.Ball.Y-o.Pos.Y; dx > -o.W/2 && dx < o.W/2 && dy > -o.H/2 && dy < o.H/2 {
+					t.Fatalf("%s, tick %d: bola %v dentro del obstáculo %d en %v", name, i, st.Ball, j+1, o.Pos)
+				}
+			}
+			if st.Ball.X < -0.05 || st.Ball.X > 1.05 || st.Ball.Y < -0.05 || st.Ball.Y > 1.05 {
+				t.Fatalf("%s, tick %d: bola fuera del campo en %v", name, i, st.Ball)
+			}
+		}
+		if goals == 0 {
+			t.Fatalf("%s: ningún gol en 20000 ticks", name)
+		}
+	}
+}
+
+func TestWallBounce(t *testing.T) {
+	// Pared a 45 grados (en píxeles) que baja hacia la derecha, delante de
+	// la bola: la bola iba hacia la derecha y sale hacia abajo
+	st := NewState("A")
+	st.Map = &pb.FieldMap{Walls: []*pb.Segment{{
+		A: &pb.Vector{X: 0.6, Y: 0.3},
+		B: &pb.Vector{X: 0.6 + 200.0/800, Y: 0.3 + 200.0/600},
+	}}}
+	st.Ball.Y = 0.4
+	vel := Velocity{X: 0.01}
+	for i := 0; i < 100 && vel.Y == 0; i++ {
+		Step(st, &vel, Classic)
+	}
+	if vel.X > 1e-6 || vel.X < -1e-6 || vel.Y <= 0 {
+		t.Fatalf("velocidad tras el rebote = %+v", vel)
+	}
+	// Misma rapidez en píxeles
+	if got := vel.Y * Classic.ScreenH; got < 7.99 || got > 8.01 {
+		t.Fatalf("rapidez %v px/tick, se esperaba 8", got)
+	}
+}
+
+func TestGoalLine(t *testing.T) {
+	st := NewState("A")
+	st.Map = &pb.FieldMap{GoalWidth: 0.4}
+	st.Paddle1.Y = 1 // la pala no estorba
+	st.Ball.Y = 0.15
+	vel := Velocity{X: -0.01}
+	for i := 0; i < 100; i++ {
+		Step(st, &vel, Classic)
+	}
+	if st.Score2 != 0 || vel.X <= 0 {
+		t.Fatalf("fuera de la portería: marcador %d, velocidad %+v", st.Score2, vel)
+	}
+
+	st.Ball.X, st.Ball.Y = 0.5, 0.5
+	vel = Velocity{X: -0.01}
+	for i := 0; i < 100 && st.Score2 == 0; i++ {
+		Step(st, &vel, Classic)
+	}
+	if st.Score2 != 1 {
+		t.Fatal("por la portería no entra")
+	}
+}
+
+func TestMovingObstacle(t *testing.T) {
+	m, err := ParseMap([]byte(`{"name": "a", "obstacles": [{"w": 0.1, "h": 0.1, "period": 100,
+		"from": {"X": 0.2, "Y": 0.2}, "to": {"X": 0.2, "Y": 0.6}}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	o := m.Obstacles[0]
+	for tick, want := range map[int]float32{0: 0.2, 25: 0.4, 50: 0.6, 75: 0.4, 100: 0.2} {
+		mm := &pb.FieldMap{Obstacles: []*pb.Obstacle{{From: o.From, To: o.To, Period: o.Period, Pos: &pb.Vector{}}}}
+		mm.Tick = int32(tick) - 1
+		stepMap(mm)
+		if y := mm.Obstacles[0].Pos.Y; y < want-1e-6 || y > want+1e-6 {
+			t.Fatalf("tick %d: y = %v, se esperaba %v", tick, y, want)
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package sim
+
+import pb "JuegoCeN/proto"
+
+// Layout es la disposición del campo en un modo de juego.
+type Layout int
+
+const (
+	LayoutDuel  Layout = iota // izquierda contra derecha, o contra la pared en la práctica
+	LayoutArena               // una pala por lado (NewArena)
+	LayoutTeams               // dos contra dos, dos palas por lado (NewTeamArena)
+)
+
+// Mode es un modo de juego: cuántos jugadores reúne una sala, cómo se
+// colocan, cuántas bolas hay a la vez y con qué reglas se simula.
+type Mode struct {
+	Name    string // como en GameAction.mode
+	Title   string // nombre para mostrar
+	Players int
+	Layout  Layout
+	Balls   int // más de una van en GameState.Balls (NewMultiBall)
+	Rules   Rules
+}
+
+// Speed son las reglas del modo rápido: bola y palas más rápidas y más
+// pequeñas.
+var Speed = Rules{
+	TickMs:      16,
+	PaddleDelta: 0.03,
+	BallVelX:    0.012,
+	BallVelY:    0.018,
+	ScreenW:     800,
+	ScreenH:     600,
+	PaddleW:     10,
+	PaddleH:     64,
+	BallRadius:  6,
+}
+
+// DefaultModes devuelve un registro nuevo con los modos de serie,
+// indexados por nombre. Cada llamada devuelve una copia que se puede
+// modificar.
+func DefaultModes() map[string]Mode {
+	practice := Classic
+	practice.Wall = true
+	modes := map[string]Mode{}
+	for _, m := range []Mode{
+		{Name: "DUEL", Title: "Clasico", Players: 2, Layout: LayoutDuel, Balls: 1, Rules: Classic},
+		{Name: "SPEED", Title: "Rapido", Players: 2, Layout: LayoutDuel, Balls: 1, Rules: Speed},
+		{Name: "MULTI", Title: "Varias bolas", Players: 2, Layout: LayoutDuel, Balls: 3, Rules: Classic},
+		{Name: "PRACTICE", Title: "Practica", Players: 1, Layout: LayoutDuel, Balls: 1, Rules: practice},
+		{Name: "FOUR", Title: "Cuatro lados", Players: 4, Layout: LayoutArena, Balls: 1, Rules: Classic},
+		{Name: "TEAMS", Title: "Dos contra dos", Players: 4, Layout: LayoutTeams, Balls: 1, Rules: Classic},
+	} {
+		modes[m.Name] = m
+	}
+	return modes
+}
+
+// NewState devuelve el estado de saque del modo, con su descriptor en
+// GameState.Mode.
+func (m Mode) NewState(roomCode string) *pb.GameState {
+	var st *pb.GameState
+	switch {
+	case m.Layout == LayoutArena:
+		st = NewArena(roomCode, m.Players)
+	case m.Layout == LayoutTeams:
+		st = NewTeamArena(roomCode)
+	case m.Balls > 1:
+		st = NewMultiBall(roomCode, m.Balls, m.Rules)
+	default:
+		st = NewState(roomCode)
+	}
+	st.Mode = m.Proto()
+	return st
+}
+
+// Proto devuelve el descriptor del modo que viaja en GameState.Mode.
+func (m Mode) Proto() *pb.GameMode {
+	r := m.Rules
+	return &pb.GameMode{
+		Name:        m.Name,
+		Title:       m.Title,
+		Players:     int32(m.Players),
+		Balls:       int32(m.Balls),
+		TickMs:      r.TickMs,
+		PaddleDelta: r.PaddleDelta,
+		BallVelX:    r.BallVelX,
+		BallVelY:    r.BallVelY,
+		ScreenW:     r.ScreenW,
+		ScreenH:     r.ScreenH,
+		PaddleW:     r.PaddleW,
+		PaddleH:     r.PaddleH,
+		BallRadius:  r.BallRadius,
+		Wall:        r.Wall,
+	}
+}
+
+// RulesOf devuelve las reglas con que se simula st, sacadas de su
+// descriptor de modo, o Classic si no lo tiene (como las repeticiones
+// antiguas).
+func RulesOf(st *pb.GameState) Rules {
+	m := st.GetMode()
+	if m == nil {
+		return Classic
+	}
+	return Rules{
+		TickMs:      m.TickMs,
+		PaddleDelta: m.PaddleDelta,
+		BallVelX:    m.BallVelX,
+		BallVelY:    m.BallVelY,
+		ScreenW:     m.ScreenW,
+		ScreenH:     m.ScreenH,
+		PaddleW:     m.PaddleW,
+		PaddleH:     m.PaddleH,
+		BallRadius:  m.BallRadius,
+		Wall:        m.Wall,
+	}
+}
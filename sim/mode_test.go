@@ -0,0 +1,52 @@
+package sim
+
+import "testing"
+
+func TestDefaultModes(t *testing.T) {
+	modes := DefaultModes()
+	for name, m := range modes {
+		st := m.NewState("A")
+		if m.Name != name || st.Mode.GetName() != name || RulesOf(st) != m.Rules {
+			t.Fatalf("%s: descriptor %v", name, st.Mode)
+		}
+		if m.Balls > 1 && len(st.Balls) != m.Balls {
+			t.Fatalf("%s: %d bolas", name, len(st.Balls))
+		}
+		if m.Layout != LayoutDuel && len(st.Paddles) != m.Players {
+			t.Fatalf("%s: %d palas", name, len(st.Paddles))
+		}
+	}
+
+	// Cada llamada es un registro nuevo
+	modes["DUEL"] = Mode{}
+	if DefaultModes()["DUEL"].Rules != Classic {
+		t.Fatal("el registro de serie ha cambiado")
+	}
+}
+
+func TestPracticeWall(t *testing.T) {
+	m := DefaultModes()["PRACTICE"]
+	st := m.NewState("A")
+	vel := m.Rules.InitialVelocity()
+	returns := int32(0)
+	for i := 0; i < 3000; i++ {
+		// La pala sigue a la bola: nunca falla
+		switch {
+		case st.Paddle1.Y < st.Ball.Y-0.02:
+			ApplyMove(st, 1, 1, m.Rules)
+		case st.Paddle1.Y > st.Ball.Y+0.02:
+			ApplyMove(st, 1, -1, m.Rules)
+		}
+		hit := st.LastHit
+		st.LastHit = 0
+		Step(st, &vel, m.Rules)
+		if st.LastHit == 1 {
+			returns++
+		} else {
+			st.LastHit = hit
+		}
+	}
+	if st.Score2 != 0 || returns == 0 || st.Score1 != returns {
+		t.Fatalf("marcador %d-%d con %d devoluciones", st.Score1, st.Score2, returns)
+	}
+}
@@ -0,0 +1,81 @@
+package sim
+
+import pb "JuegoCeN/proto"
+
+// Modo de varias bolas (GameState.Balls): el duelo clásico con varias
+// bolas a la vez. Salen del centro de una en una y cada gol devuelve al
+// centro la bola que lo marcó, que espera RespawnTicks antes de volver a
+// salir hacia quien lo encajó.
+const (
+	MaxBalls     = 5
+	ServeGap     = 60 // ticks entre los saques iniciales (~1 s)
+	RespawnTicks = 90 // ticks en el centro tras un gol (~1,5 s)
+)
+
+// NewMultiBall devuelve el estado de saque de un duelo con n bolas (de 2 a
+// MaxBalls) a la velocidad de saque de r. Salen alternando hacia cada
+// jugador y hacia arriba y abajo.
+func NewMultiBall(roomCode string, n int, r Rules) *pb.GameState {
+	st := NewState(roomCode)
+	for i := 0; i < n; i++ {
+		vx, vy := r.BallVelX, r.BallVelY
+		if i%2 == 1 {
+			vx = -vx
+		}
+		if i/2%2 == 1 {
+			vy = -vy
+		}
+		st.Balls = append(st.Balls, &pb.Ball{
+			Id:   int32(i + 1),
+			Pos:  &pb.Vector{X: 0.5, Y: 0.5},
+			VelX: vx,
+			VelY: vy,
+			Wait: int32(i * ServeGap),
+		})
+	}
+	return st
+}
+
+// MultiBall indica si la partida es del modo de varias bolas: sus bolas
+// van en GameState.Balls y Ball no se usa. Las bolas transitorias de los
+// potenciadores no cuentan.
+func MultiBall(st *pb.GameState) bool {
+	for _, b := range st.Balls {
+		if !b.Transient {
+			return true
+		}
+	}
+	return false
+}
+
+// stepBalls avanza un tick todas las bolas de GameState.Balls a su
+// velocidad por speed. Las reglas dan el tamaño de las bolas. Tras un gol
+// la bola vuelve al centro o, si es transitoria, desaparece.
+func stepBalls(st *pb.GameState, speed float32, r Rules) {
+	balls := st.Balls[:0]
+	for _, b := range st.Balls {
+		if b.Wait > 0 {
+			b.Wait--
+			balls = append(balls, b)
+			continue
+		}
+		vel := Velocity{X: b.VelX, Y: b.VelY}
+		scorer := stepBall(st, b.Pos, &vel, speed, r)
+		b.VelX, b.VelY = vel.X, vel.Y
+		switch scorer {
+		case 1:
+			st.Score1++
+		case 2:
+			st.Score2++
+		}
+		switch {
+		case scorer == 0:
+			balls = append(balls, b)
+		case !b.Transient:
+			b.Pos.X, b.Pos.Y = 0.5, 0.5
+			b.Wait = RespawnTicks
+			balls = append(balls, b)
+		}
+	}
+	st.Balls = balls
+}
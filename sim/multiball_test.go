@@ -0,0 +1,58 @@
+package sim
+
+import "testing"
+
+func TestMultiBallServes(t *testing.T) {
+	st := NewMultiBall("A", 3, Classic)
+	vel := Velocity{}
+	for i := 0; i < ServeGap; i++ {
+		Step(st, &vel, Classic)
+	}
+	b1, b2, b3 := st.Balls[0], st.Balls[1], st.Balls[2]
+	if b1.Pos.X <= 0.5 || b2.Pos.X != 0.5 || b2.Wait != 0 || b3.Wait != ServeGap {
+		t.Fatalf("bolas tras %d ticks: %v", ServeGap, st.Balls)
+	}
+	Step(st, &vel, Classic)
+	if b2.Pos.X >= 0.5 {
+		t.Fatalf("la segunda bola no sale hacia el jugador 1: %v", b2)
+	}
+	if st.Ball.X != 0.5 || st.Ball.Y != 0.5 {
+		t.Fatalf("Ball se ha movido: %v", st.Ball)
+	}
+}
+
+func TestMultiBallScoring(t *testing.T) {
+	st := NewMultiBall("A", 3, Classic)
+	vel := Velocity{}
+	goals := 0
+	for i := 0; i < 5000 && goals < 10; i++ {
+		// Las palas se mueven solas para que haya golpes y goles
+		ApplyMove(st, 1, []int32{-1, 1}[i/200%2], Classic)
+		ApplyMove(st, 2, []int32{1, -1}[i/150%2], Classic)
+		before := st.Score1 + st.Score2
+		waiting := map[int32]bool{}
+		for _, b := range st.Balls {
+			waiting[b.Id] = b.Wait > 0
+		}
+		Step(st, &vel, Classic)
+		scored := int(st.Score1 + st.Score2 - before)
+
+		// Cada gol devuelve al centro justo la bola que lo marca
+		respawned := 0
+		for _, b := range st.Balls {
+			if !waiting[b.Id] && b.Wait == RespawnTicks {
+				respawned++
+				if b.Pos.X != 0.5 || b.Pos.Y != 0.5 {
+					t.Fatalf("tick %d: bola %d esperando fuera del centro: %v", i, b.Id, b.Pos)
+				}
+			}
+		}
+		if respawned != scored {
+			t.Fatalf("tick %d: %d goles y %d bolas de vuelta al centro", i, scored, respawned)
+		}
+		goals += scored
+	}
+	if goals < 10 {
+		t.Fatalf("solo %d goles", goals)
+	}
+}
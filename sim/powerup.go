@@ -0,0 +1,171 @@
+package sim
+
+import (
+	"math"
+
+	pb "JuegoCeN/proto"
+)
+
+// Potenciadores de las partidas de dos (GameState.PowerUps). Cada cierto
+// tiempo aparece un objeto en el centro del campo; lo recoge quien tocó la
+// bola por última vez cuando una bola pasa por encima. La aparición sale
+// de GameState.Seed, así que una partida se reproduce igual con las mismas
+// acciones.
+const (
+	ItemEvery   = 300 // ticks entre apariciones (~5 s)
+	MaxItems    = 2   // objetos a la vez en el campo
+	EffectTicks = 480 // duración de los efectos (~8 s)
+	ItemRadius  = 12  // radio del objeto en píxeles
+
+	bigPaddle = 1.5 // factor de la pala grande
+	fastBall  = 1.5 // factor de la bola rápida
+)
+
+// EnablePowerUps activa los potenciadores en un estado de saque. seed
+// decide dónde y cuándo aparecen.
+func EnablePowerUps(st *pb.GameState, seed uint64) {
+	st.PowerUps = true
+	st.Seed = seed | 1 // xorshift no sale nunca de cero
+	st.NextItemIn = ItemEvery
+}
+
+// random avanza el generador del estado (xorshift64).
+func random(st *pb.GameState) uint64 {
+	x := st.Seed
+	x ^= x << 13
+	x ^= x >> 7
+	x ^= x << 17
+	st.Seed = x
+	return x
+}
+
+// randomIn devuelve un valor de [lo, hi) sacado del generador del estado.
+func randomIn(st *pb.GameState, lo, hi float32) float32 {
+	return lo + (hi-lo)*float32(random(st)>>40)/(1<<24)
+}
+
+// active indica si el jugador tiene en curso un efecto del tipo dado.
+func active(st *pb.GameState, kind pb.PowerUpKind, player int32) bool {
+	for _, e := range st.Effects {
+		if e.Kind == kind && (player == 0 || e.Player == player) {
+			return true
+		}
+	}
+	return false
+}
+
+// PaddleScale es el factor del largo de la pala del jugador (1 o 2).
+func PaddleScale(st *pb.GameState, player int32) float32 {
+	if active(st, pb.PowerUpKind_BIG_PADDLE, player) {
+		return bigPaddle
+	}
+	return 1
+}
+
+func ballSpeed(st *pb.GameState) float32 {
+	if active(st, pb.PowerUpKind_FAST_BALL, 0) {
+		return fastBall
+	}
+	return 1
+}
+
+func reversed(st *pb.GameState, player int32) bool {
+	return active(st, pb.PowerUpKind_REVERSE, player)
+}
+
+// stepPowerUps avanza los potenciadores un tick, después de la bola
+// principal: efectos, bolas adicionales, apariciones y recogidas.
+func stepPowerUps(st *pb.GameState, speed float32, r Rules) {
+	// 1) Los efectos se agotan
+	effects := st.Effects[:0]
+	for _, e := range st.Effects {
+		if e.TicksLeft--; e.TicksLeft > 0 {
+			effects = append(effects, e)
+		}
+	}
+	st.Effects = effects
+
+	// 2) Las bolas adicionales marcan como la principal, pero al hacerlo
+	// desaparecen
+	stepBalls(st, speed, r)
+
+	// 3) Aparece un objeto nuevo en la zona central
+	if st.NextItemIn--; st.NextItemIn <= 0 {
+		st.NextItemIn = ItemEvery
+		if len(st.Items) < MaxItems {
+			st.Items = append(st.Items, &pb.PowerUp{
+				Kind: pb.PowerUpKind(random(st) % 4),
+				Pos:  &pb.Vector{X: randomIn(st, 0.3, 0.7), Y: randomIn(st, 0.15, 0.85)},
+			})
+		}
+	}
+
+	// 4) Una bola que pasa por encima lo entrega a quien la tocó el último
+	if st.LastHit == 0 {
+		return
+	}
+	reach := float64(ItemRadius + r.BallRadius)
+	items := st.Items[:0]
+	for _, it := range st.Items {
+		taken := false
+		for _, b := range append([]*pb.Vector{st.Ball}, ballPositions(st.Balls)...) {
+			dx := float64((b.X - it.Pos.X) * r.ScreenW)
+			dy := float64((b.Y - it.Pos.Y) * r.ScreenH)
+			if math.Hypot(dx, dy) < reach {
+				taken = true
+				break
+			}
+		}
+		if taken {
+			collect(st, it.Kind, st.LastHit, r)
+		} else {
+			items = append(items, it)
+		}
+	}
+	st.Items = items
+}
+
+func ballPositions(balls []*pb.Ball) []*pb.Vector {
+	out := make([]*pb.Vector, len(balls))
+	for i, b := range balls {
+		out[i] = b.Pos
+	}
+	return out
+}
+
+// collect aplica el objeto recogido por player. Los efectos con duración
+// se renuevan si ya estaban en curso.
+func collect(st *pb.GameState, kind pb.PowerUpKind, player int32, r Rules) {
+	if kind == pb.PowerUpKind_MULTI_BALL {
+		// Dos bolas hacia el campo del rival, una hacia arriba y otra
+		// hacia abajo
+		vx := r.BallVelX
+		if player == 2 {
+			vx = -vx
+		}
+		for _, vy := range []float32{-r.BallVelY, r.BallVelY} {
+			var id int32
+			for _, b := range st.Balls {
+				if b.Id > id {
+					id = b.Id
+				}
+			}
+			st.Balls = append(st.Balls, &pb.Ball{
+				Id: id + 1, Pos: &pb.Vector{X: 0.5, Y: 0.5}, VelX: vx, VelY: vy, Transient: true,
+			})
+		}
+		return
+	}
+
+	target := player
+	if kind == pb.PowerUpKind_REVERSE {
+		target = 3 - player
+	}
+	for _, e := range st.Effects {
+		if e.Kind == kind && e.Player == target {
+			e.TicksLeft = EffectTicks
+			return
+		}
+	}
+	st.Effects = append(st.Effects, &pb.Effect{Kind: kind, Player: target, TicksLeft: EffectTicks})
+}
@@ -0,0 +1,83 @@
+package sim
+
+import (
+	"testing"
+
+	pb "JuegoCeN/proto"
+)
+
+func TestItemsSpawnFromSeed(t *testing.T) {
+	a, b := NewState("A"), NewState("B")
+	EnablePowerUps(a, 7)
+	EnablePowerUps(b, 7)
+	va, vb := Classic.InitialVelocity(), Classic.InitialVelocity()
+	for i := 0; i < ItemEvery; i++ {
+		Step(a, &va, Classic)
+		Step(b, &vb, Classic)
+	}
+	if len(a.Items) != 1 || a.Items[0].Kind != b.Items[0].Kind || a.Items[0].Pos.X != b.Items[0].Pos.X {
+		t.Fatalf("objetos %v y %v con la misma semilla", a.Items, b.Items)
+	}
+	if x := a.Items[0].Pos.X; x < 0.3 || x >= 0.7 {
+		t.Fatalf("objeto fuera de la zona central: %v", a.Items[0].Pos)
+	}
+}
+
+// pickUp coloca un objeto bajo la bola y simula un tick con player como
+// último en tocarla.
+func pickUp(st *pb.GameState, kind pb.PowerUpKind, player int32) {
+	st.LastHit = player
+	st.Items = []*pb.PowerUp{{Kind: kind, Pos: &pb.Vector{X: st.Ball.X, Y: st.Ball.Y}}}
+	vel := Velocity{}
+	Step(st, &vel, Classic)
+}
+
+func TestPowerUpEffects(t *testing.T) {
+	st := NewState("A")
+	EnablePowerUps(st, 1)
+
+	pickUp(st, pb.PowerUpKind_BIG_PADDLE, 1)
+	if len(st.Items) != 0 || PaddleScale(st, 1) != bigPaddle || PaddleScale(st, 2) != 1 {
+		t.Fatalf("pala grande: objetos %v, efectos %v", st.Items, st.Effects)
+	}
+
+	// Los controles invertidos son para el rival de quien lo recoge
+	pickUp(st, pb.PowerUpKind_REVERSE, 1)
+	y := st.Paddle2.Y
+	ApplyMove(st, 2, -1, Classic)
+	if st.Paddle2.Y <= y {
+		t.Fatalf("con los controles invertidos UP sube: %v -> %v", y, st.Paddle2.Y)
+	}
+
+	// Los efectos se agotan
+	vel := Velocity{}
+	for i := 0; i < EffectTicks; i++ {
+		Step(st, &vel, Classic)
+	}
+	if len(st.Effects) != 0 {
+		t.Fatalf("efectos tras %d ticks: %v", EffectTicks, st.Effects)
+	}
+}
+
+func TestMultiBall(t *testing.T) {
+	st := NewState("A")
+	EnablePowerUps(st, 1)
+	pickUp(st, pb.PowerUpKind_MULTI_BALL, 2)
+	if len(st.Balls) != 2 || st.Balls[0].VelX >= 0 || st.Balls[0].Id == st.Balls[1].Id || !st.Balls[0].Transient {
+		t.Fatalf("bolas adicionales: %v", st.Balls)
+	}
+	if MultiBall(st) {
+		t.Fatal("las bolas adicionales no hacen de la partida una de varias bolas")
+	}
+
+	// Sin nadie que las pare, ambas marcan en el campo del jugador 1 y
+	// desaparecen; la principal sigue quieta
+	st.Paddle1.Y = 0
+	vel := Velocity{}
+	for i := 0; i < 200 && len(st.Balls) > 0; i++ {
+		Step(st, &vel, Classic)
+	}
+	if len(st.Balls) != 0 || st.Score2 != 2 || st.Ball.X != 0.5 {
+		t.Fatalf("bolas %v, marcador %d-%d", st.Balls, st.Score1, st.Score2)
+	}
+}
@@ -0,0 +1,254 @@
+// Package sim contiene la física de una partida. La usa el servidor para
+// simular cada sala y el cliente para reproducir partidas grabadas, así que
+// ambos obtienen exactamente el mismo resultado.
+package sim
+
+import pb "JuegoCeN/proto"
+
+// Rules son los parámetros de la simulación. Las posiciones del GameState
+// están normalizadas a [0,1]; los tamaños se dan en píxeles de la pantalla
+// de referencia ScreenW x ScreenH.
+type Rules struct {
+	TickMs      int32   // duración de un tick en milisegundos
+	PaddleDelta float32 // velocidad máxima de la pala por tick
+	BallVelX    float32 // velocidad inicial de la bola por tick
+	BallVelY    float32
+	ScreenW     float32
+	ScreenH     float32
+	PaddleW     float32
+	PaddleH     float32
+	BallRadius  float32
+	Wall        bool // práctica: a la derecha hay una pared en vez de la pala 2
+}
+
+// Classic son las reglas de siempre: ~60 ticks por segundo en 800x600.
+var Classic = Rules{
+	TickMs:      16,
+	PaddleDelta: 0.02,
+	BallVelX:    0.008,
+	BallVelY:    0.012,
+	ScreenW:     800,
+	ScreenH:     600,
+	PaddleW:     10,
+	PaddleH:     80,
+	BallRadius:  8,
+}
+
+// Velocity es la velocidad de la bola por tick.
+type Velocity struct {
+	X, Y float32
+}
+
+// InitialVelocity devuelve la velocidad de saque de las reglas.
+func (r Rules) InitialVelocity() Velocity {
+	return Velocity{X: r.BallVelX, Y: r.BallVelY}
+}
+
+// Move convierte la acción de un GameAction en dirección: -1 sube (o va a
+// la izquierda), 1 baja (o va a la derecha) y 0 no mueve.
+func Move(move string) int32 {
+	switch move {
+	case "UP", "LEFT":
+		return -1
+	case "DOWN", "RIGHT":
+		return 1
+	}
+	return 0 // "NONE" o desconocida: no hacemos nada
+}
+
+// SetInput anota la dirección que mantiene el jugador (1 o 2, o el asiento
+// en una arena); desde el próximo tick su pala avanza PaddleDelta por tick
+// en esa dirección, así que enviar más acciones no la mueve más deprisa.
+// Devuelve si la dirección cambió.
+func SetInput(st *pb.GameState, player, dir int32) bool {
+	dir = clampDir(dir)
+	if player < 1 || player > MaxArenaPlayers || Input(st, player) == dir {
+		return false
+	}
+	for len(st.Inputs) < int(player) {
+		st.Inputs = append(st.Inputs, 0)
+	}
+	st.Inputs[player-1] = dir
+	return true
+}
+
+// Input devuelve la dirección que mantiene el jugador.
+func Input(st *pb.GameState, player int32) int32 {
+	if player < 1 || int(player) > len(st.Inputs) {
+		return 0
+	}
+	return st.Inputs[player-1]
+}
+
+// movePaddles mueve un tick cada pala según la dirección de su jugador.
+func movePaddles(st *pb.GameState, r Rules) {
+	for i, dir := range st.Inputs {
+		if dir != 0 {
+			ApplyMove(st, int32(i+1), dir, r)
+		}
+	}
+}
+
+func clampDir(dir int32) int32 {
+	switch {
+	case dir < -1:
+		return -1
+	case dir > 1:
+		return 1
+	}
+	return dir
+}
+
+// ApplyMove desplaza un tick la pala del jugador (1 o 2, o el asiento en
+// una arena) en la dirección dada, a PaddleDelta como mucho, y limita las
+// palas a [0,1].
+func ApplyMove(st *pb.GameState, player, dir int32, r Rules) {
+	dir = clampDir(dir)
+	if len(st.Paddles) > 0 {
+		applyArenaMove(st, player, dir, r)
+		return
+	}
+	if reversed(st, player) {
+		dir = -dir
+	}
+	delta := float32(dir) * r.PaddleDelta
+	switch player {
+	case 1:
+		st.Paddle1.Y += delta
+	case 2:
+		st.Paddle2.Y += delta
+	}
+
+	// Limitar dentro de [0,1]
+	if st.Paddle1.Y < 0 {
+		st.Paddle1.Y = 0
+	} else if st.Paddle1.Y > 1 {
+		st.Paddle1.Y = 1
+	}
+	if st.Paddle2.Y < 0 {
+		st.Paddle2.Y = 0
+	} else if st.Paddle2.Y > 1 {
+		st.Paddle2.Y = 1
+	}
+}
+
+// Step avanza la simulación un tick: mueve las palas según las entradas de
+// los jugadores (SetInput) y la bola, resuelve rebotes y colisiones con las
+// palas y suma el punto si la bola sale del campo. Las bolas de
+// GameState.Balls llevan su propia velocidad; en el modo de varias bolas
+// vel no se usa.
+func Step(st *pb.GameState, vel *Velocity, r Rules) {
+	movePaddles(st, r)
+	if len(st.Paddles) > 0 {
+		stepArena(st, vel, r)
+		return
+	}
+	if st.Map != nil {
+		stepMap(st.Map)
+	}
+	if MultiBall(st) {
+		stepBalls(st, 1, r)
+		return
+	}
+
+	speed := ballSpeed(st)
+	if scorer := stepBall(st, st.Ball, vel, speed, r); scorer != 0 {
+		// Punto y reinicio
+		if scorer == 1 {
+			st.Score1++
+		} else {
+			st.Score2++
+		}
+		st.Ball.X, st.Ball.Y = 0.5, 0.5
+		st.LastHit = 0
+	}
+	if st.PowerUps {
+		stepPowerUps(st, speed, r)
+	}
+}
+
+// stepBall mueve una bola un tick a la velocidad vel por speed y resuelve
+// rebotes y colisiones con las palas, anotando en LastHit la que la toque.
+// Devuelve el jugador que marca si la bola sale del campo (0 si no).
+func stepBall(st *pb.GameState, ball *pb.Vector, vel *Velocity, speed float32, r Rules) int32 {
+	// --- Normalizaciones [0,1] de los tamaños en píxeles ---
+	padHalfWidth := r.PaddleW / (2 * r.ScreenW) // mitad de ancho de pala
+	ballRadX := r.BallRadius / r.ScreenW        // radio bola en X
+	ballRadY := r.BallRadius / r.ScreenH        // radio bola en Y
+	topLimit := float32(1) - ballRadY           // límite superior
+
+	// 1) Mover la bola
+	fromX, fromY := ball.X, ball.Y
+	ball.X += vel.X * speed
+	ball.Y += vel.Y * speed
+
+	// 2) Rebote en techo/suelo, solo si la bola va hacia la pared: si no,
+	// una bola que empieza fuera de los límites cambiaría de sentido en
+	// cada tick sin volver nunca al campo
+	if (ball.Y <= ballRadY && vel.Y < 0) || (ball.Y >= topLimit && vel.Y > 0) {
+		vel.Y = -vel.Y
+	}
+
+	// Paredes y obstáculos del mapa
+	if st.Map != nil {
+		collideMap(st.Map, ball, fromX, fromY, vel, r)
+	}
+
+	// 3) Colisión pala izquierda (Paddle1.X es el centro; la mitad del
+	// largo incluye el radio de la bola)
+	if vel.X < 0 {
+		leftEdge := st.Paddle1.X + padHalfWidth
+		padHalfHeight := (r.PaddleH*PaddleScale(st, 1)/2 + r.BallRadius) / r.ScreenH
+		dy := ball.Y - st.Paddle1.Y
+		if ball.X-ballRadX <= leftEdge && (dy < padHalfHeight && -dy < padHalfHeight) {
+			// reposiciona justo fuera de la pala
+			ball.X = leftEdge + ballRadX
+			vel.X = -vel.X
+			st.LastHit = 1
+			if r.Wall {
+				st.Score1++ // en la práctica se cuentan las devoluciones
+			}
+		}
+	}
+
+	// 4) Colisión pala derecha, o con la pared de la práctica
+	if r.Wall {
+		if vel.X > 0 && ball.X+ballRadX >= 1 {
+			ball.X = 1 - ballRadX
+			vel.X = -vel.X
+		}
+	} else if vel.X > 0 {
+		rightEdge := st.Paddle2.X - padHalfWidth
+		padHalfHeight := (r.PaddleH*PaddleScale(st, 2)/2 + r.BallRadius) / r.ScreenH
+		dy := ball.Y - st.Paddle2.Y
+		if ball.X+ballRadX >= rightEdge && (dy < padHalfHeight && -dy < padHalfHeight) {
+			ball.X = rightEdge - ballRadX
+			vel.X = -vel.X
+			st.LastHit = 2
+		}
+	}
+
+	// Fuera de la portería el fondo es pared
+	if st.Map != nil {
+		bounceGoalLine(st.Map, ball, vel, r)
+	}
+
+	// 5) ¿Ha salido del campo?
+	switch {
+	case ball.X < 0:
+		return 2
+	case ball.X > 1:
+		return 1
+	}
+	return 0
+}
+
+// NewState devuelve el estado de saque: bola en el centro y palas centradas.
+func NewState(roomCode string) *pb.GameState {
+	return &pb.GameState{
+		RoomCode: roomCode,
+		Ball:     &pb.Vector{X: 0.5, Y: 0.5},
+		Paddle1:  &pb.Vector{X: 0.1, Y: 0.5},
+		Paddle2:  &pb.Vector{X: 0.9, Y: 0.5},
+	}
+}
@@ -0,0 +1,202 @@
+package sim
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	pb "JuegoCeN/proto"
+)
+
+const ticksPerCase = 600
+
+// unit lleva cualquier float32 a [0,1]; NaN e infinitos van al centro.
+func unit(x float32) float32 {
+	f := float64(x)
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0.5
+	}
+	f = math.Abs(f)
+	return float32(f - math.Floor(f))
+}
+
+// speed lleva cualquier float32 a [-0.5,0.5], hasta media pantalla por tick.
+func speed(x float32) float32 {
+	return unit(x) - 0.5
+}
+
+func finite(xs ...float32) bool {
+	for _, x := range xs {
+		if math.IsNaN(float64(x)) || math.IsInf(float64(x), 0) {
+			return false
+		}
+	}
+	return true
+}
+
+func abs32(x float32) float32 {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+// checkGame simula una partida desde el estado dado aplicando las acciones
+// codificadas en inputs (una por tick, en bucle) y comprueba los
+// invariantes tras cada tick.
+func checkGame(t *testing.T, r Rules, st *pb.GameState, vel Velocity, inputs []byte) {
+	t.Helper()
+	ballRadY := r.BallRadius / r.ScreenH
+	topLimit := 1 - ballRadY
+
+	for tick := 0; tick < ticksPerCase; tick++ {
+		if len(inputs) > 0 {
+			b := inputs[tick%len(inputs)]
+			player := int32(1 + b&1)
+			dir := int32(b>>1)%3 - 1
+			ApplyMove(st, player, dir, r)
+			for i, y := range []float32{st.Paddle1.Y, st.Paddle2.Y} {
+				if !(y >= 0 && y <= 1) {
+					t.Fatalf("tick %d: pala %d fuera de [0,1]: %v", tick, i+1, y)
+				}
+			}
+		}
+
+		s1, s2 := st.Score1, st.Score2
+		Step(st, &vel, r)
+
+		if !finite(st.Ball.X, st.Ball.Y, vel.X, vel.Y, st.Paddle1.Y, st.Paddle2.Y) {
+			t.Fatalf("tick %d: valor no finito: bola %v vel %v palas %v %v",
+				tick, st.Ball, vel, st.Paddle1.Y, st.Paddle2.Y)
+		}
+
+		// El marcador sube de uno en uno y la bola vuelve al centro
+		d1, d2 := st.Score1-s1, st.Score2-s2
+		if d1 < 0 || d2 < 0 || d1+d2 > 1 {
+			t.Fatalf("tick %d: marcador %d-%d -> %d-%d", tick, s1, s2, st.Score1, st.Score2)
+		}
+		if d1+d2 == 1 && (st.Ball.X != 0.5 || st.Ball.Y != 0.5) {
+			t.Fatalf("tick %d: tras el punto la bola está en %v", tick, st.Ball)
+		}
+
+		// En horizontal la bola nunca queda fuera: si sale, es punto
+		if !(st.Ball.X >= 0 && st.Ball.X <= 1) {
+			t.Fatalf("tick %d: bola fuera en X: %v", tick, st.Ball.X)
+		}
+		// En vertical puede pasarse de la pared como mucho un tick de
+		// recorrido, y entonces ya vuelve hacia el campo
+		vy := abs32(vel.Y)
+		if st.Ball.Y < -vy || st.Ball.Y > 1+vy {
+			t.Fatalf("tick %d: bola fuera en Y: %v (vel %v)", tick, st.Ball.Y, vel.Y)
+		}
+		if (st.Ball.Y < ballRadY && vel.Y < 0) || (st.Ball.Y > topLimit && vel.Y > 0) {
+			t.Fatalf("tick %d: bola en %v tras la pared alejándose (vel %v)", tick, st.Ball.Y, vel.Y)
+		}
+	}
+}
+
+func FuzzStep(f *testing.F) {
+	c := Classic
+	f.Add(float32(0.5), float32(0.5), c.BallVelX, c.BallVelY, float32(0.5), float32(0.5), []byte{})
+	f.Add(float32(0.5), float32(0.5), c.BallVelX, c.BallVelY, float32(0), float32(1), []byte{0, 1, 4, 5})
+	f.Add(float32(0.999), float32(0.999), float32(0.01), float32(0.01), float32(1), float32(0), []byte{2, 3})
+	f.Add(float32(0), float32(0), float32(-0.49), float32(-0.49), float32(0.5), float32(0.5), []byte{255})
+	f.Add(float32(math.MaxFloat32), float32(-math.MaxFloat32), float32(math.Inf(1)),
+		float32(math.NaN()), float32(1e-38), float32(-1e38), []byte{0, 2, 4})
+
+	f.Fuzz(func(t *testing.T, bx, by, vx, vy, p1, p2 float32, inputs []byte) {
+		st := NewState("FUZZ")
+		st.Ball.X, st.Ball.Y = unit(bx), unit(by)
+		st.Paddle1.Y, st.Paddle2.Y = unit(p1), unit(p2)
+		checkGame(t, Classic, st, Velocity{X: speed(vx), Y: speed(vy)}, inputs)
+	})
+}
+
+// FuzzApplyMove prueba que las palas acaban siempre en [0,1], incluso con
+// posiciones y direcciones absurdas (un fichero de repetición manipulado
+// puede traer cualquier dir).
+func FuzzApplyMove(f *testing.F) {
+	f.Add(float32(0.5), float32(0.5), int32(1), int32(-1))
+	f.Add(float32(0), float32(1), int32(2), int32(1))
+	f.Add(float32(-1e38), float32(1e38), int32(1), int32(math.MaxInt32))
+	f.Add(float32(0.99), float32(0.01), int32(7), int32(math.MinInt32))
+
+	f.Fuzz(func(t *testing.T, y1, y2 float32, player, dir int32) {
+		if !finite(y1, y2) {
+			t.Skip()
+		}
+		st := NewState("FUZZ")
+		st.Paddle1.Y, st.Paddle2.Y = y1, y2
+		ApplyMove(st, player, dir, Classic)
+		if !(st.Paddle1.Y >= 0 && st.Paddle1.Y <= 1 && st.Paddle2.Y >= 0 && st.Paddle2.Y <= 1) {
+			t.Fatalf("palas fuera de [0,1]: %v %v", st.Paddle1.Y, st.Paddle2.Y)
+		}
+	})
+}
+
+func TestHeldInput(t *testing.T) {
+	st := NewState("ABCD")
+	vel := Classic.InitialVelocity()
+	// Repetir la acción o pasar una dirección enorme no acelera la pala
+	for i := 0; i < 10; i++ {
+		SetInput(st, 1, -1)
+	}
+	if SetInput(st, 1, -1000) || Input(st, 1) != -1 {
+		t.Fatalf("entrada = %d", Input(st, 1))
+	}
+	SetInput(st, 2, 1)
+	y1, y2 := st.Paddle1.Y, st.Paddle2.Y
+	for i := 0; i < 3; i++ {
+		Step(st, &vel, Classic)
+		y1, y2 = y1-Classic.PaddleDelta, y2+Classic.PaddleDelta
+	}
+	if st.Paddle1.Y != y1 || st.Paddle2.Y != y2 {
+		t.Fatalf("palas en %v y %v, se esperaban %v y %v", st.Paddle1.Y, st.Paddle2.Y, y1, y2)
+	}
+
+	// Al soltar se para
+	SetInput(st, 1, 0)
+	y := st.Paddle1.Y
+	Step(st, &vel, Classic)
+	if st.Paddle1.Y != y {
+		t.Fatalf("la pala sigue moviéndose: %v -> %v", y, st.Paddle1.Y)
+	}
+	if SetInput(st, 0, 1) || SetInput(st, MaxArenaPlayers+1, 1) {
+		t.Fatal("se aceptó la entrada de un asiento inexistente")
+	}
+}
+
+// TestStepProperties recorre estados y acciones aleatorias con semilla fija,
+// para que go test sin -fuzz cubra algo más que el corpus.
+func TestStepProperties(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 300; i++ {
+		st := NewState("PROP")
+		st.Ball.X, st.Ball.Y = rng.Float32(), rng.Float32()
+		st.Paddle1.Y, st.Paddle2.Y = rng.Float32(), rng.Float32()
+		vel := Velocity{X: rng.Float32() - 0.5, Y: rng.Float32() - 0.5}
+		if i%2 == 0 {
+			vel = Classic.InitialVelocity()
+			if rng.Intn(2) == 0 {
+				vel.X = -vel.X
+			}
+		}
+		inputs := make([]byte, rng.Intn(64))
+		rng.Read(inputs)
+		checkGame(t, Classic, st, vel, inputs)
+	}
+}
+
+// TestBallStuckBeyondWall reproduce el caso que encontró el fuzzer: una
+// bola que empieza pasada la pared cambiaba de sentido en cada tick.
+func TestBallStuckBeyondWall(t *testing.T) {
+	st := NewState("PARED")
+	st.Ball.Y = 0.999
+	vel := Velocity{X: 0.001, Y: 0.01}
+	for i := 0; i < 10; i++ {
+		Step(st, &vel, Classic)
+	}
+	if st.Ball.Y > 0.95 {
+		t.Fatalf("la bola no vuelve al campo: y = %v", st.Ball.Y)
+	}
+}
@@ -0,0 +1,126 @@
+package store
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// record es una línea del fichero: un perfil tocado (ID, Name, At) o una
+// partida (Match).
+type record struct {
+	Type  string     `json:"type"`
+	ID    string     `json:"id,omitempty"`
+	Name  string     `json:"name,omitempty"`
+	At    *time.Time `json:"at,omitempty"`
+	Match *Match     `json:"match,omitempty"`
+}
+
+const (
+	recordProfile = "profile"
+	recordMatch   = "match"
+)
+
+// File es un Store persistente: mantiene todo en memoria y anexa cada
+// cambio a un fichero JSON Lines que se reproduce al abrirlo.
+type File struct {
+	*Memory
+	f *os.File
+	w *bufio.Writer
+}
+
+// OpenFile abre (o crea) el fichero y carga su contenido.
+func OpenFile(path string) (*File, error) {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("store: abriendo %s: %w", path, err)
+	}
+	s := &File{Memory: NewMemory(), f: f, w: bufio.NewWriter(f)}
+	if err := s.load(); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// load reproduce el fichero. Una línea que no se entiende es un error,
+// salvo la última si le falta el salto de línea: es un registro a medio
+// escribir cuando se cayó el servidor, y se trunca para seguir anexando
+// detrás del último completo.
+func (s *File) load() error {
+	rd := bufio.NewReader(s.f)
+	var offset int64
+	for line := 1; ; line++ {
+		data, err := rd.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return fmt.Errorf("store: leyendo %s: %w", s.f.Name(), err)
+		}
+		torn := err == io.EOF
+		if len(bytes.TrimSpace(data)) > 0 {
+			var r record
+			if err := json.Unmarshal(data, &r); err != nil {
+				if torn {
+					return s.f.Truncate(offset)
+				}
+				return fmt.Errorf("store: %s línea %d: %w", s.f.Name(), line, err)
+			}
+			switch {
+			case r.Type == recordProfile && r.ID != "" && r.At != nil:
+				s.touch(r.ID, r.Name, *r.At)
+			case r.Type == recordMatch && r.Match != nil:
+				s.add(*r.Match)
+			}
+			if torn {
+				// Completa, pero sin salto: el siguiente registro va aparte
+				_, err := s.f.Write([]byte{'\n'})
+				return err
+			}
+		}
+		if torn {
+			return nil
+		}
+		offset += int64(len(data))
+	}
+}
+
+// append escribe y sincroniza un registro; requiere s.mu.
+func (s *File) append(r record) error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	s.w.Write(data)
+	s.w.WriteByte('\n')
+	if err := s.w.Flush(); err != nil {
+		return fmt.Errorf("store: escribiendo: %w", err)
+	}
+	return s.f.Sync()
+}
+
+func (s *File) TouchProfile(id, name string, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.touch(id, name, at)
+	return s.append(record{Type: recordProfile, ID: id, Name: name, At: &at})
+}
+
+func (s *File) RecordMatch(m Match) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.add(m)
+	return s.append(record{Type: recordMatch, Match: &m})
+}
+
+func (s *File) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.w.Flush(); err != nil {
+		s.f.Close()
+		return err
+	}
+	return s.f.Close()
+}
@@ -0,0 +1,303 @@
+// Package store guarda los perfiles de jugador y el historial de partidas.
+//
+// Store es la interfaz que usa el servidor; Memory la implementa solo en
+// memoria y File la persiste en un fichero JSON Lines de solo anexado.
+package store
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"JuegoCeN/rating"
+)
+
+// ErrNotFound se devuelve al pedir un jugador que no existe.
+var ErrNotFound = errors.New("store: no encontrado")
+
+// Profile es el perfil persistente de un jugador autenticado. Played, Wins,
+// Losses y Rating se derivan de las partidas registradas.
+type Profile struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	FirstSeen time.Time `json:"first_seen"`
+	LastSeen  time.Time `json:"last_seen"`
+	Played    int       `json:"-"`
+	Wins      int       `json:"-"`
+	Losses    int       `json:"-"`
+	Rating    float64   `json:"-"`
+}
+
+// MatchPlayer identifica a un jugador dentro de una partida.
+type MatchPlayer struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// Match es una partida terminada.
+type Match struct {
+	ID        string        `json:"id"`
+	RoomCode  string        `json:"room_code"`
+	Player1   MatchPlayer   `json:"player1"`
+	Player2   MatchPlayer   `json:"player2"`
+	Score1    int32         `json:"score1"`
+	Score2    int32         `json:"score2"`
+	StartedAt time.Time     `json:"started_at"`
+	Duration  time.Duration `json:"duration"`
+	EndReason string        `json:"end_reason"`
+	// Forfeit es el jugador (1 o 2) que abandonó la partida o fue
+	// expulsado: pierde aunque fuera por delante.
+	Forfeit int `json:"forfeit,omitempty"`
+	// Unrated marca las partidas cortadas antes de terminar sin que nadie
+	// abandonase (por el administrador): cuentan como jugadas, pero sin
+	// ganador ni cambio de puntuación.
+	Unrated bool `json:"unrated,omitempty"`
+}
+
+// Winner devuelve el id del ganador, o "" si hubo empate o no puntúa.
+func (m Match) Winner() string {
+	switch {
+	case m.Unrated:
+		return ""
+	case m.Forfeit == 1:
+		return m.Player2.ID
+	case m.Forfeit == 2:
+		return m.Player1.ID
+	case m.Score1 > m.Score2:
+		return m.Player1.ID
+	case m.Score2 > m.Score1:
+		return m.Player2.ID
+	}
+	return ""
+}
+
+// Store es el almacenamiento que usa el servidor.
+type Store interface {
+	// TouchProfile crea el perfil o actualiza su nombre y última conexión.
+	TouchProfile(id, name string, at time.Time) error
+	// Profile devuelve el perfil con sus estadísticas.
+	Profile(id string) (Profile, error)
+	// RecordMatch guarda una partida terminada.
+	RecordMatch(m Match) error
+	// PlayerMatches devuelve las partidas de un jugador, de la más reciente a
+	// la más antigua, y el total disponible.
+	PlayerMatches(id string, limit, offset int) ([]Match, int, error)
+	// Leaderboard devuelve la clasificación por puntuación de quienes han
+	// jugado desde since (todos si since es cero) y el total de jugadores.
+	Leaderboard(since time.Time, limit, offset int) ([]Standing, int, error)
+	Close() error
+}
+
+// Standing es una fila de la clasificación. Played, Wins y Losses cuentan
+// solo las partidas del periodo pedido; Rating es la puntuación actual.
+type Standing struct {
+	ID     string
+	Name   string
+	Rating float64
+	Played int
+	Wins   int
+	Losses int
+}
+
+// Memory es un Store que solo vive en memoria. También es la base de File.
+type Memory struct {
+	mu       sync.Mutex
+	profiles map[string]*Profile
+	matches  []Match
+	byPlayer map[string][]int // índices en matches
+}
+
+// NewMemory crea un Store vacío en memoria.
+func NewMemory() *Memory {
+	return &Memory{
+		profiles: make(map[string]*Profile),
+		byPlayer: make(map[string][]int),
+	}
+}
+
+func (s *Memory) TouchProfile(id, name string, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.touch(id, name, at)
+	return nil
+}
+
+// touch requiere s.mu.
+func (s *Memory) touch(id, name string, at time.Time) *Profile {
+	p := s.profiles[id]
+	if p == nil {
+		p = &Profile{ID: id, FirstSeen: at, Rating: rating.Initial}
+		s.profiles[id] = p
+	}
+	if name != "" {
+		p.Name = name
+	}
+	if at.After(p.LastSeen) {
+		p.LastSeen = at
+	}
+	return p
+}
+
+func (s *Memory) Profile(id string) (Profile, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	p := s.profiles[id]
+	if p == nil {
+		return Profile{}, ErrNotFound
+	}
+	return *p, nil
+}
+
+func (s *Memory) RecordMatch(m Match) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.add(m)
+	return nil
+}
+
+// add requiere s.mu. Como File reproduce las partidas en el mismo orden,
+// las puntuaciones recalculadas al cargar coinciden con las originales.
+func (s *Memory) add(m Match) {
+	idx := len(s.matches)
+	s.matches = append(s.matches, m)
+
+	winner := m.Winner()
+	end := m.StartedAt.Add(m.Duration)
+	var ps []*Profile
+	for _, mp := range []MatchPlayer{m.Player1, m.Player2} {
+		if mp.ID == "" || (len(ps) == 1 && ps[0].ID == mp.ID) {
+			continue
+		}
+		s.byPlayer[mp.ID] = append(s.byPlayer[mp.ID], idx)
+		p := s.touch(mp.ID, "", end)
+		if p.Name == "" {
+			p.Name = mp.Name
+		}
+		ps = append(ps, p)
+	}
+
+	// Solo puntúan las partidas terminadas entre dos jugadores identificados
+	// distintos; quien abandona pierde
+	if len(ps) == 2 && !m.Unrated {
+		result := rating.Result(m.Score1, m.Score2)
+		switch m.Forfeit {
+		case 1:
+			result = 0
+		case 2:
+			result = 1
+		}
+		ps[0].Rating, ps[1].Rating = rating.Update(ps[0].Rating, ps[1].Rating,
+			ps[0].Played, ps[1].Played, result)
+	}
+
+	for _, p := range ps {
+		p.Played++
+		switch winner {
+		case "":
+		case p.ID:
+			p.Wins++
+		default:
+			p.Losses++
+		}
+	}
+}
+
+func (s *Memory) PlayerMatches(id string, limit, offset int) ([]Match, int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	idxs := s.byPlayer[id]
+	total := len(idxs)
+	out := make([]Match, 0, total)
+	for i := total - 1; i >= 0; i-- {
+		out = append(out, s.matches[idxs[i]])
+	}
+	// Las partidas se anexan al terminar; ordenamos por inicio por si acaso
+	sort.SliceStable(out, func(i, j int) bool {
+		return out[i].StartedAt.After(out[j].StartedAt)
+	})
+
+	if offset < 0 {
+		offset = 0
+	}
+	if offset > len(out) {
+		offset = len(out)
+	}
+	out = out[offset:]
+	if limit > 0 && limit < len(out) {
+		out = out[:limit]
+	}
+	return out, total, nil
+}
+
+func (s *Memory) Leaderboard(since time.Time, limit, offset int) ([]Standing, int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var out []Standing
+	if since.IsZero() {
+		for _, p := range s.profiles {
+			if p.Played > 0 {
+				out = append(out, Standing{ID: p.ID, Name: p.Name, Rating: p.Rating,
+					Played: p.Played, Wins: p.Wins, Losses: p.Losses})
+			}
+		}
+	} else {
+		rows := make(map[string]*Standing)
+		for _, m := range s.matches {
+			if m.StartedAt.Before(since) {
+				continue
+			}
+			winner := m.Winner()
+			for _, mp := range []MatchPlayer{m.Player1, m.Player2} {
+				p := s.profiles[mp.ID]
+				if p == nil {
+					continue
+				}
+				row := rows[mp.ID]
+				if row == nil {
+					row = &Standing{ID: p.ID, Name: p.Name, Rating: p.Rating}
+					rows[mp.ID] = row
+				}
+				row.Played++
+				switch winner {
+				case "":
+				case mp.ID:
+					row.Wins++
+				default:
+					row.Losses++
+				}
+			}
+		}
+		for _, row := range rows {
+			out = append(out, *row)
+		}
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		if a.Rating != b.Rating {
+			return a.Rating > b.Rating
+		}
+		if a.Wins != b.Wins {
+			return a.Wins > b.Wins
+		}
+		return a.ID < b.ID
+	})
+
+	total := len(out)
+	if offset < 0 {
+		offset = 0
+	}
+	if offset > total {
+		offset = total
+	}
+	out = out[offset:]
+	if limit > 0 && limit < len(out) {
+		out = out[:limit]
+	}
+	return out, total, nil
+}
+
+func (s *Memory) Close() error { return nil }
@@ -0,0 +1,255 @@
+package store
+
+import (
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+var t0 = time.Date(2025, 5, 1, 12, 0, 0, 0, time.UTC)
+
+func match(id string, p1, p2 string, s1, s2 int32, start time.Time) Match {
+	return Match{
+		ID:        id,
+		RoomCode:  "ABCD",
+		Player1:   MatchPlayer{ID: p1, Name: "n-" + p1},
+		Player2:   MatchPlayer{ID: p2, Name: "n-" + p2},
+		Score1:    s1,
+		Score2:    s2,
+		StartedAt: start,
+		Duration:  time.Minute,
+	}
+}
+
+// fill registra tres partidas: ana gana a bea, empatan, y bea gana a carlos.
+func fill(t *testing.T, s Store) {
+	t.Helper()
+	if err := s.TouchProfile("ana", "Ana", t0); err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range []Match{
+		match("m1", "ana", "bea", 5, 3, t0),
+		match("m2", "bea", "ana", 2, 2, t0.Add(time.Hour)),
+		match("m3", "bea", "carlos", 4, 1, t0.Add(2*time.Hour)),
+	} {
+		if err := s.RecordMatch(m); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func checkStats(t *testing.T, s Store, id string, played, wins, losses int) {
+	t.Helper()
+	p, err := s.Profile(id)
+	if err != nil {
+		t.Fatalf("Profile(%s): %v", id, err)
+	}
+	if p.Played != played || p.Wins != wins || p.Losses != losses {
+		t.Fatalf("%s: jugadas/ganadas/perdidas = %d/%d/%d, se esperaba %d/%d/%d",
+			id, p.Played, p.Wins, p.Losses, played, wins, losses)
+	}
+}
+
+func TestMemoryProfilesAndHistory(t *testing.T) {
+	s := NewMemory()
+	fill(t, s)
+
+	checkStats(t, s, "ana", 2, 1, 0)
+	checkStats(t, s, "bea", 3, 1, 1)
+	checkStats(t, s, "carlos", 1, 0, 1)
+
+	p, _ := s.Profile("ana")
+	if p.Name != "Ana" || !p.FirstSeen.Equal(t0) {
+		t.Fatalf("perfil de ana = %+v", p)
+	}
+	if c, _ := s.Profile("carlos"); c.Name != "n-carlos" {
+		t.Fatalf("el nombre de carlos debería salir de la partida, es %q", c.Name)
+	}
+	if _, err := s.Profile("nadie"); !errors.Is(err, ErrNotFound) {
+		t.Fatalf("err = %v, se esperaba ErrNotFound", err)
+	}
+
+	// Elo: ana 1520 tras ganar, y el empate con bea (1495.xx) la baja algo
+	if p.Rating <= 1500 || p.Rating >= 1520 {
+		t.Fatalf("rating de ana = %v", p.Rating)
+	}
+	if c, _ := s.Profile("carlos"); c.Rating >= 1500 {
+		t.Fatalf("carlos perdió y su rating es %v", c.Rating)
+	}
+
+	ms, total, err := s.PlayerMatches("bea", 2, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 3 || len(ms) != 2 || ms[0].ID != "m3" || ms[1].ID != "m2" {
+		t.Fatalf("primera página de bea = %v (total %d)", ids(ms), total)
+	}
+	ms, _, _ = s.PlayerMatches("bea", 2, 2)
+	if len(ms) != 1 || ms[0].ID != "m1" {
+		t.Fatalf("segunda página de bea = %v", ids(ms))
+	}
+	ms, _, _ = s.PlayerMatches("bea", 0, 10)
+	if len(ms) != 0 {
+		t.Fatalf("offset fuera de rango devolvió %v", ids(ms))
+	}
+}
+
+func TestForfeitAndUnrated(t *testing.T) {
+	s := NewMemory()
+	// ana se va cuando ganaba 3-0: pierde ella
+	left := match("m1", "ana", "bea", 3, 0, t0)
+	left.Forfeit = 1
+	// Cortada por el administrador: cuenta como jugada, sin más
+	cut := match("m2", "ana", "bea", 5, 0, t0.Add(time.Hour))
+	cut.Unrated = true
+	for _, m := range []Match{left, cut} {
+		if err := s.RecordMatch(m); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if left.Winner() != "bea" || cut.Winner() != "" {
+		t.Fatalf("ganadores %q y %q", left.Winner(), cut.Winner())
+	}
+	checkStats(t, s, "ana", 2, 0, 1)
+	checkStats(t, s, "bea", 2, 1, 0)
+	a, _ := s.Profile("ana")
+	b, _ := s.Profile("bea")
+	if a.Rating >= 1500 || b.Rating <= 1500 || a.Rating+b.Rating != 3000 {
+		t.Fatalf("ratings ana %v, bea %v", a.Rating, b.Rating)
+	}
+}
+
+func TestLeaderboard(t *testing.T) {
+	s := NewMemory()
+	fill(t, s)
+
+	rows, total, err := s.Leaderboard(time.Time{}, 2, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 3 || len(rows) != 2 {
+		t.Fatalf("total %d, filas %d", total, len(rows))
+	}
+	for i := 1; i < len(rows); i++ {
+		if rows[i].Rating > rows[i-1].Rating {
+			t.Fatalf("clasificación desordenada: %+v", rows)
+		}
+	}
+	last, _, _ := s.Leaderboard(time.Time{}, 2, 2)
+	if len(last) != 1 || last[0].ID != "carlos" {
+		t.Fatalf("última página = %+v", last)
+	}
+
+	// Desde la segunda partida: ana solo tiene el empate
+	rows, total, _ = s.Leaderboard(t0.Add(30*time.Minute), 0, 0)
+	if total != 3 {
+		t.Fatalf("total del periodo = %d", total)
+	}
+	for _, r := range rows {
+		if r.ID == "ana" && (r.Played != 1 || r.Wins != 0 || r.Losses != 0) {
+			t.Fatalf("ana en el periodo = %+v", r)
+		}
+	}
+	rows, total, _ = s.Leaderboard(t0.Add(90*time.Minute), 0, 0)
+	if total != 2 {
+		t.Fatalf("solo bea y carlos jugaron en la última hora, total = %d (%+v)", total, rows)
+	}
+}
+
+func TestFilePersists(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "partidas.jsonl")
+	s, err := OpenFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fill(t, s)
+	if err := s.TouchProfile("ana", "Ana María", t0.Add(3*time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err = OpenFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+
+	checkStats(t, s, "ana", 2, 1, 0)
+	checkStats(t, s, "bea", 3, 1, 1)
+	mem := NewMemory()
+	fill(t, mem)
+	for _, id := range []string{"ana", "bea", "carlos"} {
+		a, _ := s.Profile(id)
+		b, _ := mem.Profile(id)
+		if math.Abs(a.Rating-b.Rating) > 1e-9 {
+			t.Fatalf("rating recargado de %s = %v, en memoria %v", id, a.Rating, b.Rating)
+		}
+	}
+
+	p, _ := s.Profile("ana")
+	if p.Name != "Ana María" || !p.LastSeen.Equal(t0.Add(3*time.Hour)) {
+		t.Fatalf("perfil recargado = %+v", p)
+	}
+	ms, total, _ := s.PlayerMatches("carlos", 10, 0)
+	if total != 1 || ms[0].Score1 != 4 || ms[0].Duration != time.Minute {
+		t.Fatalf("partida recargada = %+v", ms)
+	}
+}
+
+func TestFileRejectsCorruptLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "partidas.jsonl")
+	data := "{\"type\":\"match\"\n" + `{"type":"profile","id":"ana","at":"2024-01-01T00:00:00Z"}` + "\n"
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := OpenFile(path); err == nil {
+		t.Fatal("una línea corrupta en medio debería impedir abrir el fichero")
+	}
+}
+
+func TestFileDropsTornLastLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "partidas.jsonl")
+	s, err := OpenFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.RecordMatch(match("m1", "ana", "bea", 3, 1, t0))
+	s.Close()
+
+	// El servidor se cayó a mitad de anexar un registro
+	f, _ := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0)
+	f.WriteString(`{"type":"match","match":{"id":"m2","pla`)
+	f.Close()
+
+	s, err = OpenFile(path)
+	if err != nil {
+		t.Fatalf("con la última línea a medias: %v", err)
+	}
+	s.RecordMatch(match("m3", "ana", "bea", 0, 3, t0.Add(time.Hour)))
+	s.Close()
+
+	// El registro roto se ha truncado y el nuevo va detrás del completo
+	s, err = OpenFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer s.Close()
+	ms, total, _ := s.PlayerMatches("ana", 10, 0)
+	if total != 2 || strings.Join(ids(ms), ",") != "m3,m1" {
+		t.Fatalf("partidas = %v", ids(ms))
+	}
+}
+
+func ids(ms []Match) []string {
+	out := make([]string, len(ms))
+	for i, m := range ms {
+		out[i] = m.ID
+	}
+	return out
+}
@@ -0,0 +1,118 @@
+// Package tlsutil construye las credenciales TLS que comparten el servidor,
+// el cliente y las herramientas de línea de comandos.
+package tlsutil
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// Config describe los ficheros PEM de una conexión TLS.
+//
+// En el servidor, CertFile/KeyFile son su certificado y CAFile, si se indica,
+// activa TLS mutuo: solo se aceptan clientes firmados por esa CA.
+//
+// En el cliente, CAFile fija la CA con la que se valida al servidor (en vez
+// de las raíces del sistema), ServerName sustituye al nombre esperado en el
+// certificado y CertFile/KeyFile son el certificado de cliente para mTLS.
+type Config struct {
+	CertFile   string
+	KeyFile    string
+	CAFile     string
+	ServerName string
+}
+
+// ServerEnabled indica si el servidor debe escuchar con TLS: basta con
+// cualquiera de los ficheros, de modo que una CA de clientes sin par
+// certificado/clave hace fallar ServerTLS en vez de dejar el servidor en
+// claro.
+func (c Config) ServerEnabled() bool {
+	return c.CertFile != "" || c.KeyFile != "" || c.CAFile != ""
+}
+
+// ServerTLS devuelve la configuración TLS del servidor.
+func (c Config) ServerTLS() (*tls.Config, error) {
+	if c.CertFile == "" || c.KeyFile == "" {
+		return nil, errors.New("tls: hacen falta certificado y clave del servidor")
+	}
+	cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("tls: cargando par certificado/clave: %w", err)
+	}
+	cfg := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if c.CAFile != "" {
+		pool, err := loadPool(c.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		cfg.ClientCAs = pool
+		cfg.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+	return cfg, nil
+}
+
+// ServerCredentials envuelve ServerTLS para grpc.Creds.
+func (c Config) ServerCredentials() (credentials.TransportCredentials, error) {
+	cfg, err := c.ServerTLS()
+	if err != nil {
+		return nil, err
+	}
+	return credentials.NewTLS(cfg), nil
+}
+
+// ClientTLS devuelve la configuración TLS del cliente.
+func (c Config) ClientTLS() (*tls.Config, error) {
+	cfg := &tls.Config{
+		ServerName: c.ServerName,
+		MinVersion: tls.VersionTLS12,
+	}
+	if c.CAFile != "" {
+		pool, err := loadPool(c.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		cfg.RootCAs = pool
+	}
+	if c.CertFile != "" || c.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(c.CertFile, c.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("tls: cargando certificado de cliente: %w", err)
+		}
+		cfg.Certificates = []tls.Certificate{cert}
+	}
+	return cfg, nil
+}
+
+// ClientCredentials devuelve credenciales TLS si enabled es true o si se ha
+// configurado algún fichero; en otro caso, credenciales sin cifrar.
+func (c Config) ClientCredentials(enabled bool) (credentials.TransportCredentials, error) {
+	if !enabled && c == (Config{}) {
+		return insecure.NewCredentials(), nil
+	}
+	cfg, err := c.ClientTLS()
+	if err != nil {
+		return nil, err
+	}
+	return credentials.NewTLS(cfg), nil
+}
+
+func loadPool(caFile string) (*x509.CertPool, error) {
+	pem, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, fmt.Errorf("tls: leyendo CA: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(pem) {
+		return nil, fmt.Errorf("tls: %s no contiene certificados PEM", caFile)
+	}
+	return pool, nil
+}
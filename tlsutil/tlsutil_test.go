@@ -0,0 +1,225 @@
+package tlsutil
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// testCA es una CA autofirmada generada para cada test.
+type testCA struct {
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	file string
+}
+
+func newTestCA(t *testing.T, dir, name string) *testCA {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(dir, name+".pem")
+	writePEM(t, file, "CERTIFICATE", der)
+	return &testCA{cert: cert, key: key, file: file}
+}
+
+// issue firma un certificado hoja y devuelve las rutas de certificado y clave.
+func (ca *testCA) issue(t *testing.T, dir, name string, client bool, dnsNames ...string) (string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	usage := x509.ExtKeyUsageServerAuth
+	if client {
+		usage = x509.ExtKeyUsageClientAuth
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+		DNSNames:     dnsNames,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, &key.PublicKey, ca.key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certFile := filepath.Join(dir, name+".pem")
+	keyFile := filepath.Join(dir, name+"-key.pem")
+	writePEM(t, certFile, "CERTIFICATE", der)
+	writePEM(t, keyFile, "EC PRIVATE KEY", keyDER)
+	return certFile, keyFile
+}
+
+func writePEM(t *testing.T, file, typ string, der []byte) {
+	t.Helper()
+	data := pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: der})
+	if err := os.WriteFile(file, data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// startServer levanta un servidor gRPC con el servicio de salud estándar.
+func startServer(t *testing.T, cfg Config) string {
+	t.Helper()
+	creds, err := cfg.ServerCredentials()
+	if err != nil {
+		t.Fatal(err)
+	}
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := grpc.NewServer(grpc.Creds(creds))
+	healthpb.RegisterHealthServer(srv, health.NewServer())
+	go srv.Serve(lis)
+	t.Cleanup(srv.Stop)
+	return lis.Addr().String()
+}
+
+// check hace una llamada real para forzar el handshake.
+func check(t *testing.T, addr string, cfg Config) error {
+	t.Helper()
+	creds, err := cfg.ClientCredentials(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	return err
+}
+
+func TestTLSWithPinnedCA(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t, dir, "ca")
+	cert, key := ca.issue(t, dir, "server", false, "localhost")
+	addr := startServer(t, Config{CertFile: cert, KeyFile: key})
+
+	if err := check(t, addr, Config{CAFile: ca.file, ServerName: "localhost"}); err != nil {
+		t.Fatalf("con la CA fijada la conexión debería funcionar: %v", err)
+	}
+	if err := check(t, addr, Config{ServerName: "localhost"}); err == nil {
+		t.Fatal("con las raíces del sistema el certificado autofirmado debería rechazarse")
+	}
+}
+
+func TestTLSServerNameOverride(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t, dir, "ca")
+	cert, key := ca.issue(t, dir, "server", false, "pingpong.lan")
+	addr := startServer(t, Config{CertFile: cert, KeyFile: key})
+
+	if err := check(t, addr, Config{CAFile: ca.file}); err == nil {
+		t.Fatal("sin override el nombre 127.0.0.1 no coincide con el certificado")
+	}
+	if err := check(t, addr, Config{CAFile: ca.file, ServerName: "pingpong.lan"}); err != nil {
+		t.Fatalf("con override de nombre debería conectar: %v", err)
+	}
+}
+
+func TestMutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCA(t, dir, "ca")
+	rogue := newTestCA(t, dir, "rogue")
+	srvCert, srvKey := ca.issue(t, dir, "server", false, "localhost")
+	goodCert, goodKey := ca.issue(t, dir, "client", true)
+	badCert, badKey := rogue.issue(t, dir, "intruso", true)
+	addr := startServer(t, Config{CertFile: srvCert, KeyFile: srvKey, CAFile: ca.file})
+
+	base := Config{CAFile: ca.file, ServerName: "localhost"}
+	if err := check(t, addr, base); err == nil {
+		t.Fatal("sin certificado de cliente el servidor debería rechazar la conexión")
+	}
+
+	bad := base
+	bad.CertFile, bad.KeyFile = badCert, badKey
+	if err := check(t, addr, bad); err == nil {
+		t.Fatal("un certificado de otra CA debería rechazarse")
+	}
+
+	good := base
+	good.CertFile, good.KeyFile = goodCert, goodKey
+	if err := check(t, addr, good); err != nil {
+		t.Fatalf("el cliente con certificado válido debería conectar: %v", err)
+	}
+}
+
+func TestClientCredentialsInsecureByDefault(t *testing.T) {
+	creds, err := Config{}.ClientCredentials(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p := creds.Info().SecurityProtocol; p != "insecure" {
+		t.Fatalf("protocolo = %q, se esperaba insecure", p)
+	}
+
+	creds, err = Config{ServerName: "pingpong.lan"}.ClientCredentials(false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p := creds.Info().SecurityProtocol; p != "tls" {
+		t.Fatalf("con opciones TLS el protocolo = %q, se esperaba tls", p)
+	}
+}
+
+func TestServerTLSRequiresKeyPair(t *testing.T) {
+	if _, err := (Config{CertFile: "server.pem"}).ServerTLS(); err == nil {
+		t.Fatal("sin clave debería fallar")
+	}
+	if (Config{}).ServerEnabled() {
+		t.Fatal("sin ficheros el servidor no debería activar TLS")
+	}
+	// Pedir TLS mutuo sin certificado no deja el servidor en claro
+	onlyCA := Config{CAFile: "ca.pem"}
+	if !onlyCA.ServerEnabled() {
+		t.Fatal("con -tls-client-ca el servidor debería intentar TLS")
+	}
+	if _, err := onlyCA.ServerTLS(); err == nil {
+		t.Fatal("una CA de clientes sin certificado ni clave debería fallar")
+	}
+}
@@ -0,0 +1,488 @@
+// Package tournament genera y hace avanzar cuadros de torneo: eliminación
+// simple, doble eliminación y liga (todos contra todos). No sabe nada de
+// salas ni de red: el servidor le pide los partidos listos para jugar y le
+// comunica los resultados.
+package tournament
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// Format es el tipo de cuadro.
+type Format int
+
+const (
+	SingleElimination Format = iota
+	DoubleElimination
+	RoundRobin
+)
+
+func (f Format) String() string {
+	switch f {
+	case SingleElimination:
+		return "eliminación simple"
+	case DoubleElimination:
+		return "doble eliminación"
+	case RoundRobin:
+		return "liga"
+	}
+	return fmt.Sprintf("Format(%d)", int(f))
+}
+
+// Bracket indica a qué parte del cuadro pertenece un partido.
+type Bracket int
+
+const (
+	Winners Bracket = iota // cuadro principal (o único, en eliminación simple)
+	Losers                 // cuadro de perdedores de la doble eliminación
+	Final                  // gran final de la doble eliminación
+	League                 // jornada de liga
+)
+
+// MatchState es el estado de un partido.
+type MatchState int
+
+const (
+	Waiting MatchState = iota // falta saber algún jugador
+	Ready                     // ambos jugadores conocidos
+	Playing
+	Done
+)
+
+// NoPlayer marca un hueco vacío: un bye o un jugador aún por decidir.
+const NoPlayer = -1
+
+// Player es un inscrito. Al generar el cuadro se ordenan por puntuación, y
+// su posición es el cabeza de serie (0 = el mejor).
+type Player struct {
+	ID     string
+	Name   string
+	Rating float64
+}
+
+// sourceKind dice de dónde sale el jugador de un hueco.
+type sourceKind int
+
+const (
+	fromSeed sourceKind = iota
+	fromWinner
+	fromLoser
+)
+
+type source struct {
+	kind  sourceKind
+	index int // cabeza de serie (NoPlayer = bye) o partido de origen
+}
+
+// Match es un partido del cuadro. Players son índices en Tournament.Players
+// (NoPlayer si el hueco está vacío).
+type Match struct {
+	ID       int
+	Bracket  Bracket
+	Round    int
+	Players  [2]int
+	State    MatchState
+	Winner   int // índice del ganador; NoPlayer si nadie (bye doble)
+	Bye      bool
+	Walkover bool // ganado sin jugar porque el rival no se presentó
+	Score    [2]int32
+	RoomCode string
+
+	src      [2]source
+	resolved [2]bool
+	reset    bool // segunda gran final: solo se juega si gana el de perdedores
+}
+
+// Loser devuelve el perdedor de un partido terminado, o NoPlayer.
+func (m *Match) Loser() int {
+	if m.State != Done || m.Winner == NoPlayer {
+		return NoPlayer
+	}
+	if m.Players[0] == m.Winner {
+		return m.Players[1]
+	}
+	return m.Players[0]
+}
+
+// Tournament es un cuadro en juego.
+type Tournament struct {
+	Format  Format
+	Players []Player
+	Matches []*Match
+}
+
+var (
+	ErrTooFewPlayers = errors.New("hacen falta al menos dos jugadores")
+	ErrNoMatch       = errors.New("el partido no existe")
+	ErrNotReady      = errors.New("el partido no está listo")
+)
+
+// New genera el cuadro para los jugadores dados, ordenados de mejor a peor
+// puntuación (a igualdad, por orden de inscripción). Los partidos con bye
+// se resuelven solos.
+func New(format Format, players []Player) (*Tournament, error) {
+	if len(players) < 2 {
+		return nil, ErrTooFewPlayers
+	}
+	t := &Tournament{Format: format, Players: append([]Player(nil), players...)}
+	sort.SliceStable(t.Players, func(i, j int) bool {
+		return t.Players[i].Rating > t.Players[j].Rating
+	})
+	switch format {
+	case SingleElimination:
+		t.buildElimination(false)
+	case DoubleElimination:
+		t.buildElimination(true)
+	case RoundRobin:
+		t.buildRoundRobin()
+	default:
+		return nil, fmt.Errorf("formato desconocido: %d", format)
+	}
+	t.resolve()
+	return t, nil
+}
+
+func (t *Tournament) add(b Bracket, round int, a, c source) int {
+	m := &Match{ID: len(t.Matches), Bracket: b, Round: round, src: [2]source{a, c},
+		Players: [2]int{NoPlayer, NoPlayer}, Winner: NoPlayer}
+	t.Matches = append(t.Matches, m)
+	return m.ID
+}
+
+// seedOrder devuelve el orden de los cabezas de serie en un cuadro de size
+// (potencia de dos) para que el 1 y el 2 solo se crucen en la final.
+func seedOrder(size int) []int {
+	order := []int{0}
+	for n := 1; n < size; n *= 2 {
+		next := make([]int, 0, 2*n)
+		for _, s := range order {
+			next = append(next, s, 2*n-1-s)
+		}
+		order = next
+	}
+	return order
+}
+
+func (t *Tournament) buildElimination(double bool) {
+	size := 1
+	for size < len(t.Players) {
+		size *= 2
+	}
+	seed := func(s int) source {
+		if s >= len(t.Players) {
+			return source{fromSeed, NoPlayer}
+		}
+		return source{fromSeed, s}
+	}
+
+	// Cuadro principal; losers guarda los partidos de cada ronda
+	order := seedOrder(size)
+	var rounds [][]int
+	var cur []int
+	for i := 0; i < size; i += 2 {
+		cur = append(cur, t.add(Winners, 1, seed(order[i]), seed(order[i+1])))
+	}
+	rounds = append(rounds, cur)
+	for r := 2; len(cur) > 1; r++ {
+		var next []int
+		for i := 0; i < len(cur); i += 2 {
+			next = append(next, t.add(Winners, r,
+				source{fromWinner, cur[i]}, source{fromWinner, cur[i+1]}))
+		}
+		rounds = append(rounds, next)
+		cur = next
+	}
+	if !double {
+		return
+	}
+
+	// Cuadro de perdedores: los que caen en la ronda 1 se cruzan entre sí;
+	// después, en cada ronda los supervivientes reciben a los que caen del
+	// principal y, si quedan varios, se cruzan entre ellos
+	losersOf := func(ms []int) []source {
+		out := make([]source, len(ms))
+		for i, m := range ms {
+			out[i] = source{fromLoser, m}
+		}
+		return out
+	}
+	lr := 0
+	pairUp := func(srcs []source) []source {
+		lr++
+		var out []source
+		for i := 0; i+1 < len(srcs); i += 2 {
+			out = append(out, source{fromWinner, t.add(Losers, lr, srcs[i], srcs[i+1])})
+		}
+		return out
+	}
+	alive := losersOf(rounds[0])
+	if len(alive) > 1 {
+		alive = pairUp(alive)
+	}
+	for r := 1; r < len(rounds); r++ {
+		dropped := losersOf(rounds[r])
+		// En rondas alternas se invierte el orden para retrasar revanchas
+		if r%2 == 1 {
+			for i, j := 0, len(dropped)-1; i < j; i, j = i+1, j-1 {
+				dropped[i], dropped[j] = dropped[j], dropped[i]
+			}
+		}
+		lr++
+		var next []source
+		for i := range alive {
+			next = append(next, source{fromWinner, t.add(Losers, lr, alive[i], dropped[i])})
+		}
+		alive = next
+		if len(alive) > 1 {
+			alive = pairUp(alive)
+		}
+	}
+
+	// Gran final y, si gana quien viene de perdedores, desempate
+	final := rounds[len(rounds)-1][0]
+	gf := t.add(Final, 1, source{fromWinner, final}, alive[0])
+	reset := t.add(Final, 2, source{fromWinner, gf}, source{fromLoser, gf})
+	t.Matches[reset].reset = true
+}
+
+// buildRoundRobin reparte las jornadas con el método del círculo.
+func (t *Tournament) buildRoundRobin() {
+	n := len(t.Players)
+	slots := make([]int, n)
+	for i := range slots {
+		slots[i] = i
+	}
+	if n%2 == 1 {
+		slots = append(slots, NoPlayer)
+	}
+	m := len(slots)
+	for r := 1; r < m; r++ {
+		for i := 0; i < m/2; i++ {
+			a, b := slots[i], slots[m-1-i]
+			if a != NoPlayer && b != NoPlayer {
+				t.add(League, r, source{fromSeed, a}, source{fromSeed, b})
+			}
+		}
+		// Rotar todos menos el primero
+		last := slots[m-1]
+		copy(slots[2:], slots[1:m-1])
+		slots[1] = last
+	}
+}
+
+// value devuelve el jugador que sale de src y si ya se sabe.
+func (t *Tournament) value(src source) (int, bool) {
+	switch src.kind {
+	case fromSeed:
+		return src.index, true
+	case fromWinner, fromLoser:
+		m := t.Matches[src.index]
+		if m.State != Done {
+			return NoPlayer, false
+		}
+		if src.kind == fromWinner {
+			return m.Winner, true
+		}
+		return m.Loser(), true
+	}
+	return NoPlayer, false
+}
+
+// resolve rellena los huecos que ya se conocen y da por jugados los
+// partidos con bye, hasta que no cambia nada.
+func (t *Tournament) resolve() {
+	for changed := true; changed; {
+		changed = false
+		for _, m := range t.Matches {
+			if m.State != Waiting {
+				continue
+			}
+			for i := range m.src {
+				if m.resolved[i] {
+					continue
+				}
+				if p, ok := t.value(m.src[i]); ok {
+					m.Players[i], m.resolved[i] = p, true
+					changed = true
+				}
+			}
+			if !m.resolved[0] || !m.resolved[1] {
+				continue
+			}
+			if m.Players[0] != NoPlayer && m.Players[1] != NoPlayer {
+				m.State = Ready
+				continue
+			}
+			// Bye: pasa quien haya, si hay alguien
+			m.State, m.Bye = Done, true
+			m.Winner = m.Players[0]
+			if m.Winner == NoPlayer {
+				m.Winner = m.Players[1]
+			}
+			changed = true
+		}
+	}
+}
+
+// Ready devuelve los partidos listos para empezar.
+func (t *Tournament) Ready() []*Match {
+	var out []*Match
+	for _, m := range t.Matches {
+		if m.State == Ready {
+			out = append(out, m)
+		}
+	}
+	return out
+}
+
+// Start marca el partido como en juego en la sala indicada.
+func (t *Tournament) Start(id int, roomCode string) error {
+	m, err := t.match(id)
+	if err != nil {
+		return err
+	}
+	if m.State != Ready {
+		return ErrNotReady
+	}
+	m.State, m.RoomCode = Playing, roomCode
+	return nil
+}
+
+// Report apunta el resultado de un partido (winner es 0 o 1, el hueco del
+// ganador) y hace avanzar el cuadro.
+func (t *Tournament) Report(id, winner int, score1, score2 int32) error {
+	m, err := t.match(id)
+	if err != nil {
+		return err
+	}
+	if m.State != Ready && m.State != Playing {
+		return ErrNotReady
+	}
+	if winner != 0 && winner != 1 {
+		return fmt.Errorf("hueco de ganador inválido: %d", winner)
+	}
+	m.State, m.Winner, m.Score = Done, m.Players[winner], [2]int32{score1, score2}
+
+	// Si la gran final la gana quien venía invicto, no hay desempate
+	if m.Bracket == Final && !m.reset && winner == 0 {
+		r := t.Matches[len(t.Matches)-1]
+		r.State, r.Bye, r.Winner = Done, true, m.Winner
+		r.resolved = [2]bool{true, true}
+	}
+	t.resolve()
+	return nil
+}
+
+// Walkover da por ganado sin jugar un partido listo al jugador del hueco
+// winner, porque el rival no se presentó.
+func (t *Tournament) Walkover(id, winner int) error {
+	if err := t.Report(id, winner, 0, 0); err != nil {
+		return err
+	}
+	t.Matches[id].Walkover = true
+	return nil
+}
+
+func (t *Tournament) match(id int) (*Match, error) {
+	if id < 0 || id >= len(t.Matches) {
+		return nil, ErrNoMatch
+	}
+	return t.Matches[id], nil
+}
+
+// Done indica si el torneo ha terminado.
+func (t *Tournament) Done() bool {
+	for _, m := range t.Matches {
+		if m.State != Done {
+			return false
+		}
+	}
+	return true
+}
+
+// Champion devuelve el ganador del torneo, o NoPlayer si no ha terminado.
+func (t *Tournament) Champion() int {
+	if !t.Done() {
+		return NoPlayer
+	}
+	if t.Format == RoundRobin {
+		return t.Standings()[0].Player
+	}
+	return t.Matches[len(t.Matches)-1].Winner
+}
+
+// Alive indica si al jugador le quedan partidos por jugar.
+func (t *Tournament) Alive(p int) bool {
+	if t.Done() {
+		return false
+	}
+	switch t.Format {
+	case RoundRobin:
+		for _, m := range t.Matches {
+			if m.State != Done && (m.Players[0] == p || m.Players[1] == p) {
+				return true
+			}
+		}
+		return false
+	case DoubleElimination:
+		return t.losses(p) < 2
+	default:
+		return t.losses(p) < 1
+	}
+}
+
+func (t *Tournament) losses(p int) int {
+	n := 0
+	for _, m := range t.Matches {
+		if !m.Bye && m.Loser() == p {
+			n++
+		}
+	}
+	return n
+}
+
+// Standing es la clasificación de un jugador en la liga.
+type Standing struct {
+	Player               int
+	Played, Wins, Losses int
+	PointsFor, Against   int32
+}
+
+// Standings clasifica por victorias, luego por diferencia de puntos y luego
+// por cabeza de serie. Solo tiene sentido en liga, pero vale para cualquier
+// formato.
+func (t *Tournament) Standings() []Standing {
+	st := make([]Standing, len(t.Players))
+	for i := range st {
+		st[i].Player = i
+	}
+	for _, m := range t.Matches {
+		if m.State != Done || m.Bye {
+			continue
+		}
+		for i, p := range m.Players {
+			s := &st[p]
+			s.Played++
+			s.PointsFor += m.Score[i]
+			s.Against += m.Score[1-i]
+			if m.Winner == p {
+				s.Wins++
+			} else {
+				s.Losses++
+			}
+		}
+	}
+	sort.SliceStable(st, func(i, j int) bool {
+		a, b := st[i], st[j]
+		if a.Wins != b.Wins {
+			return a.Wins > b.Wins
+		}
+		if da, db := a.PointsFor-a.Against, b.PointsFor-b.Against; da != db {
+			return da > db
+		}
+		return a.Player < b.Player
+	})
+	return st
+}
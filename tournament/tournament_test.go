@@ -0,0 +1,218 @@
+package tournament
+
+import (
+	"fmt"
+	"testing"
+)
+
+func players(n int) []Player {
+	ps := make([]Player, n)
+	for i := range ps {
+		// Puntuación decreciente: el jugador i es el cabeza de serie i
+		ps[i] = Player{ID: fmt.Sprintf("p%d", i), Name: fmt.Sprintf("P%d", i), Rating: float64(2000 - i)}
+	}
+	return ps
+}
+
+// playAll juega los partidos listos hasta el final; gana siempre el mejor
+// cabeza de serie salvo que upset diga lo contrario. Devuelve cuántos
+// partidos se jugaron.
+func playAll(t *testing.T, tr *Tournament, upset func(m *Match) bool) int {
+	t.Helper()
+	played := 0
+	for !tr.Done() {
+		ready := tr.Ready()
+		if len(ready) == 0 {
+			t.Fatal("el torneo no ha terminado pero no hay partidos listos")
+		}
+		for _, m := range ready {
+			if err := tr.Start(m.ID, "SALA"); err != nil {
+				t.Fatal(err)
+			}
+			w := 0
+			if m.Players[1] < m.Players[0] {
+				w = 1
+			}
+			if upset != nil && upset(m) {
+				w = 1 - w
+			}
+			if err := tr.Report(m.ID, w, 11, 5); err != nil {
+				t.Fatal(err)
+			}
+			played++
+		}
+	}
+	return played
+}
+
+func TestSingleEliminationSeeds(t *testing.T) {
+	tr, err := New(SingleElimination, players(8))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Primera ronda: 1-8, 4-5, 2-7, 3-6 (en índices desde 0)
+	want := [][2]int{{0, 7}, {3, 4}, {1, 6}, {2, 5}}
+	for i, w := range want {
+		if m := tr.Matches[i]; m.Players != w || m.State != Ready {
+			t.Fatalf("partido %d = %v (%v), se esperaba %v", i, m.Players, m.State, w)
+		}
+	}
+	if n := playAll(t, tr, nil); n != 7 {
+		t.Fatalf("%d partidos, se esperaban 7", n)
+	}
+	if c := tr.Champion(); c != 0 {
+		t.Fatalf("campeón = %d", c)
+	}
+	if tr.Alive(0) || tr.Alive(7) {
+		t.Fatal("con el torneo terminado nadie sigue vivo")
+	}
+}
+
+func TestSingleEliminationByes(t *testing.T) {
+	tr, err := New(SingleElimination, players(5))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Con 5 jugadores en un cuadro de 8 hay 3 byes: los tres mejores pasan
+	// solos, así que 2-3 ya está listo en segunda ronda junto al 4-5
+	ready := tr.Ready()
+	if len(ready) != 2 || ready[0].Players != [2]int{3, 4} || ready[1].Players != [2]int{1, 2} {
+		t.Fatalf("listos = %v y %v", ready[0].Players, ready[len(ready)-1].Players)
+	}
+	if n := playAll(t, tr, nil); n != 4 {
+		t.Fatalf("%d partidos, se esperaban 4", n)
+	}
+	if tr.Champion() != 0 {
+		t.Fatal("el campeón no es el cabeza de serie 1")
+	}
+}
+
+func TestDoubleElimination(t *testing.T) {
+	for n := 2; n <= 9; n++ {
+		t.Run(fmt.Sprint(n), func(t *testing.T) {
+			tr, err := New(DoubleElimination, players(n))
+			if err != nil {
+				t.Fatal(err)
+			}
+			// Sin sorpresas nadie pierde dos veces antes de tiempo y el
+			// invicto gana la gran final sin desempate: 2n-2 partidos
+			if got := playAll(t, tr, nil); got != 2*n-2 {
+				t.Fatalf("%d partidos, se esperaban %d", got, 2*n-2)
+			}
+			if tr.Champion() != 0 {
+				t.Fatalf("campeón = %d", tr.Champion())
+			}
+			for p := 1; p < n; p++ {
+				if tr.losses(p) != 2 {
+					t.Fatalf("el jugador %d tiene %d derrotas", p, tr.losses(p))
+				}
+			}
+		})
+	}
+}
+
+func TestDoubleEliminationReset(t *testing.T) {
+	tr, _ := New(DoubleElimination, players(4))
+	// El cabeza de serie 1 pierde solo la gran final: hay desempate
+	gf := 0
+	n := playAll(t, tr, func(m *Match) bool {
+		if m.Bracket == Final && m.Round == 1 {
+			gf++
+			return true
+		}
+		return false
+	})
+	if gf != 1 || n != 2*4-1 {
+		t.Fatalf("gran final %d veces, %d partidos", gf, n)
+	}
+	// En el desempate vuelve a ganar el mejor (0), que así pierde una sola vez
+	if tr.Champion() != 0 || tr.losses(0) != 1 {
+		t.Fatalf("campeón %d con %d derrotas", tr.Champion(), tr.losses(0))
+	}
+}
+
+func TestDoubleEliminationLosersBracket(t *testing.T) {
+	tr, _ := New(DoubleElimination, players(4))
+	// El 1 cae en la primera ronda y lo gana todo desde perdedores
+	aliveInLosers := false
+	playAll(t, tr, func(m *Match) bool {
+		if m.Bracket == Losers && (m.Players[0] == 0 || m.Players[1] == 0) {
+			aliveInLosers = tr.Alive(0)
+		}
+		return m.Bracket == Winners && m.Round == 1 && m.Players[0] == 0
+	})
+	if !aliveInLosers {
+		t.Fatal("tras la primera derrota el jugador debería seguir vivo")
+	}
+	// Gana la gran final y el desempate
+	if tr.Champion() != 0 || tr.losses(0) != 1 || tr.losses(1) != 2 {
+		t.Fatalf("campeón %d; derrotas %d y %d", tr.Champion(), tr.losses(0), tr.losses(1))
+	}
+}
+
+func TestRoundRobin(t *testing.T) {
+	for n := 2; n <= 7; n++ {
+		tr, err := New(RoundRobin, players(n))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(tr.Matches) != n*(n-1)/2 {
+			t.Fatalf("%d jugadores: %d partidos", n, len(tr.Matches))
+		}
+		// Nadie juega dos veces en la misma jornada ni repite rival
+		seen := map[[2]int]bool{}
+		perRound := map[int]map[int]bool{}
+		for _, m := range tr.Matches {
+			pair := m.Players
+			if pair[0] > pair[1] {
+				pair[0], pair[1] = pair[1], pair[0]
+			}
+			if seen[pair] {
+				t.Fatalf("%d jugadores: %v se repite", n, pair)
+			}
+			seen[pair] = true
+			if perRound[m.Round] == nil {
+				perRound[m.Round] = map[int]bool{}
+			}
+			for _, p := range m.Players {
+				if perRound[m.Round][p] {
+					t.Fatalf("%d jugadores: %d juega dos veces en la jornada %d", n, p, m.Round)
+				}
+				perRound[m.Round][p] = true
+			}
+		}
+		playAll(t, tr, nil)
+		st := tr.Standings()
+		if tr.Champion() != 0 || st[0].Wins != n-1 || st[n-1].Wins != 0 {
+			t.Fatalf("%d jugadores: clasificación %+v", n, st)
+		}
+	}
+}
+
+func TestReportErrors(t *testing.T) {
+	if _, err := New(SingleElimination, players(1)); err != ErrTooFewPlayers {
+		t.Fatalf("err = %v", err)
+	}
+	tr, _ := New(SingleElimination, players(4))
+	final := tr.Matches[len(tr.Matches)-1]
+	if err := tr.Report(final.ID, 0, 11, 0); err != ErrNotReady {
+		t.Fatalf("final sin jugadores: %v", err)
+	}
+	if err := tr.Report(99, 0, 11, 0); err != ErrNoMatch {
+		t.Fatalf("partido inexistente: %v", err)
+	}
+}
+
+func TestWalkover(t *testing.T) {
+	tr, _ := New(SingleElimination, players(2))
+	m := tr.Matches[0]
+	if err := tr.Walkover(m.ID, 1); err != nil {
+		t.Fatal(err)
+	}
+	if !m.Walkover || m.State != Done || tr.Champion() != 1 {
+		t.Fatalf("partido = %+v, campeón %d", m, tr.Champion())
+	}
+	if err := tr.Walkover(m.ID, 0); err != ErrNotReady {
+		t.Fatalf("segunda vez: %v", err)
+	}
+}